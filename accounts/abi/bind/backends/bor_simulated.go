@@ -41,3 +41,8 @@ func (fb *filterBackend) GetBorBlockLogs(ctx context.Context, hash common.Hash)
 func (fb *filterBackend) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) event.Subscription {
 	return fb.bc.SubscribeStateSyncEvent(ch)
 }
+
+// SubscribeFinalizedHeadEvent subscribes to milestone and checkpoint finality events
+func (fb *filterBackend) SubscribeFinalizedHeadEvent(ch chan<- core.FinalizedHeadEvent) event.Subscription {
+	return fb.bc.SubscribeFinalizedHeadEvent(ch)
+}
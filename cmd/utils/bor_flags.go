@@ -68,6 +68,45 @@ var (
 		Usage: "Use child heimdall process to fetch data, Only works when bor.runheimdall is true",
 	}
 
+	// HeimdallArchiveFlag flag for using a local heimdall archive instead of a live heimdall
+	HeimdallArchiveFlag = &cli.StringFlag{
+		Name:  "bor.heimdallarchive",
+		Usage: "Path of a local heimdall archive to verify headers without a live Heimdall",
+		Value: "",
+	}
+
+	// HeimdallArchiveSignerFlag flag for the trusted signer of the heimdall archive
+	HeimdallArchiveSignerFlag = &cli.StringFlag{
+		Name:  "bor.heimdallarchivesigner",
+		Usage: "Address of the trusted signer of the heimdall archive",
+		Value: "",
+	}
+
+	// HeimdallEndpointsFlag flag for additional heimdall endpoints to fail over to
+	HeimdallEndpointsFlag = &cli.StringSliceFlag{
+		Name:  "bor.heimdallendpoints",
+		Usage: "Additional Heimdall endpoints to fail over to (http(s):// for REST, grpc:// for gRPC)",
+	}
+
+	// HeimdallFailoverTimeoutFlag flag for the time after which a heimdall request is failed over
+	HeimdallFailoverTimeoutFlag = &cli.DurationFlag{
+		Name:  "bor.heimdallfailovertimeout",
+		Usage: "Time after which a request to a Heimdall endpoint is failed over to the next one",
+		Value: 30 * time.Second,
+	}
+
+	// HeimdallHedgeDelayFlag flag for the time after which a slow heimdall request is hedged
+	HeimdallHedgeDelayFlag = &cli.DurationFlag{
+		Name:  "bor.heimdallhedgedelay",
+		Usage: "Time after which a slow request is also sent to the next Heimdall endpoint (0 = disabled)",
+	}
+
+	// HeimdallQuorumFlag flag for the number of heimdall endpoints which must agree
+	HeimdallQuorumFlag = &cli.IntFlag{
+		Name:  "bor.heimdallquorum",
+		Usage: "Number of Heimdall endpoints which must agree on spans and milestones (0 = disabled)",
+	}
+
 	// BorFlags all bor related flags
 	BorFlags = []cli.Flag{
 		HeimdallURLFlag,
@@ -78,6 +117,12 @@ var (
 		RunHeimdallFlag,
 		RunHeimdallArgsFlag,
 		UseHeimdallAppFlag,
+		HeimdallArchiveFlag,
+		HeimdallArchiveSignerFlag,
+		HeimdallEndpointsFlag,
+		HeimdallFailoverTimeoutFlag,
+		HeimdallHedgeDelayFlag,
+		HeimdallQuorumFlag,
 	}
 )
 
@@ -91,6 +136,12 @@ func SetBorConfig(ctx *cli.Context, cfg *eth.Config) {
 	cfg.RunHeimdall = ctx.Bool(RunHeimdallFlag.Name)
 	cfg.RunHeimdallArgs = ctx.String(RunHeimdallArgsFlag.Name)
 	cfg.UseHeimdallApp = ctx.Bool(UseHeimdallAppFlag.Name)
+	cfg.HeimdallArchivePath = ctx.String(HeimdallArchiveFlag.Name)
+	cfg.HeimdallArchiveSigner = ctx.String(HeimdallArchiveSignerFlag.Name)
+	cfg.HeimdallEndpoints = ctx.StringSlice(HeimdallEndpointsFlag.Name)
+	cfg.HeimdallFailoverTimeout = ctx.Duration(HeimdallFailoverTimeoutFlag.Name)
+	cfg.HeimdallHedgeDelay = ctx.Duration(HeimdallHedgeDelayFlag.Name)
+	cfg.HeimdallQuorum = ctx.Int(HeimdallQuorumFlag.Name)
 }
 
 // CreateBorEthereum Creates bor ethereum object from eth.Config
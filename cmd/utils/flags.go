@@ -1851,7 +1851,7 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	}
 	if !ctx.Bool(SnapshotFlag.Name) || cfg.SnapshotCache == 0 {
 		// If snap-sync is requested, this flag is also required
-		if cfg.SyncMode == downloader.SnapSync {
+		if cfg.SyncMode == downloader.SnapSync || cfg.SyncMode == downloader.MilestoneSync {
 			if !ctx.Bool(SnapshotFlag.Name) {
 				log.Warn("Snap sync requested, enabling --snapshot")
 			}
@@ -2366,15 +2366,21 @@ func MakeChain(ctx *cli.Context, stack *node.Node, readonly bool) (*core.BlockCh
 	}
 
 	configs := &ethconfig.Config{
-		Genesis:             gspec,
-		HeimdallURL:         ctx.String(HeimdallURLFlag.Name),
-		HeimdallTimeout:     ctx.Duration(HeimdallTimeoutFlag.Name),
-		WithoutHeimdall:     ctx.Bool(WithoutHeimdallFlag.Name),
-		HeimdallgRPCAddress: ctx.String(HeimdallgRPCAddressFlag.Name),
-		HeimdallWSAddress:   ctx.String(HeimdallWSAddressFlag.Name),
-		RunHeimdall:         ctx.Bool(RunHeimdallArgsFlag.Name),
-		RunHeimdallArgs:     ctx.String(RunHeimdallArgsFlag.Name),
-		UseHeimdallApp:      ctx.Bool(UseHeimdallAppFlag.Name),
+		Genesis:                 gspec,
+		HeimdallURL:             ctx.String(HeimdallURLFlag.Name),
+		HeimdallTimeout:         ctx.Duration(HeimdallTimeoutFlag.Name),
+		WithoutHeimdall:         ctx.Bool(WithoutHeimdallFlag.Name),
+		HeimdallgRPCAddress:     ctx.String(HeimdallgRPCAddressFlag.Name),
+		HeimdallWSAddress:       ctx.String(HeimdallWSAddressFlag.Name),
+		RunHeimdall:             ctx.Bool(RunHeimdallArgsFlag.Name),
+		RunHeimdallArgs:         ctx.String(RunHeimdallArgsFlag.Name),
+		UseHeimdallApp:          ctx.Bool(UseHeimdallAppFlag.Name),
+		HeimdallArchivePath:     ctx.String(HeimdallArchiveFlag.Name),
+		HeimdallArchiveSigner:   ctx.String(HeimdallArchiveSignerFlag.Name),
+		HeimdallEndpoints:       ctx.StringSlice(HeimdallEndpointsFlag.Name),
+		HeimdallFailoverTimeout: ctx.Duration(HeimdallFailoverTimeoutFlag.Name),
+		HeimdallHedgeDelay:      ctx.Duration(HeimdallHedgeDelayFlag.Name),
+		HeimdallQuorum:          ctx.Int(HeimdallQuorumFlag.Name),
 	}
 	_ = CreateBorEthereum(configs)
 	engine, err := ethconfig.CreateConsensusEngine(config, configs, chainDb, nil)
@@ -3,26 +3,34 @@ package bor
 import (
 	"encoding/hex"
 	"math"
-	"math/big"
 	"sort"
 	"strconv"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
 	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	lru "github.com/hashicorp/golang-lru"
-	"github.com/xsleonard/go-merkle"
-	"golang.org/x/crypto/sha3"
+
+	borTypes "github.com/0xPolygon/heimdall-v2/x/bor/types"
 )
 
 var (
 	// MaxCheckpointLength is the maximum number of blocks that can be requested for constructing a checkpoint root hash
 	MaxCheckpointLength = uint64(math.Pow(2, 15))
+
+	// MaxProducerStatsLength is the maximum number of blocks that can be requested for the producer stats
+	MaxProducerStatsLength = uint64(math.Pow(2, 15))
+
+	// MaxTxDependencyStatsLength is the maximum number of blocks that can be requested for the tx dependency stats
+	MaxTxDependencyStatsLength = uint64(math.Pow(2, 15))
+
+	// MaxValidatorSetHistoryLength is the maximum number of blocks that can be requested for the validator set history
+	MaxValidatorSetHistoryLength = uint64(math.Pow(2, 12))
 )
 
 // API is a user facing RPC API to allow controlling the signer and voting
@@ -271,6 +279,292 @@ func (api *API) GetCurrentValidators() ([]*valset.Validator, error) {
 	return snap.ValidatorSet.Validators, nil
 }
 
+// SpanInfo is a span as seen by the span store of the node
+type SpanInfo struct {
+	ID                uint64               `json:"id"`
+	StartBlock        uint64               `json:"startBlock"`
+	EndBlock          uint64               `json:"endBlock"`
+	BorChainID        string               `json:"borChainId"`
+	ValidatorSet      *valset.ValidatorSet `json:"validatorSet"`
+	SelectedProducers []*valset.Validator  `json:"selectedProducers"`
+}
+
+func newSpanInfo(currentSpan *borTypes.Span) *SpanInfo {
+	validatorSet := span.ConvertHeimdallValSetToBorValSet(currentSpan.ValidatorSet)
+
+	selectedProducers := span.ConvertHeimdallValidatorsToBorValidators(currentSpan.SelectedProducers)
+
+	producers := make([]*valset.Validator, len(selectedProducers))
+	for i := range selectedProducers {
+		producers[i] = &selectedProducers[i]
+	}
+
+	return &SpanInfo{
+		ID:                currentSpan.Id,
+		StartBlock:        currentSpan.StartBlock,
+		EndBlock:          currentSpan.EndBlock,
+		BorChainID:        currentSpan.BorChainId,
+		ValidatorSet:      &validatorSet,
+		SelectedProducers: producers,
+	}
+}
+
+// GetSpan retrieves the span with the given id from the span store of the node.
+// Spans which were never fetched by the node are not available.
+func (api *API) GetSpan(id uint64) (*SpanInfo, error) {
+	currentSpan := api.bor.spanStore.knownSpanById(id)
+	if currentSpan == nil {
+		return nil, errUnknownSpan
+	}
+
+	return newSpanInfo(currentSpan), nil
+}
+
+// GetSpanByBlock retrieves the span containing the given block from the span store of the node.
+func (api *API) GetSpanByBlock(number rpc.BlockNumber) (*SpanInfo, error) {
+	var header *types.Header
+	if number == rpc.LatestBlockNumber {
+		header = api.chain.CurrentHeader()
+	} else {
+		header = api.chain.GetHeaderByNumber(uint64(number.Int64()))
+	}
+
+	if header == nil {
+		return nil, errUnknownBlock
+	}
+
+	currentSpan := api.bor.spanStore.knownSpanByBlockNumber(header.Number.Uint64())
+	if currentSpan == nil {
+		return nil, errUnknownSpan
+	}
+
+	return newSpanInfo(currentSpan), nil
+}
+
+// ValidatorSetInfo is the validator set in effect from a block until the end of its sprint
+type ValidatorSetInfo struct {
+	Number           uint64              `json:"number"`
+	SpanID           *uint64             `json:"spanId,omitempty"` // nil if the span isn't known to the node
+	Proposer         common.Address      `json:"proposer"`
+	TotalVotingPower int64               `json:"totalVotingPower"`
+	Validators       []*valset.Validator `json:"validators"`
+}
+
+// GetValidatorSetHistory retrieves the validator sets in effect between the start and end
+// blocks (both inclusive). An entry is returned for the start block and for every sprint
+// start block after it, with the voting power and proposer priority of the validators as
+// seen by the snapshots of the node.
+func (api *API) GetValidatorSetHistory(start uint64, end uint64) ([]*ValidatorSetInfo, error) {
+	currentHeaderNumber := api.chain.CurrentHeader().Number.Uint64()
+
+	if start > end || end > currentHeaderNumber {
+		return nil, &valset.InvalidStartEndBlockError{Start: start, End: end, CurrentHeader: currentHeaderNumber}
+	}
+
+	if end-start+1 > MaxValidatorSetHistoryLength {
+		return nil, &MaxValidatorSetHistoryLengthExceededError{start, end}
+	}
+
+	// The validator set of a block is the one of the snapshot at its parent
+	if start == 0 {
+		start = 1
+	}
+
+	history := make([]*ValidatorSetInfo, 0)
+
+	for number := start; number <= end; number++ {
+		if number != start && !api.bor.config.IsSprintStart(number) {
+			continue
+		}
+
+		header := api.chain.GetHeaderByNumber(number - 1)
+		if header == nil {
+			return nil, errUnknownBlock
+		}
+
+		snap, err := api.bor.snapshot(api.chain, header.Number.Uint64(), header.Hash(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		info := &ValidatorSetInfo{
+			Number:           number,
+			Proposer:         snap.ValidatorSet.GetProposer().Address,
+			TotalVotingPower: snap.ValidatorSet.TotalVotingPower(),
+			Validators:       snap.ValidatorSet.Copy().Validators,
+		}
+
+		if currentSpan := api.bor.spanStore.knownSpanByBlockNumber(number); currentSpan != nil {
+			spanID := currentSpan.Id
+			info.SpanID = &spanID
+		}
+
+		history = append(history, info)
+	}
+
+	return history, nil
+}
+
+// GetProducerStats returns the performance of every producer between the start and end
+// blocks (both inclusive): the in-turn slots it was expected to fill, the blocks it signed
+// in and out of turn and its average delay compared with the minimum one.
+func (api *API) GetProducerStats(start uint64, end uint64) ([]*ProducerStats, error) {
+	currentHeaderNumber := api.chain.CurrentHeader().Number.Uint64()
+
+	if start > end || end > currentHeaderNumber {
+		return nil, &valset.InvalidStartEndBlockError{Start: start, End: end, CurrentHeader: currentHeaderNumber}
+	}
+
+	if end-start+1 > MaxProducerStatsLength {
+		return nil, &MaxProducerStatsLengthExceededError{start, end}
+	}
+
+	// The genesis block has no producer
+	if start == 0 {
+		start = 1
+	}
+
+	collector := newProducerStatsCollector()
+
+	parent := api.chain.GetHeaderByNumber(start - 1)
+	if parent == nil {
+		return nil, errUnknownBlock
+	}
+
+	for number := start; number <= end; number++ {
+		header := api.chain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, errUnknownBlock
+		}
+
+		record, err := api.bor.producerRecordOf(api.chain, header, parent)
+		if err != nil {
+			return nil, err
+		}
+
+		collector.add(record)
+
+		parent = header
+	}
+
+	return collector.result(), nil
+}
+
+// GetCheckpoint retrieves the checkpoint with the given number from the checkpoint history
+// of the node. Only checkpoints processed by the node are available.
+func (api *API) GetCheckpoint(number uint64) (*rawdb.FinalityRecord, error) {
+	if api.bor.db == nil {
+		return nil, errUnknownCheckpoint
+	}
+
+	record := rawdb.ReadCheckpointRecord(api.bor.db, number)
+	if record == nil {
+		return nil, errUnknownCheckpoint
+	}
+
+	return record, nil
+}
+
+// GetMilestone retrieves the milestone with the given id from the milestone history of
+// the node. Only milestones processed by the node are available.
+func (api *API) GetMilestone(id string) (*rawdb.FinalityRecord, error) {
+	if api.bor.db == nil {
+		return nil, errUnknownMilestone
+	}
+
+	record := rawdb.ReadMilestoneRecord(api.bor.db, id)
+	if record == nil {
+		return nil, errUnknownMilestone
+	}
+
+	return record, nil
+}
+
+// GetBlockProductionReport retrieves the timeline of the production of the block with
+// the given number by the local validator. Only the recently produced blocks are available.
+func (api *API) GetBlockProductionReport(number uint64) (*rawdb.BlockProductionTimeline, error) {
+	if api.bor.db == nil {
+		return nil, errUnknownProductionReport
+	}
+
+	timeline := rawdb.ReadBlockProductionTimeline(api.bor.db, number)
+	if timeline == nil {
+		return nil, errUnknownProductionReport
+	}
+
+	return timeline, nil
+}
+
+// FinalizedRange is the checkpoint and milestone which finalized a block
+type FinalizedRange struct {
+	Number     uint64                `json:"number"`
+	Milestone  *rawdb.FinalityRecord `json:"milestone"`  // nil if no processed milestone contains the block
+	Checkpoint *rawdb.FinalityRecord `json:"checkpoint"` // nil if no processed checkpoint contains the block
+}
+
+// GetFinalizedRange retrieves the processed milestone and checkpoint containing the given block
+func (api *API) GetFinalizedRange(number uint64) (*FinalizedRange, error) {
+	result := &FinalizedRange{Number: number}
+
+	if api.bor.db == nil {
+		return result, nil
+	}
+
+	result.Milestone = rawdb.FindMilestoneRecord(api.bor.db, number)
+	result.Checkpoint = rawdb.FindCheckpointRecord(api.bor.db, number)
+
+	return result, nil
+}
+
+// GetFinalityIncidents retrieves the given number of latest mismatches between the local
+// chain and a milestone or checkpoint, the latest first. All the retained incidents are
+// returned if the count is zero.
+func (api *API) GetFinalityIncidents(count uint64) ([]*rawdb.FinalityIncident, error) {
+	if api.bor.db == nil {
+		return []*rawdb.FinalityIncident{}, nil
+	}
+
+	if count == 0 || count > rawdb.MaxFinalityIncidents {
+		count = rawdb.MaxFinalityIncidents
+	}
+
+	return rawdb.ReadFinalityIncidents(api.bor.db, count), nil
+}
+
+// GetTxDependencyStats returns the accuracy of the transaction dependency metadata embedded
+// by every producer in its blocks between the start and end blocks (both inclusive). Only
+// the blocks whose transactions were executed in parallel by the node on import are checked.
+func (api *API) GetTxDependencyStats(start uint64, end uint64) ([]*TxDependencyStats, error) {
+	currentHeaderNumber := api.chain.CurrentHeader().Number.Uint64()
+
+	if start > end || end > currentHeaderNumber {
+		return nil, &valset.InvalidStartEndBlockError{Start: start, End: end, CurrentHeader: currentHeaderNumber}
+	}
+
+	if end-start+1 > MaxTxDependencyStatsLength {
+		return nil, &MaxTxDependencyStatsLengthExceededError{start, end}
+	}
+
+	if api.bor.db == nil {
+		return nil, errUnknownBlock
+	}
+
+	collector := newTxDependencyStatsCollector()
+
+	for number := start; number <= end; number++ {
+		header := api.chain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, errUnknownBlock
+		}
+
+		if record := rawdb.ReadTxDependencyRecord(api.bor.db, number, header.Hash()); record != nil {
+			collector.add(record)
+		}
+	}
+
+	return collector.result(), nil
+}
+
 // GetRootHash returns the merkle root of the start to end block headers
 func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 	if err := api.initializeRootHashCache(); err != nil {
@@ -295,54 +589,72 @@ func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 		return "", &valset.InvalidStartEndBlockError{Start: start, End: end, CurrentHeader: currentHeaderNumber}
 	}
 
-	blockHeaders := make([]*types.Header, end-start+1)
-	wg := new(sync.WaitGroup)
-	concurrent := make(chan bool, 20)
+	rootHash, err := api.rootHashStore().root(start, end)
+	if err != nil {
+		return "", err
+	}
+
+	root := hex.EncodeToString(rootHash[:])
+	api.rootHashCache.Add(key, root)
 
-	for i := start; i <= end; i++ {
-		wg.Add(1)
-		concurrent <- true
+	return root, nil
+}
 
-		go func(number uint64) {
-			blockHeaders[number-start] = api.chain.GetHeaderByNumber(number)
+// RootHashProof is the merkle inclusion proof of a block in the root hash of a block range
+type RootHashProof struct {
+	RootHash common.Hash   `json:"rootHash"`
+	Leaf     common.Hash   `json:"leaf"`
+	Index    uint64        `json:"index"` // Position of the block in the range
+	Proof    []common.Hash `json:"proof"` // Sibling hashes from the leaf up to the root
+}
+
+// GetRootHashProof returns the merkle inclusion proof of the given block in the root
+// hash of the start to end block headers
+func (api *API) GetRootHashProof(start uint64, end uint64, number uint64) (*RootHashProof, error) {
+	length := end - start + 1
 
-			<-concurrent
-			wg.Done()
-		}(i)
+	if length > MaxCheckpointLength {
+		return nil, &MaxCheckpointLengthExceededError{start, end}
 	}
-	wg.Wait()
-	close(concurrent)
 
-	headers := make([][32]byte, nextPowerOfTwo(length))
+	currentHeaderNumber := api.chain.CurrentHeader().Number.Uint64()
 
-	for i := 0; i < len(blockHeaders); i++ {
-		blockHeader := blockHeaders[i]
-		// Handle no header case, which is possible if ancient pruning was done
-		if blockHeader == nil {
-			return "", errUnknownBlock
-		}
-		header := crypto.Keccak256(appendBytes32(
-			blockHeader.Number.Bytes(),
-			new(big.Int).SetUint64(blockHeader.Time).Bytes(),
-			blockHeader.TxHash.Bytes(),
-			blockHeader.ReceiptHash.Bytes(),
-		))
+	if start > end || end > currentHeaderNumber {
+		return nil, &valset.InvalidStartEndBlockError{Start: start, End: end, CurrentHeader: currentHeaderNumber}
+	}
 
-		var arr [32]byte
+	if number < start || number > end {
+		return nil, &valset.InvalidStartEndBlockError{Start: start, End: end, CurrentHeader: number}
+	}
+
+	store := api.rootHashStore()
 
-		copy(arr[:], header)
-		headers[i] = arr
+	root, err := store.root(start, end)
+	if err != nil {
+		return nil, err
 	}
 
-	tree := merkle.NewTreeWithOpts(merkle.TreeOptions{EnableHashSorting: false, DisableHashLeaves: true})
-	if err := tree.Generate(convert(headers), sha3.NewLegacyKeccak256()); err != nil {
-		return "", err
+	leaf, proof, err := store.proof(start, end, number)
+	if err != nil {
+		return nil, err
 	}
 
-	root := hex.EncodeToString(tree.Root().Hash)
-	api.rootHashCache.Add(key, root)
+	result := &RootHashProof{
+		RootHash: root,
+		Leaf:     leaf,
+		Index:    number - start,
+		Proof:    make([]common.Hash, len(proof)),
+	}
 
-	return root, nil
+	for i, sibling := range proof {
+		result.Proof[i] = sibling
+	}
+
+	return result, nil
+}
+
+func (api *API) rootHashStore() *rootHashStore {
+	return &rootHashStore{chain: api.chain, db: api.bor.db}
 }
 
 func (api *API) initializeRootHashCache() error {
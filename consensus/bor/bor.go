@@ -75,6 +75,22 @@ var (
 	// that is not part of the local blockchain.
 	errUnknownBlock = errors.New("unknown block")
 
+	// errUnknownSpan is returned when a span is requested which is not present
+	// in the span store of the node.
+	errUnknownSpan = errors.New("unknown span")
+
+	// errUnknownCheckpoint is returned when a checkpoint is requested which is
+	// not present in the checkpoint history of the node.
+	errUnknownCheckpoint = errors.New("unknown checkpoint")
+
+	// errUnknownMilestone is returned when a milestone is requested which is
+	// not present in the milestone history of the node.
+	errUnknownMilestone = errors.New("unknown milestone")
+
+	// errUnknownProductionReport is returned when the production report of a block is
+	// requested which wasn't produced recently by the local validator.
+	errUnknownProductionReport = errors.New("unknown block production report")
+
 	// errMissingVanity is returned if a block's extra-data section is shorter than
 	// 32 bytes, which is required to store the signer vanity.
 	errMissingVanity = errors.New("extra-data 32 byte vanity prefix missing")
@@ -231,7 +247,14 @@ type Bor struct {
 	HeimdallClient         IHeimdallClient
 	HeimdallWSClient       IHeimdallWSClient
 
-	spanStore SpanStore // Store to save previous span data from heimdall
+	spanStore  *SpanStore       // Store to save previous span data from heimdall
+	stateSyncs *stateSyncBuffer // State-sync records pushed by heimdall
+
+	stateSyncCommit atomic.Pointer[stateSyncCommit] // Last state-sync commit of a locally assembled block
+	signedHeader    atomic.Pointer[types.Header]    // Last header signed by Seal, waiting for its slot
+
+	producerHead *types.Header // Last canonical head whose producer records are persisted
+	producerLock sync.Mutex    // Serializes the recording of the producer records
 
 	// The fields below are for testing only
 	fakeDiff      bool // Skip difficulty verifications
@@ -240,6 +263,12 @@ type Bor struct {
 	closeOnce sync.Once
 }
 
+// stateSyncCommit is the time spent committing the state-sync events of a block
+type stateSyncCommit struct {
+	number  uint64
+	elapsed time.Duration
+}
+
 type signer struct {
 	signer common.Address // Ethereum address of the signing key
 	signFn SignerFn       // Signer function to authorize hashes with
@@ -282,6 +311,7 @@ func New(
 		HeimdallClient:         heimdallClient,
 		HeimdallWSClient:       heimdallWSClient,
 		spanStore:              spanStore,
+		stateSyncs:             newStateSyncBuffer(),
 		DevFakeAuthor:          devFakeAuthor,
 	}
 
@@ -755,6 +785,35 @@ func (c *Bor) verifySeal(chain consensus.ChainHeaderReader, header *types.Header
 	return nil
 }
 
+// VerifySpanSigner checks whether a header is signed by one of the producers of its span.
+// Unlike verifySeal, it doesn't need the ancestors of the header to build a snapshot, for
+// a header downloaded ahead of its ancestors to be verified.
+func (c *Bor) VerifySpanSigner(header *types.Header) error {
+	number := header.Number.Uint64()
+	if number == 0 {
+		return errUnknownBlock
+	}
+
+	signer, err := ecrecover(header, c.signatures, c.config)
+	if err != nil {
+		return err
+	}
+
+	span, err := c.spanStore.spanByBlockNumber(context.Background(), number)
+	if err != nil {
+		return err
+	}
+
+	for _, producer := range borSpan.ConvertHeimdallValidatorsToBorValidators(span.SelectedProducers) {
+		if producer.Address == signer {
+			return nil
+		}
+	}
+
+	// Check the UnauthorizedSignerError.Error() msg to see why we pass number-1
+	return &UnauthorizedSignerError{number - 1, signer.Bytes()}
+}
+
 // IsBlockEarly returns true if the header time is earlier than expected (according to consensus rules). This
 // can happen if the producer maliciously updates the header time.
 func IsBlockEarly(parent *types.Header, header *types.Header, number uint64, succession int, cfg *params.BorConfig) bool {
@@ -992,11 +1051,15 @@ func (c *Bor) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header *typ
 
 		if c.HeimdallClient != nil {
 			// commit states
+			start := time.Now()
+
 			stateSyncData, err = c.CommitStates(state, header, cx)
 			if err != nil {
 				log.Error("Error while committing states", "error", err)
 				return nil, err
 			}
+
+			c.stateSyncCommit.Store(&stateSyncCommit{number: headerNumber, elapsed: time.Since(start)})
 		}
 	}
 
@@ -1022,6 +1085,17 @@ func (c *Bor) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header *typ
 	return block, nil
 }
 
+// StateSyncCommitTime returns the time spent committing the state-sync events of the
+// block with the given number, if it was the last block assembled by the node.
+func (c *Bor) StateSyncCommitTime(number uint64) (time.Duration, bool) {
+	commit := c.stateSyncCommit.Load()
+	if commit == nil || commit.number != number {
+		return 0, false
+	}
+
+	return commit.elapsed, true
+}
+
 // Authorize injects a private key into the consensus engine to mint new blocks
 // with.
 func (c *Bor) Authorize(currentSigner common.Address, signFn SignerFn) {
@@ -1088,6 +1162,8 @@ func (c *Bor) Seal(chain consensus.ChainHeaderReader, block *types.Block, result
 		return err
 	}
 
+	c.signedHeader.Store(header)
+
 	// Wait until sealing is terminated or delay timeout.
 	log.Info("Waiting for slot to sign and propagate", "number", number, "hash", header.Hash, "delay-in-sec", uint(delay), "delay", common.PrettyDuration(delay))
 
@@ -1125,6 +1201,36 @@ func (c *Bor) Seal(chain consensus.ChainHeaderReader, block *types.Block, result
 	return nil
 }
 
+// SignedHeader returns the header with the given seal hash if it was signed by the last
+// call to Seal. The signature is computed before waiting for the slot of the block, hence
+// the hash of the sealed block is known before it's propagated.
+func (c *Bor) SignedHeader(sealHash common.Hash) *types.Header {
+	header := c.signedHeader.Load()
+	if header == nil || SealHash(header, c.config) != sealHash {
+		return nil
+	}
+
+	return types.CopyHeader(header)
+}
+
+// InTurn reports whether the local signer is the primary producer of the block following
+// the given parent.
+func (c *Bor) InTurn(chain consensus.ChainHeaderReader, parent *types.Header) bool {
+	signer := c.authorizedSigner.Load().signer
+	if signer == (common.Address{}) {
+		return false
+	}
+
+	snap, err := c.snapshot(chain, parent.Number.Uint64(), parent.Hash(), nil)
+	if err != nil {
+		return false
+	}
+
+	succession, err := snap.GetSignerSuccessionNumber(signer)
+
+	return err == nil && succession == 0
+}
+
 func Sign(signFn SignerFn, signer common.Address, header *types.Header, c *params.BorConfig) error {
 	sighash, err := signFn(accounts.Account{Address: signer}, accounts.MimetypeBor, BorRLP(header, c))
 	if err != nil {
@@ -1327,18 +1433,15 @@ func (c *Bor) CommitStates(
 		if err != nil {
 			return nil, err
 		}
-
-		stateSyncDelay := c.config.CalculateStateSyncDelay(number)
-		to = time.Unix(int64(header.Time-stateSyncDelay), 0)
 	} else {
 		lastStateIDBig, err = c.GenesisContractsClient.LastStateId(nil, number-1, header.ParentHash)
 		if err != nil {
 			return nil, err
 		}
-
-		to = time.Unix(int64(chain.Chain.GetHeaderByNumber(number-c.config.CalculateSprint(number)).Time), 0)
 	}
 
+	to = StateSyncWindowEnd(c.config, header, chain.Chain.GetHeaderByNumber)
+
 	lastStateID := lastStateIDBig.Uint64()
 	from = lastStateID + 1
 
@@ -1347,29 +1450,30 @@ func (c *Bor) CommitStates(
 		"fromID", from,
 		"to", to.Format(time.RFC3339))
 
-	var eventRecords []*clerk.EventRecordWithTime
-	eventRecords, err = c.HeimdallClient.StateSyncEvents(context.Background(), from, to.Unix())
-	if err != nil {
-		log.Error("Error occurred when fetching state sync events", "fromID", from, "to", to.Unix(), "err", err)
+	// Use the records pushed by heimdall if they are complete, fetch them otherwise. The
+	// buffered records are the ones heimdall returned, windowed the way heimdall does,
+	// hence they commit the same records. Overrides truncate the records heimdall returns
+	// by index though, so the records of overridden blocks are always fetched.
+	var (
+		eventRecords []*clerk.EventRecordWithTime
+		ok           bool
+	)
 
-		stateSyncs := make([]*types.StateSyncData, 0)
-		return stateSyncs, nil
+	if !hasStateSyncOverride(c.config, number) {
+		eventRecords, ok = c.stateSyncs.get(from, to)
 	}
 
-	// This if statement checks if there are any state sync record overrides configured for the current block number.
-	// If there are, it truncates the eventRecords array to the specified number of records.
-	if c.config.OverrideStateSyncRecords != nil {
-		if val, ok := c.config.OverrideStateSyncRecords[strconv.FormatUint(number, 10)]; ok {
-			eventRecords = eventRecords[0:val]
+	if !ok {
+		eventRecords, err = c.HeimdallClient.StateSyncEvents(context.Background(), from, to.Unix())
+		if err != nil {
+			log.Error("Error occurred when fetching state sync events", "fromID", from, "to", to.Unix(), "err", err)
+
+			stateSyncs := make([]*types.StateSyncData, 0)
+			return stateSyncs, nil
 		}
-	}
 
-	// This if statement checks if there are any state sync record overrides configured for the current block number.
-	// If there are, it truncates the eventRecords array to the specified number of records.
-	if c.config.OverrideStateSyncRecordsInRange != nil {
-		overrideStateSyncRecord, ok := c.config.GetOverrideStateSyncRecord(number)
-		if ok {
-			eventRecords = eventRecords[0:overrideStateSyncRecord]
+		if c.HeimdallWSClient != nil {
+			c.stateSyncs.add(eventRecords...)
 		}
 	}
 
@@ -1377,20 +1481,12 @@ func (c *Bor) CommitStates(
 	processStart := time.Now()
 	totalGas := 0 /// limit on gas for state sync per block
 	chainID := c.chainConfig.ChainID.String()
-	stateSyncs := make([]*types.StateSyncData, 0, len(eventRecords))
+	committed := FilterStateSyncEvents(c.config, number, eventRecords, lastStateID, to, chainID)
+	stateSyncs := make([]*types.StateSyncData, 0, len(committed))
 
 	var gasUsed uint64
 
-	for _, eventRecord := range eventRecords {
-		if eventRecord.ID <= lastStateID {
-			continue
-		}
-
-		if err = validateEventRecord(eventRecord, number, to, lastStateID, chainID); err != nil {
-			log.Error("while validating event record", "block", number, "to", to, "stateID", lastStateID+1, "error", err.Error())
-			break
-		}
-
+	for _, eventRecord := range committed {
 		stateData := types.StateSyncData{
 			ID:       eventRecord.ID,
 			Contract: eventRecord.Contract,
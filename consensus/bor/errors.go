@@ -21,6 +21,48 @@ func (e *MaxCheckpointLengthExceededError) Error() string {
 	)
 }
 
+type MaxProducerStatsLengthExceededError struct {
+	Start uint64
+	End   uint64
+}
+
+func (e *MaxProducerStatsLengthExceededError) Error() string {
+	return fmt.Sprintf(
+		"Start: %d and end block: %d exceed max allowed producer stats length: %d",
+		e.Start,
+		e.End,
+		MaxProducerStatsLength,
+	)
+}
+
+type MaxTxDependencyStatsLengthExceededError struct {
+	Start uint64
+	End   uint64
+}
+
+func (e *MaxTxDependencyStatsLengthExceededError) Error() string {
+	return fmt.Sprintf(
+		"Start: %d and end block: %d exceed max allowed tx dependency stats length: %d",
+		e.Start,
+		e.End,
+		MaxTxDependencyStatsLength,
+	)
+}
+
+type MaxValidatorSetHistoryLengthExceededError struct {
+	Start uint64
+	End   uint64
+}
+
+func (e *MaxValidatorSetHistoryLengthExceededError) Error() string {
+	return fmt.Sprintf(
+		"Start: %d and end block: %d exceed max allowed validator set history length: %d",
+		e.Start,
+		e.End,
+		MaxValidatorSetHistoryLength,
+	)
+}
+
 // MismatchingValidatorsError is returned if a last block in sprint contains a
 // list of validators different from the one that local node calculated
 type MismatchingValidatorsError struct {
@@ -25,6 +25,11 @@ type IHeimdallClient interface {
 //go:generate mockgen -destination=../../tests/bor/mocks/IHeimdallWSClient.go -package=mocks . IHeimdallWSClient
 type IHeimdallWSClient interface {
 	SubscribeMilestoneEvents(ctx context.Context) <-chan *milestone.Milestone
+	// The span, checkpoint and state-sync streams only carry the ids of the new
+	// entries as heimdall doesn't publish the full objects in its events.
+	SubscribeSpanEvents(ctx context.Context) <-chan uint64
+	SubscribeCheckpointEvents(ctx context.Context) <-chan uint64
+	SubscribeStateSyncEvents(ctx context.Context) <-chan uint64
 	Unsubscribe(ctx context.Context) error
 	Close() error
 }
@@ -0,0 +1,490 @@
+package heimdall
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygon/heimdall-v2/x/bor/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	ErrNoBackends        = errors.New("no heimdall backends configured")
+	ErrQuorumNotReached  = errors.New("heimdall backends did not reach quorum")
+	ErrInvalidQuorum     = errors.New("quorum exceeds the number of heimdall backends")
+	ErrDuplicateBackends = errors.New("duplicate heimdall backend name")
+)
+
+const (
+	defaultRequestTimeout   = 30 * time.Second
+	defaultFailureThreshold = 3
+	defaultCooldown         = 30 * time.Second
+)
+
+// Client is the set of queries served by every heimdall backend, i.e. the
+// REST client, the gRPC client and the in-process heimdall app client.
+type Client interface {
+	StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error)
+	GetSpan(ctx context.Context, spanID uint64) (*types.Span, error)
+	GetLatestSpan(ctx context.Context) (*types.Span, error)
+	FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error)
+	FetchCheckpointCount(ctx context.Context) (int64, error)
+	FetchMilestone(ctx context.Context) (*milestone.Milestone, error)
+	FetchMilestoneCount(ctx context.Context) (int64, error)
+	Close()
+}
+
+// HealthChecker is implemented by the backends which know without a request
+// whether they can serve queries, e.g. the in-process heimdall app client which
+// can't while the app isn't running. Such backends are skipped while unhealthy.
+type HealthChecker interface {
+	Health() error
+}
+
+// Backend is a named heimdall client used by the MultiHeimdallClient. The name
+// is used in logs and metrics so it must be unique.
+type Backend struct {
+	Name   string
+	Client Client
+}
+
+// MultiClientConfig configures how requests are spread over the backends
+type MultiClientConfig struct {
+	// RequestTimeout bounds a single attempt on a backend. The backends retry
+	// internally until their context is done, so without a timeout a stuck
+	// backend would never fail over. Zero applies the default timeout.
+	RequestTimeout time.Duration
+
+	// HedgeDelay is the time after which the request is also sent to the next
+	// backend if the current one hasn't answered yet. Zero disables hedging.
+	HedgeDelay time.Duration
+
+	// Quorum is the number of backends which must return the same span or
+	// milestone for it to be accepted. Values below 2 disable quorum reads.
+	Quorum int
+
+	// FailureThreshold is the number of consecutive failures after which a
+	// backend is considered unhealthy for Cooldown.
+	FailureThreshold int
+	Cooldown         time.Duration
+}
+
+type backendMetrics struct {
+	valid    *metrics.Meter
+	invalid  *metrics.Meter
+	hedged   *metrics.Meter
+	duration *metrics.Timer
+	healthy  *metrics.Gauge
+}
+
+func newBackendMetrics(name string) backendMetrics {
+	prefix := "client/backends/" + name
+
+	return backendMetrics{
+		valid:    metrics.GetOrRegisterMeter(prefix+"/valid", nil),
+		invalid:  metrics.GetOrRegisterMeter(prefix+"/invalid", nil),
+		hedged:   metrics.GetOrRegisterMeter(prefix+"/hedged", nil),
+		duration: metrics.GetOrRegisterTimer(prefix+"/duration", nil),
+		healthy:  metrics.GetOrRegisterGauge(prefix+"/healthy", nil),
+	}
+}
+
+type backend struct {
+	Backend
+
+	metrics backendMetrics
+
+	lock           sync.Mutex
+	failures       int       // consecutive failures
+	unhealthyUntil time.Time // zero if the backend is healthy
+}
+
+// check returns the error of the health check of the backend, if it has one
+func (b *backend) check() error {
+	if checker, ok := b.Client.(HealthChecker); ok {
+		return checker.Health()
+	}
+
+	return nil
+}
+
+func (b *backend) healthy(now time.Time) bool {
+	if b.check() != nil {
+		return false
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return now.After(b.unhealthyUntil)
+}
+
+func (b *backend) report(err error, threshold int, cooldown time.Duration) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if err == nil {
+		b.failures = 0
+		b.unhealthyUntil = time.Time{}
+		b.metrics.healthy.Update(1)
+
+		return
+	}
+
+	b.failures++
+	if b.failures >= threshold {
+		if time.Now().After(b.unhealthyUntil) {
+			log.Warn("Heimdall backend marked unhealthy", "backend", b.Name, "failures", b.failures, "cooldown", cooldown, "err", err)
+		}
+
+		b.unhealthyUntil = time.Now().Add(cooldown)
+		b.metrics.healthy.Update(0)
+	}
+}
+
+// MultiHeimdallClient serves heimdall queries from several backends. Requests are
+// sent to the healthiest backend first and fail over to the next one on error.
+// If configured, slow requests are hedged to a second backend and spans and
+// milestones are only accepted once a quorum of backends agrees on them.
+type MultiHeimdallClient struct {
+	backends []*backend
+	config   MultiClientConfig
+
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMultiHeimdallClient creates a client over the given backends. The order of
+// the backends is the order of preference while all of them are healthy.
+func NewMultiHeimdallClient(backends []Backend, config MultiClientConfig) (*MultiHeimdallClient, error) {
+	if len(backends) == 0 {
+		return nil, ErrNoBackends
+	}
+
+	if config.Quorum > len(backends) {
+		return nil, fmt.Errorf("%w: quorum %d, backends %d", ErrInvalidQuorum, config.Quorum, len(backends))
+	}
+
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = defaultRequestTimeout
+	}
+
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = defaultFailureThreshold
+	}
+
+	if config.Cooldown <= 0 {
+		config.Cooldown = defaultCooldown
+	}
+
+	m := &MultiHeimdallClient{
+		backends: make([]*backend, 0, len(backends)),
+		config:   config,
+		closeCh:  make(chan struct{}),
+	}
+
+	names := make(map[string]struct{}, len(backends))
+
+	for _, b := range backends {
+		if _, ok := names[b.Name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateBackends, b.Name)
+		}
+
+		names[b.Name] = struct{}{}
+
+		state := &backend{
+			Backend: b,
+			metrics: newBackendMetrics(b.Name),
+		}
+		state.metrics.healthy.Update(1)
+
+		m.backends = append(m.backends, state)
+	}
+
+	return m, nil
+}
+
+// candidates returns the backends in the order they should be tried, healthy
+// backends first. Unhealthy backends are kept as a last resort.
+func (m *MultiHeimdallClient) candidates() []*backend {
+	now := time.Now()
+
+	candidates := make([]*backend, len(m.backends))
+	copy(candidates, m.backends)
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].healthy(now) && !candidates[j].healthy(now)
+	})
+
+	return candidates
+}
+
+// attempt runs a single request against a backend and records its outcome
+func attempt[T any](ctx context.Context, m *MultiHeimdallClient, b *backend, fn func(context.Context, Client) (T, error)) (T, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, m.config.RequestTimeout)
+	defer cancel()
+
+	start := time.Now()
+
+	var (
+		value T
+		err   = b.check()
+	)
+
+	if err == nil {
+		value, err = fn(attemptCtx, b.Client)
+	}
+
+	// The request was abandoned by the caller (e.g. a hedged request won),
+	// which doesn't say anything about the health of the backend.
+	if err != nil && ctx.Err() != nil {
+		return value, err
+	}
+
+	b.metrics.duration.UpdateSince(start)
+
+	if err != nil {
+		b.metrics.invalid.Mark(1)
+		log.Debug("Heimdall backend request failed", "backend", b.Name, "err", err)
+	} else {
+		b.metrics.valid.Mark(1)
+	}
+
+	b.report(err, m.config.FailureThreshold, m.config.Cooldown)
+
+	return value, err
+}
+
+type backendResult[T any] struct {
+	value   T
+	err     error
+	backend *backend
+}
+
+// do runs the request on the preferred backend, failing over to the next one on
+// error and hedging to it if no answer arrived within the hedge delay.
+func do[T any](ctx context.Context, m *MultiHeimdallClient, fn func(context.Context, Client) (T, error)) (T, error) {
+	var zero T
+
+	candidates := m.candidates()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan backendResult[T], len(candidates))
+	next := 0
+
+	start := func() {
+		b := candidates[next]
+		next++
+
+		go func() {
+			value, err := attempt(ctx, m, b, fn)
+			results <- backendResult[T]{value: value, err: err, backend: b}
+		}()
+	}
+
+	start()
+
+	pending := 1
+
+	var hedge <-chan time.Time
+
+	if m.config.HedgeDelay > 0 && next < len(candidates) {
+		timer := time.NewTimer(m.config.HedgeDelay)
+		defer timer.Stop()
+
+		hedge = timer.C
+	}
+
+	var errs []error
+
+	for pending > 0 {
+		select {
+		case result := <-results:
+			pending--
+
+			if result.err == nil {
+				return result.value, nil
+			}
+
+			errs = append(errs, fmt.Errorf("%s: %w", result.backend.Name, result.err))
+
+			if next < len(candidates) {
+				start()
+				pending++
+			}
+
+		case <-hedge:
+			hedge = nil
+
+			if next < len(candidates) {
+				candidates[next].metrics.hedged.Mark(1)
+				log.Debug("Hedging heimdall request", "backend", candidates[next].Name, "delay", m.config.HedgeDelay)
+
+				start()
+				pending++
+			}
+
+		case <-ctx.Done():
+			return zero, ctx.Err()
+
+		case <-m.closeCh:
+			return zero, ErrShutdownDetected
+		}
+	}
+
+	return zero, errors.Join(errs...)
+}
+
+// quorum runs the request on all backends concurrently and returns the first
+// value on which the configured number of backends agree.
+func quorum[T any](ctx context.Context, m *MultiHeimdallClient, fn func(context.Context, Client) (T, error)) (T, error) {
+	var zero T
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan backendResult[T], len(m.backends))
+
+	for _, b := range m.backends {
+		go func(b *backend) {
+			value, err := attempt(ctx, m, b, fn)
+			results <- backendResult[T]{value: value, err: err, backend: b}
+		}(b)
+	}
+
+	votes := make(map[common.Hash]int)
+
+	var errs []error
+
+	for pending := len(m.backends); pending > 0; pending-- {
+		select {
+		case result := <-results:
+			if result.err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", result.backend.Name, result.err))
+				continue
+			}
+
+			key, err := hashJSON(result.value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", result.backend.Name, err))
+				continue
+			}
+
+			votes[key]++
+			if votes[key] >= m.config.Quorum {
+				return result.value, nil
+			}
+
+		case <-ctx.Done():
+			return zero, ctx.Err()
+
+		case <-m.closeCh:
+			return zero, ErrShutdownDetected
+		}
+	}
+
+	errs = append([]error{fmt.Errorf("%w: want %d, votes %v", ErrQuorumNotReached, m.config.Quorum, votes)}, errs...)
+
+	return zero, errors.Join(errs...)
+}
+
+func hashJSON(v any) (common.Hash, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return crypto.Keccak256Hash(data), nil
+}
+
+func (m *MultiHeimdallClient) quorumEnabled() bool {
+	return m.config.Quorum > 1
+}
+
+func (m *MultiHeimdallClient) StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	return do(ctx, m, func(ctx context.Context, c Client) ([]*clerk.EventRecordWithTime, error) {
+		return c.StateSyncEvents(ctx, fromID, to)
+	})
+}
+
+func (m *MultiHeimdallClient) GetSpan(ctx context.Context, spanID uint64) (*types.Span, error) {
+	fn := func(ctx context.Context, c Client) (*types.Span, error) {
+		return c.GetSpan(ctx, spanID)
+	}
+
+	if m.quorumEnabled() {
+		return quorum(ctx, m, fn)
+	}
+
+	return do(ctx, m, fn)
+}
+
+// GetLatestSpan returns the latest span. With quorum enabled, the backends must
+// agree on the latest span which may fail shortly after a new span is committed.
+func (m *MultiHeimdallClient) GetLatestSpan(ctx context.Context) (*types.Span, error) {
+	fn := func(ctx context.Context, c Client) (*types.Span, error) {
+		return c.GetLatestSpan(ctx)
+	}
+
+	if m.quorumEnabled() {
+		return quorum(ctx, m, fn)
+	}
+
+	return do(ctx, m, fn)
+}
+
+func (m *MultiHeimdallClient) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	return do(ctx, m, func(ctx context.Context, c Client) (*checkpoint.Checkpoint, error) {
+		return c.FetchCheckpoint(ctx, number)
+	})
+}
+
+func (m *MultiHeimdallClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	return do(ctx, m, func(ctx context.Context, c Client) (int64, error) {
+		return c.FetchCheckpointCount(ctx)
+	})
+}
+
+// FetchMilestone returns the latest milestone. With quorum enabled, the backends
+// must agree on the latest milestone which may fail while a new one propagates.
+func (m *MultiHeimdallClient) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
+	fn := func(ctx context.Context, c Client) (*milestone.Milestone, error) {
+		return c.FetchMilestone(ctx)
+	}
+
+	if m.quorumEnabled() {
+		return quorum(ctx, m, fn)
+	}
+
+	return do(ctx, m, fn)
+}
+
+func (m *MultiHeimdallClient) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	return do(ctx, m, func(ctx context.Context, c Client) (int64, error) {
+		return c.FetchMilestoneCount(ctx)
+	})
+}
+
+func (m *MultiHeimdallClient) Close() {
+	m.closeOnce.Do(func() {
+		log.Debug("Shutdown detected, Closing Heimdall multi client")
+		close(m.closeCh)
+
+		for _, b := range m.backends {
+			b.Client.Close()
+		}
+	})
+}
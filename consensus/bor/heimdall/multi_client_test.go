@@ -0,0 +1,281 @@
+package heimdall
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0xPolygon/heimdall-v2/x/bor/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallapp"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errBackendDown = errors.New("backend down")
+
+// fakeBackend is a heimdall client serving a fixed span and milestone after an
+// optional delay, or failing every request.
+type fakeBackend struct {
+	delay     time.Duration
+	fail      bool
+	span      *types.Span
+	milestone *milestone.Milestone
+	calls     atomic.Int64
+	closed    atomic.Bool
+}
+
+func (f *fakeBackend) wait(ctx context.Context) error {
+	f.calls.Add(1)
+
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if f.fail {
+		return errBackendDown
+	}
+
+	return nil
+}
+
+func (f *fakeBackend) StateSyncEvents(ctx context.Context, _ uint64, _ int64) ([]*clerk.EventRecordWithTime, error) {
+	return nil, f.wait(ctx)
+}
+
+func (f *fakeBackend) GetSpan(ctx context.Context, _ uint64) (*types.Span, error) {
+	if err := f.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return f.span, nil
+}
+
+func (f *fakeBackend) GetLatestSpan(ctx context.Context) (*types.Span, error) {
+	return f.GetSpan(ctx, 0)
+}
+
+func (f *fakeBackend) FetchCheckpoint(ctx context.Context, _ int64) (*checkpoint.Checkpoint, error) {
+	return &checkpoint.Checkpoint{}, f.wait(ctx)
+}
+
+func (f *fakeBackend) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	return 1, f.wait(ctx)
+}
+
+func (f *fakeBackend) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
+	if err := f.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return f.milestone, nil
+}
+
+func (f *fakeBackend) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	return 1, f.wait(ctx)
+}
+
+func (f *fakeBackend) Close() {
+	f.closed.Store(true)
+}
+
+// checkedBackend is a fake heimdall client with a health check
+type checkedBackend struct {
+	fakeBackend
+	down atomic.Bool
+}
+
+func (c *checkedBackend) Health() error {
+	if c.down.Load() {
+		return errBackendDown
+	}
+
+	return nil
+}
+
+func TestMultiHeimdallClient_Failover(t *testing.T) {
+	t.Parallel()
+
+	span := &types.Span{Id: 1, StartBlock: 256, EndBlock: 6655}
+	bad := &fakeBackend{fail: true}
+	good := &fakeBackend{span: span}
+
+	client, err := NewMultiHeimdallClient([]Backend{
+		{Name: "failover-bad", Client: bad},
+		{Name: "failover-good", Client: good},
+	}, MultiClientConfig{FailureThreshold: 1, Cooldown: time.Minute})
+	require.NoError(t, err)
+
+	res, err := client.GetSpan(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, span, res)
+	require.Equal(t, int64(1), bad.calls.Load())
+
+	// The failing backend is now unhealthy and must be tried last
+	_, err = client.GetSpan(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), bad.calls.Load())
+	require.Equal(t, int64(2), good.calls.Load())
+
+	client.Close()
+	require.True(t, bad.closed.Load())
+	require.True(t, good.closed.Load())
+}
+
+func TestMultiHeimdallClient_HealthCheck(t *testing.T) {
+	t.Parallel()
+
+	span := &types.Span{Id: 1, StartBlock: 256, EndBlock: 6655}
+	checked := &checkedBackend{fakeBackend: fakeBackend{span: span}}
+	good := &fakeBackend{span: span}
+
+	checked.down.Store(true)
+
+	client, err := NewMultiHeimdallClient([]Backend{
+		{Name: "health-checked", Client: checked},
+		{Name: "health-good", Client: good},
+	}, MultiClientConfig{})
+	require.NoError(t, err)
+
+	// A backend failing its health check isn't queried
+	res, err := client.GetLatestSpan(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, span, res)
+	require.Equal(t, int64(0), checked.calls.Load())
+	require.Equal(t, int64(1), good.calls.Load())
+
+	// It's preferred again as soon as its health check passes
+	checked.down.Store(false)
+
+	_, err = client.GetLatestSpan(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), checked.calls.Load())
+	require.Equal(t, int64(1), good.calls.Load())
+}
+
+func TestMultiHeimdallClient_AppFailover(t *testing.T) {
+	t.Parallel()
+
+	span := &types.Span{Id: 1, StartBlock: 256, EndBlock: 6655}
+	good := &fakeBackend{span: span, milestone: &milestone.Milestone{EndBlock: 10}}
+
+	// The in-process heimdall app fails over while it isn't running
+	client, err := NewMultiHeimdallClient([]Backend{
+		{Name: "app-failover-app", Client: heimdallapp.NewHeimdallAppClient()},
+		{Name: "app-failover-good", Client: good},
+	}, MultiClientConfig{})
+	require.NoError(t, err)
+
+	res, err := client.GetLatestSpan(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, span, res)
+
+	m, err := client.FetchMilestone(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), m.EndBlock)
+
+	count, err := client.FetchCheckpointCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
+
+func TestMultiHeimdallClient_AllFailing(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewMultiHeimdallClient([]Backend{
+		{Name: "failing-a", Client: &fakeBackend{fail: true}},
+		{Name: "failing-b", Client: &fakeBackend{fail: true}},
+	}, MultiClientConfig{})
+	require.NoError(t, err)
+
+	_, err = client.FetchCheckpointCount(context.Background())
+	require.ErrorIs(t, err, errBackendDown)
+}
+
+func TestMultiHeimdallClient_Timeout(t *testing.T) {
+	t.Parallel()
+
+	span := &types.Span{Id: 2}
+	stuck := &fakeBackend{delay: time.Hour, span: &types.Span{Id: 3}}
+	good := &fakeBackend{span: span}
+
+	client, err := NewMultiHeimdallClient([]Backend{
+		{Name: "timeout-stuck", Client: stuck},
+		{Name: "timeout-good", Client: good},
+	}, MultiClientConfig{RequestTimeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+
+	res, err := client.GetSpan(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, span, res)
+
+	// Without a timeout a stuck backend would never fail over, hence the default applies
+	client, err = NewMultiHeimdallClient([]Backend{
+		{Name: "timeout-default-stuck", Client: stuck},
+	}, MultiClientConfig{})
+	require.NoError(t, err)
+	require.Equal(t, defaultRequestTimeout, client.config.RequestTimeout)
+}
+
+func TestMultiHeimdallClient_Hedging(t *testing.T) {
+	t.Parallel()
+
+	span := &types.Span{Id: 4}
+	slow := &fakeBackend{delay: time.Hour, span: &types.Span{Id: 5}}
+	fast := &fakeBackend{span: span}
+
+	client, err := NewMultiHeimdallClient([]Backend{
+		{Name: "hedge-slow", Client: slow},
+		{Name: "hedge-fast", Client: fast},
+	}, MultiClientConfig{HedgeDelay: 20 * time.Millisecond})
+	require.NoError(t, err)
+
+	start := time.Now()
+	res, err := client.GetSpan(context.Background(), 4)
+	require.NoError(t, err)
+	require.Equal(t, span, res)
+	require.Less(t, time.Since(start), time.Minute)
+
+	// The abandoned request must not count against the slow backend
+	require.True(t, client.backends[0].healthy(time.Now()))
+}
+
+func TestMultiHeimdallClient_Quorum(t *testing.T) {
+	t.Parallel()
+
+	honest := &milestone.Milestone{StartBlock: 10, EndBlock: 20, Hash: common.HexToHash("0x01")}
+	forged := &milestone.Milestone{StartBlock: 10, EndBlock: 20, Hash: common.HexToHash("0x02")}
+
+	client, err := NewMultiHeimdallClient([]Backend{
+		{Name: "quorum-forged", Client: &fakeBackend{milestone: forged}},
+		{Name: "quorum-honest-a", Client: &fakeBackend{milestone: honest, delay: 10 * time.Millisecond}},
+		{Name: "quorum-honest-b", Client: &fakeBackend{milestone: honest, delay: 10 * time.Millisecond}},
+	}, MultiClientConfig{Quorum: 2})
+	require.NoError(t, err)
+
+	res, err := client.FetchMilestone(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, honest.Hash, res.Hash)
+
+	client, err = NewMultiHeimdallClient([]Backend{
+		{Name: "noquorum-forged", Client: &fakeBackend{milestone: forged}},
+		{Name: "noquorum-honest", Client: &fakeBackend{milestone: honest}},
+		{Name: "noquorum-down", Client: &fakeBackend{fail: true}},
+	}, MultiClientConfig{Quorum: 2})
+	require.NoError(t, err)
+
+	_, err = client.FetchMilestone(context.Background())
+	require.ErrorIs(t, err, ErrQuorumNotReached)
+
+	_, err = NewMultiHeimdallClient([]Backend{
+		{Name: "invalid", Client: &fakeBackend{}},
+	}, MultiClientConfig{Quorum: 2})
+	require.ErrorIs(t, err, ErrInvalidQuorum)
+}
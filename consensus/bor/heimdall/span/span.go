@@ -1,12 +1,37 @@
 package span
 
 import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/bor/valset"
 
+	borTypes "github.com/0xPolygon/heimdall-v2/x/bor/types"
 	stakeTypes "github.com/0xPolygon/heimdall-v2/x/stake/types"
 )
 
+// UnmarshalSpanJSON decodes a span in the JSON format served by heimdall, either
+// as is or wrapped in the response of the span query.
+func UnmarshalSpanJSON(data []byte) (*borTypes.Span, error) {
+	interfaceRegistry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(interfaceRegistry)
+	cdc := codec.NewProtoCodec(interfaceRegistry)
+
+	response := new(borTypes.QuerySpanByIdResponse)
+	if err := cdc.UnmarshalJSON(data, response); err == nil && response.Span != nil {
+		return response.Span, nil
+	}
+
+	span := new(borTypes.Span)
+	if err := cdc.UnmarshalJSON(data, span); err != nil {
+		return nil, err
+	}
+
+	return span, nil
+}
+
 func ConvertHeimdallValSetToBorValSet(heimdallValSet stakeTypes.ValidatorSet) valset.ValidatorSet {
 	validators := make([]*valset.Validator, len(heimdallValSet.Validators))
 	for i, v := range heimdallValSet.Validators {
@@ -0,0 +1,44 @@
+package span
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalSpanJSON(t *testing.T) {
+	t.Parallel()
+
+	const span = `{
+		"id": "42",
+		"start_block": "6656",
+		"end_block": "13055",
+		"validator_set": {
+			"validators": [
+				{"val_id": "1", "signer": "0x0000000000000000000000000000000000000001", "voting_power": "100"},
+				{"val_id": "2", "signer": "0x0000000000000000000000000000000000000002", "voting_power": "200"}
+			],
+			"proposer": {"val_id": "1", "signer": "0x0000000000000000000000000000000000000001", "voting_power": "100"}
+		},
+		"selected_producers": [
+			{"val_id": "2", "signer": "0x0000000000000000000000000000000000000002", "voting_power": "200"}
+		],
+		"bor_chain_id": "137"
+	}`
+
+	for _, data := range []string{span, `{"span": ` + span + `}`} {
+		decoded, err := UnmarshalSpanJSON([]byte(data))
+		require.NoError(t, err)
+
+		require.Equal(t, uint64(42), decoded.Id)
+		require.Equal(t, uint64(6656), decoded.StartBlock)
+		require.Equal(t, uint64(13055), decoded.EndBlock)
+		require.Equal(t, "137", decoded.BorChainId)
+		require.Len(t, decoded.ValidatorSet.Validators, 2)
+		require.Len(t, decoded.SelectedProducers, 1)
+		require.Equal(t, int64(200), decoded.SelectedProducers[0].VotingPower)
+	}
+
+	_, err := UnmarshalSpanJSON([]byte(`{"unknown": true}`))
+	require.Error(t, err)
+}
@@ -11,6 +11,10 @@ import (
 )
 
 func (h *HeimdallAppClient) FetchCheckpointCount(_ context.Context) (int64, error) {
+	if err := h.Health(); err != nil {
+		return 0, err
+	}
+
 	log.Info("Fetching checkpoint count")
 
 	res, err := h.hApp.CheckpointKeeper.GetAckCount(h.NewContext())
@@ -24,6 +28,10 @@ func (h *HeimdallAppClient) FetchCheckpointCount(_ context.Context) (int64, erro
 }
 
 func (h *HeimdallAppClient) FetchCheckpoint(_ context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	if err := h.Health(); err != nil {
+		return nil, err
+	}
+
 	log.Info("Fetching checkpoint", "number", number)
 
 	res, err := h.hApp.CheckpointKeeper.GetCheckpointByNumber(h.NewContext(), uint64(number))
@@ -1,6 +1,8 @@
 package heimdallapp
 
 import (
+	"errors"
+
 	"github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -12,6 +14,9 @@ const (
 	stateFetchLimit = 50
 )
 
+// errAppNotRunning is returned while the in-process heimdall app isn't running
+var errAppNotRunning = errors.New("heimdall app is not running")
+
 type HeimdallAppClient struct {
 	hApp *app.HeimdallApp
 }
@@ -23,6 +28,16 @@ func NewHeimdallAppClient() *HeimdallAppClient {
 	}
 }
 
+// Health returns an error while the in-process heimdall app isn't running, so that a
+// multi-endpoint client fails over to its other backends without querying it.
+func (h *HeimdallAppClient) Health() error {
+	if h.hApp == nil {
+		return errAppNotRunning
+	}
+
+	return nil
+}
+
 func (h *HeimdallAppClient) Close() {
 	// Nothing to close as of now
 	log.Warn("Shutdown detected, Closing Heimdall App conn")
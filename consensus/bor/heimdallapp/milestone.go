@@ -13,6 +13,10 @@ import (
 )
 
 func (h *HeimdallAppClient) FetchMilestoneCount(_ context.Context) (int64, error) {
+	if err := h.Health(); err != nil {
+		return 0, err
+	}
+
 	log.Debug("Fetching milestone count")
 
 	res, err := h.hApp.MilestoneKeeper.GetMilestoneCount(h.NewContext())
@@ -26,6 +30,10 @@ func (h *HeimdallAppClient) FetchMilestoneCount(_ context.Context) (int64, error
 }
 
 func (h *HeimdallAppClient) FetchMilestone(_ context.Context) (*milestone.Milestone, error) {
+	if err := h.Health(); err != nil {
+		return nil, err
+	}
+
 	log.Debug("Fetching Latest Milestone")
 
 	res, err := h.hApp.MilestoneKeeper.GetLastMilestone(h.NewContext())
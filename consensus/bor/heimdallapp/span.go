@@ -8,7 +8,36 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
-func (h *HeimdallAppClient) GetSpan(_ context.Context, _ uint64) (*borTypes.Span, error) {
-	log.Warn("GetSpan not implemented!")
-	return nil, nil
+func (h *HeimdallAppClient) GetSpan(_ context.Context, spanID uint64) (*borTypes.Span, error) {
+	if err := h.Health(); err != nil {
+		return nil, err
+	}
+
+	log.Info("Fetching span", "spanID", spanID)
+
+	res, err := h.hApp.BorKeeper.GetSpan(h.NewContext(), spanID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Fetched span", "spanID", spanID)
+
+	return &res, nil
+}
+
+func (h *HeimdallAppClient) GetLatestSpan(_ context.Context) (*borTypes.Span, error) {
+	if err := h.Health(); err != nil {
+		return nil, err
+	}
+
+	log.Info("Fetching latest span")
+
+	res, err := h.hApp.BorKeeper.GetLastSpan(h.NewContext())
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Fetched latest span", "spanID", res.Id)
+
+	return &res, nil
 }
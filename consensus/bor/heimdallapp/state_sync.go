@@ -11,6 +11,10 @@ import (
 )
 
 func (h *HeimdallAppClient) StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	if err := h.Health(); err != nil {
+		return nil, err
+	}
+
 	totalRecords := make([]*clerk.EventRecordWithTime, 0)
 
 	for {
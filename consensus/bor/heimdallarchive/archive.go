@@ -0,0 +1,232 @@
+package heimdallarchive
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	borTypes "github.com/0xPolygon/heimdall-v2/x/bor/types"
+)
+
+// Version is the current version of the archive format
+const Version = 1
+
+var (
+	ErrInvalidArchiveHash   = errors.New("archive hash mismatch")
+	ErrInvalidArchiveSigner = errors.New("archive not signed by the trusted signer")
+	ErrUnsignedArchive      = errors.New("archive is not signed")
+	ErrUnsupportedVersion   = errors.New("unsupported archive version")
+	ErrChainIDMismatch      = errors.New("archive chain id mismatch")
+)
+
+// Archive holds the heimdall data (spans, checkpoints, milestones and state-sync
+// events) required by bor consensus to verify headers without a live heimdall.
+type Archive struct {
+	ChainID         string
+	Spans           []*borTypes.Span
+	CheckpointStart int64 // Heimdall number of the first checkpoint in the archive
+	Checkpoints     []*checkpoint.Checkpoint
+	Milestones      []*milestone.Milestone
+	MilestoneCount  int64
+	EventRecords    []*clerk.EventRecordWithTime
+}
+
+// checkpointRecord and milestoneRecord strip the heimdall specific json decoding
+// of checkpoints and milestones so that they can be stored in their plain form.
+type (
+	checkpointRecord checkpoint.Checkpoint
+	milestoneRecord  milestone.Milestone
+)
+
+type archiveJSON struct {
+	Version         uint64                       `json:"version"`
+	ChainID         string                       `json:"chain_id"`
+	Spans           []*borTypes.Span             `json:"spans"`
+	CheckpointStart int64                        `json:"checkpoint_start"`
+	Checkpoints     []*checkpointRecord          `json:"checkpoints"`
+	Milestones      []*milestoneRecord           `json:"milestones"`
+	MilestoneCount  int64                        `json:"milestone_count"`
+	EventRecords    []*clerk.EventRecordWithTime `json:"event_records"`
+}
+
+// Bundle is the on-disk representation of an archive. It carries the hash of the
+// encoded archive and optionally a signature over that hash so that the origin of
+// the data can be verified before it's used for consensus.
+type Bundle struct {
+	Archive   json.RawMessage `json:"archive"`
+	Hash      common.Hash     `json:"hash"`
+	Signer    common.Address  `json:"signer,omitempty"`
+	Signature hexutil.Bytes   `json:"signature,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (a *Archive) MarshalJSON() ([]byte, error) {
+	enc := archiveJSON{
+		Version:         Version,
+		ChainID:         a.ChainID,
+		Spans:           a.Spans,
+		CheckpointStart: a.CheckpointStart,
+		Checkpoints:     make([]*checkpointRecord, 0, len(a.Checkpoints)),
+		Milestones:      make([]*milestoneRecord, 0, len(a.Milestones)),
+		MilestoneCount:  a.MilestoneCount,
+		EventRecords:    a.EventRecords,
+	}
+
+	for _, c := range a.Checkpoints {
+		enc.Checkpoints = append(enc.Checkpoints, (*checkpointRecord)(c))
+	}
+
+	for _, m := range a.Milestones {
+		enc.Milestones = append(enc.Milestones, (*milestoneRecord)(m))
+	}
+
+	return json.Marshal(&enc)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (a *Archive) UnmarshalJSON(data []byte) error {
+	var dec archiveJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	if dec.Version != Version {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, dec.Version)
+	}
+
+	a.ChainID = dec.ChainID
+	a.Spans = dec.Spans
+	a.CheckpointStart = dec.CheckpointStart
+	a.MilestoneCount = dec.MilestoneCount
+	a.EventRecords = dec.EventRecords
+
+	a.Checkpoints = make([]*checkpoint.Checkpoint, 0, len(dec.Checkpoints))
+	for _, c := range dec.Checkpoints {
+		a.Checkpoints = append(a.Checkpoints, (*checkpoint.Checkpoint)(c))
+	}
+
+	a.Milestones = make([]*milestone.Milestone, 0, len(dec.Milestones))
+	for _, m := range dec.Milestones {
+		a.Milestones = append(a.Milestones, (*milestone.Milestone)(m))
+	}
+
+	return nil
+}
+
+// sort orders all the entries of the archive in ascending order so that lookups
+// can be done using binary search.
+func (a *Archive) sort() {
+	sort.SliceStable(a.Spans, func(i, j int) bool {
+		return a.Spans[i].Id < a.Spans[j].Id
+	})
+	sort.SliceStable(a.Checkpoints, func(i, j int) bool {
+		return a.Checkpoints[i].StartBlock < a.Checkpoints[j].StartBlock
+	})
+	sort.SliceStable(a.Milestones, func(i, j int) bool {
+		return a.Milestones[i].EndBlock < a.Milestones[j].EndBlock
+	})
+	sort.SliceStable(a.EventRecords, func(i, j int) bool {
+		return a.EventRecords[i].ID < a.EventRecords[j].ID
+	})
+}
+
+// NewBundle encodes the archive into a bundle. If a key is given, the bundle is
+// signed with it.
+func NewBundle(archive *Archive, key *ecdsa.PrivateKey) (*Bundle, error) {
+	archive.sort()
+
+	data, err := json.Marshal(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &Bundle{
+		Archive: data,
+		Hash:    crypto.Keccak256Hash(data),
+	}
+
+	if key != nil {
+		sig, err := crypto.Sign(bundle.Hash.Bytes(), key)
+		if err != nil {
+			return nil, err
+		}
+
+		bundle.Signer = crypto.PubkeyToAddress(key.PublicKey)
+		bundle.Signature = sig
+	}
+
+	return bundle, nil
+}
+
+// Open verifies the integrity of the bundle and decodes the archive from it. If a
+// trusted signer is given, the bundle must carry a valid signature from it.
+func (b *Bundle) Open(trustedSigner *common.Address) (*Archive, error) {
+	if crypto.Keccak256Hash(b.Archive) != b.Hash {
+		return nil, ErrInvalidArchiveHash
+	}
+
+	if len(b.Signature) != 0 {
+		pubkey, err := crypto.SigToPub(b.Hash.Bytes(), b.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive signature: %w", err)
+		}
+
+		if signer := crypto.PubkeyToAddress(*pubkey); signer != b.Signer {
+			return nil, fmt.Errorf("%w: signed by %s, claimed %s", ErrInvalidArchiveSigner, signer, b.Signer)
+		}
+	}
+
+	if trustedSigner != nil {
+		if len(b.Signature) == 0 {
+			return nil, ErrUnsignedArchive
+		}
+
+		if b.Signer != *trustedSigner {
+			return nil, fmt.Errorf("%w: signed by %s, want %s", ErrInvalidArchiveSigner, b.Signer, *trustedSigner)
+		}
+	}
+
+	archive := new(Archive)
+	if err := json.Unmarshal(b.Archive, archive); err != nil {
+		return nil, err
+	}
+
+	archive.sort()
+
+	return archive, nil
+}
+
+// WriteFile writes the bundle to the given path
+func WriteFile(path string, bundle *Bundle) error {
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0600)
+}
+
+// ReadFile reads the bundle from the given path and opens the archive in it
+func ReadFile(path string, trustedSigner *common.Address) (*Archive, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := new(Bundle)
+	if err := json.Unmarshal(data, bundle); err != nil {
+		return nil, fmt.Errorf("invalid archive bundle: %w", err)
+	}
+
+	return bundle.Open(trustedSigner)
+}
@@ -0,0 +1,158 @@
+package heimdallarchive
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	borTypes "github.com/0xPolygon/heimdall-v2/x/bor/types"
+)
+
+func testArchive() *Archive {
+	now := time.Unix(1_700_000_000, 0).UTC()
+
+	return &Archive{
+		ChainID: "137",
+		Spans: []*borTypes.Span{
+			{Id: 1, StartBlock: 256, EndBlock: 6655, BorChainId: "137"},
+			{Id: 0, StartBlock: 0, EndBlock: 255, BorChainId: "137"},
+		},
+		CheckpointStart: 5,
+		Checkpoints: []*checkpoint.Checkpoint{
+			{StartBlock: 0, EndBlock: 255, RootHash: common.HexToHash("0x01"), BorChainID: "137"},
+			{StartBlock: 256, EndBlock: 511, RootHash: common.HexToHash("0x02"), BorChainID: "137"},
+		},
+		Milestones: []*milestone.Milestone{
+			{StartBlock: 500, EndBlock: 510, Hash: common.HexToHash("0x03"), MilestoneID: "a"},
+			{StartBlock: 511, EndBlock: 520, Hash: common.HexToHash("0x04"), MilestoneID: "b"},
+		},
+		MilestoneCount: 2,
+		EventRecords: []*clerk.EventRecordWithTime{
+			{EventRecord: clerk.EventRecord{ID: 1, ChainID: "137"}, Time: now},
+			{EventRecord: clerk.EventRecord{ID: 2, ChainID: "137"}, Time: now.Add(time.Minute)},
+			{EventRecord: clerk.EventRecord{ID: 3, ChainID: "137"}, Time: now.Add(2 * time.Minute)},
+		},
+	}
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+
+	bundle, err := NewBundle(testArchive(), key)
+	require.NoError(t, err)
+	require.Equal(t, signer, bundle.Signer)
+
+	path := filepath.Join(t.TempDir(), "archive.json")
+	require.NoError(t, WriteFile(path, bundle))
+
+	archive, err := ReadFile(path, &signer)
+	require.NoError(t, err)
+	require.Equal(t, "137", archive.ChainID)
+	require.Len(t, archive.Spans, 2)
+	require.Equal(t, uint64(0), archive.Spans[0].Id)
+	require.Len(t, archive.Checkpoints, 2)
+	require.Equal(t, common.HexToHash("0x02"), archive.Checkpoints[1].RootHash)
+	require.Len(t, archive.Milestones, 2)
+	require.Equal(t, "b", archive.Milestones[1].MilestoneID)
+	require.Len(t, archive.EventRecords, 3)
+
+	// A different trusted signer must be rejected
+	other := common.HexToAddress("0x1234")
+	_, err = ReadFile(path, &other)
+	require.ErrorIs(t, err, ErrInvalidArchiveSigner)
+
+	// Tampering with the archive must be detected
+	bundle.Archive[len(bundle.Archive)-2] ^= 0x01
+	_, err = bundle.Open(nil)
+	require.ErrorIs(t, err, ErrInvalidArchiveHash)
+
+	// Unsigned bundles are rejected when a trusted signer is configured
+	unsigned, err := NewBundle(testArchive(), nil)
+	require.NoError(t, err)
+
+	_, err = unsigned.Open(&signer)
+	require.ErrorIs(t, err, ErrUnsignedArchive)
+
+	_, err = unsigned.Open(nil)
+	require.NoError(t, err)
+}
+
+func TestHeimdallArchiveClient(t *testing.T) {
+	ctx := context.Background()
+	client := NewHeimdallArchiveClient(testArchive())
+
+	span, err := client.GetSpan(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(256), span.StartBlock)
+
+	_, err = client.GetSpan(ctx, 2)
+	require.ErrorIs(t, err, ErrSpanNotFound)
+
+	latestSpan, err := client.GetLatestSpan(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), latestSpan.Id)
+
+	cp, err := client.FetchCheckpoint(ctx, 6)
+	require.NoError(t, err)
+	require.Equal(t, uint64(256), cp.StartBlock)
+
+	latestCp, err := client.FetchCheckpoint(ctx, -1)
+	require.NoError(t, err)
+	require.Equal(t, cp, latestCp)
+
+	_, err = client.FetchCheckpoint(ctx, 4)
+	require.ErrorIs(t, err, ErrCheckpointNotFound)
+
+	count, err := client.FetchCheckpointCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), count)
+
+	m, err := client.FetchMilestone(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "b", m.MilestoneID)
+
+	to := time.Unix(1_700_000_000, 0).Add(2 * time.Minute).Unix()
+	events, err := client.StateSyncEvents(ctx, 2, to)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(2), events[0].ID)
+
+	// Records past the first one outside of the window aren't served, even if they're in it
+	archive := testArchive()
+	archive.EventRecords = append(archive.EventRecords, &clerk.EventRecordWithTime{
+		EventRecord: clerk.EventRecord{ID: 4, ChainID: "137"},
+		Time:        time.Unix(1_700_000_000, 0),
+	})
+
+	events, err = NewHeimdallArchiveClient(archive).StateSyncEvents(ctx, 2, to)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(2), events[0].ID)
+}
+
+func TestHeimdallArchiveClientFromFile(t *testing.T) {
+	bundle, err := NewBundle(testArchive(), nil)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "archive.json")
+	require.NoError(t, WriteFile(path, bundle))
+
+	_, err = NewHeimdallArchiveClientFromFile(path, "137", nil)
+	require.NoError(t, err)
+
+	// The archive of another chain must be rejected
+	_, err = NewHeimdallArchiveClientFromFile(path, "80002", nil)
+	require.ErrorIs(t, err, ErrChainIDMismatch)
+}
@@ -0,0 +1,145 @@
+package heimdallarchive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/log"
+
+	borTypes "github.com/0xPolygon/heimdall-v2/x/bor/types"
+)
+
+var (
+	ErrSpanNotFound       = errors.New("span not found in heimdall archive")
+	ErrCheckpointNotFound = errors.New("checkpoint not found in heimdall archive")
+	ErrMilestoneNotFound  = errors.New("milestone not found in heimdall archive")
+)
+
+// HeimdallArchiveClient serves heimdall data from a local archive instead of a
+// live heimdall node. It's used for verifying historical headers in an offline
+// environment and as a deterministic heimdall in tests.
+type HeimdallArchiveClient struct {
+	archive *Archive
+}
+
+// NewHeimdallArchiveClient creates a new client serving data from the given archive
+func NewHeimdallArchiveClient(archive *Archive) *HeimdallArchiveClient {
+	archive.sort()
+
+	return &HeimdallArchiveClient{
+		archive: archive,
+	}
+}
+
+// NewHeimdallArchiveClientFromFile creates a new client serving data from the archive
+// bundle at the given path, which must be the archive of the given bor chain. If a trusted
+// signer is given, the bundle must be signed by it.
+func NewHeimdallArchiveClientFromFile(path string, chainID string, trustedSigner *common.Address) (*HeimdallArchiveClient, error) {
+	archive, err := ReadFile(path, trustedSigner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load heimdall archive: %w", err)
+	}
+
+	if archive.ChainID != chainID {
+		return nil, fmt.Errorf("%w: archive %s, node %s", ErrChainIDMismatch, archive.ChainID, chainID)
+	}
+
+	log.Info("Loaded heimdall archive", "path", path, "chainID", archive.ChainID,
+		"spans", len(archive.Spans), "checkpoints", len(archive.Checkpoints),
+		"milestones", len(archive.Milestones), "events", len(archive.EventRecords))
+
+	return NewHeimdallArchiveClient(archive), nil
+}
+
+func (h *HeimdallArchiveClient) StateSyncEvents(_ context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	records := h.archive.EventRecords
+
+	i := sort.Search(len(records), func(i int) bool {
+		return records[i].ID >= fromID
+	})
+
+	toTime := time.Unix(to, 0)
+	eventRecords := make([]*clerk.EventRecordWithTime, 0)
+
+	// Records are served in id order up to the first one outside of the window
+	for ; i < len(records) && records[i].Time.Before(toTime); i++ {
+		eventRecords = append(eventRecords, records[i])
+	}
+
+	return eventRecords, nil
+}
+
+func (h *HeimdallArchiveClient) GetSpan(_ context.Context, spanID uint64) (*borTypes.Span, error) {
+	spans := h.archive.Spans
+
+	i := sort.Search(len(spans), func(i int) bool {
+		return spans[i].Id >= spanID
+	})
+
+	if i == len(spans) || spans[i].Id != spanID {
+		return nil, fmt.Errorf("%w: id %d", ErrSpanNotFound, spanID)
+	}
+
+	return spans[i], nil
+}
+
+func (h *HeimdallArchiveClient) GetLatestSpan(_ context.Context) (*borTypes.Span, error) {
+	if len(h.archive.Spans) == 0 {
+		return nil, ErrSpanNotFound
+	}
+
+	return h.archive.Spans[len(h.archive.Spans)-1], nil
+}
+
+// FetchCheckpoint returns the checkpoint with the given heimdall number. If -1 is
+// passed, the latest checkpoint in the archive is returned.
+func (h *HeimdallArchiveClient) FetchCheckpoint(_ context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	checkpoints := h.archive.Checkpoints
+	if len(checkpoints) == 0 {
+		return nil, ErrCheckpointNotFound
+	}
+
+	if number == -1 {
+		return checkpoints[len(checkpoints)-1], nil
+	}
+
+	index := number - h.archive.CheckpointStart
+	if index < 0 || index >= int64(len(checkpoints)) {
+		return nil, fmt.Errorf("%w: number %d", ErrCheckpointNotFound, number)
+	}
+
+	return checkpoints[index], nil
+}
+
+func (h *HeimdallArchiveClient) FetchCheckpointCount(_ context.Context) (int64, error) {
+	if len(h.archive.Checkpoints) == 0 {
+		return 0, nil
+	}
+
+	return h.archive.CheckpointStart + int64(len(h.archive.Checkpoints)) - 1, nil
+}
+
+// FetchMilestone returns the latest milestone in the archive
+func (h *HeimdallArchiveClient) FetchMilestone(_ context.Context) (*milestone.Milestone, error) {
+	if len(h.archive.Milestones) == 0 {
+		return nil, ErrMilestoneNotFound
+	}
+
+	return h.archive.Milestones[len(h.archive.Milestones)-1], nil
+}
+
+func (h *HeimdallArchiveClient) FetchMilestoneCount(_ context.Context) (int64, error) {
+	return h.archive.MilestoneCount, nil
+}
+
+func (h *HeimdallArchiveClient) Close() {
+	// Nothing to close as the archive is held in memory
+	log.Debug("Shutdown detected, Closing Heimdall archive client")
+}
@@ -0,0 +1,99 @@
+package heimdallfake
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrUnavailable is the error a fault can return to simulate a heimdall which
+// doesn't answer at all.
+var ErrUnavailable = errors.New("fake heimdall unavailable")
+
+// Method is the name of a heimdall client method faults can be injected into.
+type Method string
+
+const (
+	MethodStateSyncEvents      Method = "StateSyncEvents"
+	MethodGetSpan              Method = "GetSpan"
+	MethodGetLatestSpan        Method = "GetLatestSpan"
+	MethodFetchCheckpoint      Method = "FetchCheckpoint"
+	MethodFetchCheckpointCount Method = "FetchCheckpointCount"
+	MethodFetchMilestone       Method = "FetchMilestone"
+	MethodFetchMilestoneCount  Method = "FetchMilestoneCount"
+)
+
+// Fault is a misbehaviour of heimdall applied to the calls of a method.
+type Fault struct {
+	Delay time.Duration // Delay before answering, cut short by the caller's context like a timeout
+	Err   error         // Error returned instead of the data, nil to answer normally after the delay
+	Times int           // Number of calls the fault applies to, 0 to apply it until cleared
+}
+
+// Inject queues a fault for the given method. The faults of a method apply in the
+// order they were injected, a fault applying until cleared shadowing the next ones.
+func (h *FakeHeimdall) Inject(method Method, fault Fault) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.faults[method] = append(h.faults[method], &fault)
+}
+
+// Clear removes the faults queued for the given method.
+func (h *FakeHeimdall) Clear(method Method) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.faults, method)
+}
+
+// ClearAll removes the faults queued for all the methods.
+func (h *FakeHeimdall) ClearAll() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.faults = make(map[Method][]*Fault)
+}
+
+// Calls returns the number of times the given method was called, faulty or not.
+func (h *FakeHeimdall) Calls(method Method) int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return h.calls[method]
+}
+
+// call records a call of the given method and applies the next fault queued for it,
+// returning the error the call must fail with if any.
+func (h *FakeHeimdall) call(ctx context.Context, method Method) error {
+	h.lock.Lock()
+
+	h.calls[method]++
+
+	var fault Fault
+
+	if faults := h.faults[method]; len(faults) > 0 {
+		fault = *faults[0]
+
+		if faults[0].Times > 0 {
+			if faults[0].Times--; faults[0].Times == 0 {
+				h.faults[method] = faults[1:]
+			}
+		}
+	}
+
+	h.lock.Unlock()
+
+	if fault.Delay > 0 {
+		timer := time.NewTimer(fault.Delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return fault.Err
+}
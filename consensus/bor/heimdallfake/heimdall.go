@@ -0,0 +1,295 @@
+package heimdallfake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	borSpan "github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/log"
+
+	borTypes "github.com/0xPolygon/heimdall-v2/x/bor/types"
+)
+
+var (
+	ErrSpanNotFound       = errors.New("span not found in fake heimdall")
+	ErrCheckpointNotFound = errors.New("checkpoint not found in fake heimdall")
+	ErrMilestoneNotFound  = errors.New("milestone not found in fake heimdall")
+)
+
+// FakeHeimdall is an in-process heimdall whose data and misbehaviours are scripted
+// by tests, for the heimdall dependent paths of bor to be exercised deterministically.
+// It serves the heimdall client API and, through NewWSClient, the heimdall ws API,
+// the data added to it being announced to the ws subscribers as heimdall would.
+type FakeHeimdall struct {
+	spans       map[uint64]*borTypes.Span
+	checkpoints []*checkpoint.Checkpoint
+	milestones  []*milestone.Milestone
+	events      []*clerk.EventRecordWithTime
+
+	stalled    bool // Whether milestones are withheld, the latest one served being stale
+	milestoneN int  // Number of milestones served, lagging behind while stalled
+
+	faults map[Method][]*Fault
+	calls  map[Method]int
+
+	clients      []*FakeHeimdallWS
+	disconnected bool // Whether the ws endpoint is down, nothing being announced
+	lock         sync.Mutex
+}
+
+// NewFakeHeimdall creates a fake heimdall without any data nor fault.
+func NewFakeHeimdall() *FakeHeimdall {
+	return &FakeHeimdall{
+		spans:  make(map[uint64]*borTypes.Span),
+		faults: make(map[Method][]*Fault),
+		calls:  make(map[Method]int),
+	}
+}
+
+// NewSpan creates a span of the given validators, all of them selected as producers.
+func NewSpan(id, startBlock, endBlock uint64, chainID string, validators []*valset.Validator) *borTypes.Span {
+	validatorSet := valset.NewValidatorSet(validators)
+
+	return &borTypes.Span{
+		Id:                id,
+		StartBlock:        startBlock,
+		EndBlock:          endBlock,
+		ValidatorSet:      borSpan.ConvertBorValSetToHeimdallValSet(validatorSet),
+		SelectedProducers: borSpan.ConvertBorValidatorsToHeimdallValidators(validatorSet.Validators),
+		BorChainId:        chainID,
+	}
+}
+
+// AddSpan adds or replaces a span and announces it.
+func (h *FakeHeimdall) AddSpan(span *borTypes.Span) {
+	h.lock.Lock()
+	h.spans[span.Id] = span
+	clients := h.connectedClients()
+	h.lock.Unlock()
+
+	for _, client := range clients {
+		client.spans.publish(span.Id)
+	}
+}
+
+// RemoveSpan removes a span, leaving a gap in the spans served.
+func (h *FakeHeimdall) RemoveSpan(id uint64) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	delete(h.spans, id)
+}
+
+// AddCheckpoint appends a checkpoint and announces its number.
+func (h *FakeHeimdall) AddCheckpoint(cp *checkpoint.Checkpoint) {
+	h.lock.Lock()
+	h.checkpoints = append(h.checkpoints, cp)
+	number := uint64(len(h.checkpoints))
+	clients := h.connectedClients()
+	h.lock.Unlock()
+
+	for _, client := range clients {
+		client.checkpoints.publish(number)
+	}
+}
+
+// AddMilestone appends a milestone and announces it, unless milestones are stalled.
+// The milestone isn't checked against any chain, for divergent milestones to be
+// scripted as well.
+func (h *FakeHeimdall) AddMilestone(m *milestone.Milestone) {
+	h.lock.Lock()
+	h.milestones = append(h.milestones, m)
+
+	if h.stalled {
+		h.lock.Unlock()
+		return
+	}
+
+	h.milestoneN = len(h.milestones)
+	clients := h.connectedClients()
+	h.lock.Unlock()
+
+	for _, client := range clients {
+		client.milestones.publish(m)
+	}
+}
+
+// StallMilestones withholds the milestones added from now on, heimdall serving the
+// latest milestone it had until ResumeMilestones is called.
+func (h *FakeHeimdall) StallMilestones() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.stalled = true
+}
+
+// ResumeMilestones serves the milestones withheld since StallMilestones was called
+// and announces the latest one.
+func (h *FakeHeimdall) ResumeMilestones() {
+	h.lock.Lock()
+	h.stalled = false
+
+	if h.milestoneN == len(h.milestones) {
+		h.lock.Unlock()
+		return
+	}
+
+	h.milestoneN = len(h.milestones)
+	latest := h.milestones[h.milestoneN-1]
+	clients := h.connectedClients()
+	h.lock.Unlock()
+
+	for _, client := range clients {
+		client.milestones.publish(latest)
+	}
+}
+
+// AddEventRecord adds a state-sync event record and announces its id. The records are
+// served sorted by id as heimdall does, but announced in the order they were added,
+// for out of order announcements and gaps in the ids to be scripted.
+func (h *FakeHeimdall) AddEventRecord(record *clerk.EventRecordWithTime) {
+	h.lock.Lock()
+	h.events = append(h.events, record)
+	sort.SliceStable(h.events, func(i, j int) bool {
+		return h.events[i].ID < h.events[j].ID
+	})
+	clients := h.connectedClients()
+	h.lock.Unlock()
+
+	for _, client := range clients {
+		client.stateSyncs.publish(record.ID)
+	}
+}
+
+func (h *FakeHeimdall) StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	if err := h.call(ctx, MethodStateSyncEvents); err != nil {
+		return nil, err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	toTime := time.Unix(to, 0)
+	records := make([]*clerk.EventRecordWithTime, 0)
+
+	for _, record := range h.events {
+		if record.ID >= fromID && record.Time.Before(toTime) {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+func (h *FakeHeimdall) GetSpan(ctx context.Context, spanID uint64) (*borTypes.Span, error) {
+	if err := h.call(ctx, MethodGetSpan); err != nil {
+		return nil, err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	span, ok := h.spans[spanID]
+	if !ok {
+		return nil, fmt.Errorf("%w: id %d", ErrSpanNotFound, spanID)
+	}
+
+	return span, nil
+}
+
+func (h *FakeHeimdall) GetLatestSpan(ctx context.Context) (*borTypes.Span, error) {
+	if err := h.call(ctx, MethodGetLatestSpan); err != nil {
+		return nil, err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	var latest *borTypes.Span
+
+	for _, span := range h.spans {
+		if latest == nil || span.Id > latest.Id {
+			latest = span
+		}
+	}
+
+	if latest == nil {
+		return nil, ErrSpanNotFound
+	}
+
+	return latest, nil
+}
+
+// FetchCheckpoint returns the checkpoint with the given number, starting at 1. If -1
+// is passed, the latest checkpoint is returned.
+func (h *FakeHeimdall) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	if err := h.call(ctx, MethodFetchCheckpoint); err != nil {
+		return nil, err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if number == -1 {
+		number = int64(len(h.checkpoints))
+	}
+
+	if number < 1 || number > int64(len(h.checkpoints)) {
+		return nil, fmt.Errorf("%w: number %d", ErrCheckpointNotFound, number)
+	}
+
+	return h.checkpoints[number-1], nil
+}
+
+func (h *FakeHeimdall) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	if err := h.call(ctx, MethodFetchCheckpointCount); err != nil {
+		return 0, err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return int64(len(h.checkpoints)), nil
+}
+
+// FetchMilestone returns the latest milestone served, which is stale while
+// milestones are stalled.
+func (h *FakeHeimdall) FetchMilestone(ctx context.Context) (*milestone.Milestone, error) {
+	if err := h.call(ctx, MethodFetchMilestone); err != nil {
+		return nil, err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.milestoneN == 0 {
+		return nil, ErrMilestoneNotFound
+	}
+
+	return h.milestones[h.milestoneN-1], nil
+}
+
+func (h *FakeHeimdall) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	if err := h.call(ctx, MethodFetchMilestoneCount); err != nil {
+		return 0, err
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return int64(h.milestoneN), nil
+}
+
+func (h *FakeHeimdall) Close() {
+	// Nothing to close as the fake is held in memory, the same fake being shared by
+	// the nodes of a test network
+	log.Debug("Shutdown detected, Closing fake Heimdall client")
+}
@@ -0,0 +1,228 @@
+package heimdallfake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+)
+
+var (
+	_ bor.IHeimdallClient   = (*FakeHeimdall)(nil)
+	_ bor.IHeimdallWSClient = (*FakeHeimdallWS)(nil)
+)
+
+func TestFaults(t *testing.T) {
+	t.Parallel()
+
+	h := NewFakeHeimdall()
+	h.AddCheckpoint(&checkpoint.Checkpoint{EndBlock: 255})
+
+	// The faults apply in order, for the number of calls they were injected for
+	h.Inject(MethodFetchCheckpoint, Fault{Err: ErrUnavailable, Times: 2})
+	h.Inject(MethodFetchCheckpoint, Fault{Err: context.Canceled, Times: 1})
+
+	for i := 0; i < 2; i++ {
+		_, err := h.FetchCheckpoint(context.Background(), -1)
+		require.ErrorIs(t, err, ErrUnavailable)
+	}
+
+	_, err := h.FetchCheckpoint(context.Background(), -1)
+	require.ErrorIs(t, err, context.Canceled)
+
+	latest, err := h.FetchCheckpoint(context.Background(), -1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(255), latest.EndBlock)
+	require.Equal(t, 4, h.Calls(MethodFetchCheckpoint))
+
+	// A delay longer than the caller's timeout times the call out
+	h.Inject(MethodFetchMilestone, Fault{Delay: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = h.FetchMilestone(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A fault injected until cleared applies to every call
+	h.Clear(MethodFetchMilestone)
+	h.Inject(MethodGetLatestSpan, Fault{Err: ErrUnavailable})
+
+	for i := 0; i < 3; i++ {
+		_, err := h.GetLatestSpan(context.Background())
+		require.ErrorIs(t, err, ErrUnavailable)
+	}
+
+	h.ClearAll()
+
+	_, err = h.GetLatestSpan(context.Background())
+	require.ErrorIs(t, err, ErrSpanNotFound)
+
+	_, err = h.FetchMilestone(context.Background())
+	require.ErrorIs(t, err, ErrMilestoneNotFound)
+}
+
+func TestSpans(t *testing.T) {
+	t.Parallel()
+
+	validators := []*valset.Validator{
+		{Address: common.HexToAddress("0x01"), VotingPower: 10},
+		{Address: common.HexToAddress("0x02"), VotingPower: 10},
+	}
+
+	h := NewFakeHeimdall()
+	h.AddSpan(NewSpan(0, 0, 255, "15001", validators))
+	h.AddSpan(NewSpan(1, 256, 6655, "15001", validators))
+	h.AddSpan(NewSpan(2, 6656, 13055, "15001", validators))
+
+	span, err := h.GetSpan(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(256), span.StartBlock)
+	require.Len(t, span.SelectedProducers, 2)
+	require.Equal(t, "15001", span.BorChainId)
+
+	// Removing a span leaves a gap
+	h.RemoveSpan(1)
+
+	_, err = h.GetSpan(context.Background(), 1)
+	require.ErrorIs(t, err, ErrSpanNotFound)
+
+	latest, err := h.GetLatestSpan(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), latest.Id)
+}
+
+func TestStallMilestones(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := NewFakeHeimdall()
+	events := h.NewWSClient().SubscribeMilestoneEvents(ctx)
+
+	h.AddMilestone(&milestone.Milestone{EndBlock: 16, MilestoneID: "a"})
+	require.Equal(t, "a", (<-events).MilestoneID)
+
+	// The milestones added while stalled are neither served nor announced
+	h.StallMilestones()
+	h.AddMilestone(&milestone.Milestone{EndBlock: 32, MilestoneID: "b"})
+	h.AddMilestone(&milestone.Milestone{EndBlock: 48, MilestoneID: "c"})
+
+	stale, err := h.FetchMilestone(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "a", stale.MilestoneID)
+
+	count, err := h.FetchMilestoneCount(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+	require.Empty(t, events)
+
+	// Resuming announces the latest milestone only
+	h.ResumeMilestones()
+	require.Equal(t, "c", (<-events).MilestoneID)
+	require.Empty(t, events)
+
+	latest, err := h.FetchMilestone(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "c", latest.MilestoneID)
+}
+
+func TestEventRecords(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := NewFakeHeimdall()
+	events := h.NewWSClient().SubscribeStateSyncEvents(ctx)
+
+	now := time.Unix(1_700_000_000, 0)
+
+	for _, id := range []uint64{1, 3, 2, 5} {
+		h.AddEventRecord(&clerk.EventRecordWithTime{EventRecord: clerk.EventRecord{ID: id}, Time: now.Add(time.Duration(id) * time.Second)})
+	}
+
+	// The records are announced in the order they were added
+	for _, id := range []uint64{1, 3, 2, 5} {
+		require.Equal(t, id, <-events)
+	}
+
+	// but served sorted by id, within the time window
+	records, err := h.StateSyncEvents(context.Background(), 2, now.Add(5*time.Second).Unix())
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, uint64(2), records[0].ID)
+	require.Equal(t, uint64(3), records[1].ID)
+}
+
+func TestDropSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := NewFakeHeimdall()
+
+	a, b := h.NewWSClient(), h.NewWSClient()
+	spansA, spansB := a.SubscribeSpanEvents(ctx), b.SubscribeSpanEvents(ctx)
+
+	// Closing a client doesn't affect the other ones
+	require.NoError(t, a.Close())
+
+	_, ok := <-spansA
+	require.False(t, ok)
+
+	h.AddSpan(NewSpan(0, 0, 255, "15001", []*valset.Validator{{Address: common.HexToAddress("0x01"), VotingPower: 10}}))
+	require.Equal(t, uint64(0), <-spansB)
+
+	// Dropping the subscriptions closes them, the clients subscribing again
+	h.DropSubscriptions()
+
+	_, ok = <-spansB
+	require.False(t, ok)
+
+	checkpoints := b.SubscribeCheckpointEvents(ctx)
+	h.AddCheckpoint(&checkpoint.Checkpoint{EndBlock: 255})
+	require.Equal(t, uint64(1), <-checkpoints)
+
+	// While disconnected, the subscriptions are refused and nothing is announced
+	h.DisconnectWS()
+
+	_, ok = <-checkpoints
+	require.False(t, ok)
+
+	_, ok = <-b.SubscribeCheckpointEvents(ctx)
+	require.False(t, ok)
+
+	h.AddCheckpoint(&checkpoint.Checkpoint{EndBlock: 511})
+	h.ReconnectWS()
+
+	checkpoints = b.SubscribeCheckpointEvents(ctx)
+	h.AddCheckpoint(&checkpoint.Checkpoint{EndBlock: 767})
+	require.Equal(t, uint64(3), <-checkpoints)
+
+	count, err := h.FetchCheckpointCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	// Cancelling the context of a subscription closes it
+	subCtx, subCancel := context.WithCancel(ctx)
+	milestones := b.SubscribeMilestoneEvents(subCtx)
+	subCancel()
+
+	select {
+	case _, ok := <-milestones:
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("subscription not closed")
+	}
+}
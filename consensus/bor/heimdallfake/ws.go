@@ -0,0 +1,222 @@
+package heimdallfake
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+)
+
+// subscriptionBuffer is the number of announcements a subscriber can lag behind
+// before the fake blocks on announcing new data.
+const subscriptionBuffer = 64
+
+// FakeHeimdallWS is the heimdall ws client of a node connected to a fake heimdall.
+// Every node gets its own client, for a node shutting down not to unsubscribe the
+// other nodes sharing the fake.
+type FakeHeimdallWS struct {
+	heimdall *FakeHeimdall
+
+	milestones  feed[*milestone.Milestone]
+	checkpoints feed[uint64]
+	spans       feed[uint64]
+	stateSyncs  feed[uint64]
+}
+
+// NewWSClient creates a heimdall ws client to which the data added to the fake from
+// now on is announced.
+func (h *FakeHeimdall) NewWSClient() *FakeHeimdallWS {
+	client := &FakeHeimdallWS{heimdall: h}
+
+	h.lock.Lock()
+	h.clients = append(h.clients, client)
+	h.lock.Unlock()
+
+	return client
+}
+
+// DropSubscriptions closes the ws subscriptions of all the clients, as a broken
+// connection to heimdall would. The clients are free to subscribe again.
+func (h *FakeHeimdall) DropSubscriptions() {
+	h.lock.Lock()
+	clients := h.clients
+	h.lock.Unlock()
+
+	for _, client := range clients {
+		client.unsubscribe()
+	}
+}
+
+// DisconnectWS closes the ws subscriptions of all the clients and refuses the new ones
+// until ReconnectWS is called, the data added meanwhile being left unannounced, as if
+// the heimdall ws endpoint was down.
+func (h *FakeHeimdall) DisconnectWS() {
+	h.lock.Lock()
+	h.disconnected = true
+	h.lock.Unlock()
+
+	h.DropSubscriptions()
+}
+
+// ReconnectWS accepts ws subscriptions again after DisconnectWS.
+func (h *FakeHeimdall) ReconnectWS() {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.disconnected = false
+}
+
+// connectedClients returns the clients the data added is announced to. The lock must
+// be held.
+func (h *FakeHeimdall) connectedClients() []*FakeHeimdallWS {
+	if h.disconnected {
+		return nil
+	}
+
+	return h.clients
+}
+
+func (c *FakeHeimdallWS) SubscribeMilestoneEvents(ctx context.Context) <-chan *milestone.Milestone {
+	return c.milestones.subscribe(ctx, c.connected())
+}
+
+func (c *FakeHeimdallWS) SubscribeCheckpointEvents(ctx context.Context) <-chan uint64 {
+	return c.checkpoints.subscribe(ctx, c.connected())
+}
+
+func (c *FakeHeimdallWS) SubscribeSpanEvents(ctx context.Context) <-chan uint64 {
+	return c.spans.subscribe(ctx, c.connected())
+}
+
+func (c *FakeHeimdallWS) SubscribeStateSyncEvents(ctx context.Context) <-chan uint64 {
+	return c.stateSyncs.subscribe(ctx, c.connected())
+}
+
+func (c *FakeHeimdallWS) Unsubscribe(_ context.Context) error {
+	c.unsubscribe()
+	return nil
+}
+
+func (c *FakeHeimdallWS) Close() error {
+	c.unsubscribe()
+
+	h := c.heimdall
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for i, client := range h.clients {
+		if client == c {
+			h.clients = append(h.clients[:i:i], h.clients[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// connected returns whether the ws endpoint of the fake accepts subscriptions.
+func (c *FakeHeimdallWS) connected() bool {
+	c.heimdall.lock.Lock()
+	defer c.heimdall.lock.Unlock()
+
+	return !c.heimdall.disconnected
+}
+
+func (c *FakeHeimdallWS) unsubscribe() {
+	c.milestones.close()
+	c.checkpoints.close()
+	c.spans.close()
+	c.stateSyncs.close()
+}
+
+// feed delivers the announcements of a kind of data to its subscriptions.
+type feed[T any] struct {
+	subs []*subscription[T]
+	lock sync.Mutex
+}
+
+// subscribe returns the channel of a new subscription, which is closed right away if
+// the endpoint isn't connected, like a subscription failing to be established.
+func (f *feed[T]) subscribe(ctx context.Context, connected bool) <-chan T {
+	sub := &subscription[T]{
+		ch:   make(chan T, subscriptionBuffer),
+		done: make(chan struct{}),
+	}
+
+	if !connected {
+		sub.close()
+		return sub.ch
+	}
+
+	f.lock.Lock()
+	f.subs = append(f.subs, sub)
+	f.lock.Unlock()
+
+	// Close the subscription once the subscriber is gone, like the real client does
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.close()
+		case <-sub.done:
+		}
+	}()
+
+	return sub.ch
+}
+
+func (f *feed[T]) publish(value T) {
+	f.lock.Lock()
+	subs := f.subs
+	f.lock.Unlock()
+
+	for _, sub := range subs {
+		sub.send(value)
+	}
+}
+
+func (f *feed[T]) close() {
+	f.lock.Lock()
+	subs := f.subs
+	f.subs = nil
+	f.lock.Unlock()
+
+	for _, sub := range subs {
+		sub.close()
+	}
+}
+
+// subscription is the channel of a subscriber, which is closed once and never sent
+// to afterwards.
+type subscription[T any] struct {
+	ch     chan T
+	done   chan struct{}
+	once   sync.Once
+	closed bool
+	lock   sync.Mutex
+}
+
+func (s *subscription[T]) send(value T) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.ch <- value:
+	case <-s.done:
+	}
+}
+
+func (s *subscription[T]) close() {
+	s.once.Do(func() {
+		close(s.done)
+
+		s.lock.Lock()
+		s.closed = true
+		close(s.ch)
+		s.lock.Unlock()
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,37 +14,70 @@ import (
 	"github.com/gorilla/websocket"
 )
 
+const (
+	milestoneQuery  = "tm.event='NewBlock' AND milestone.number>0"
+	checkpointQuery = "tm.event='NewBlock' AND checkpoint-ack.header-index EXISTS"
+	spanQuery       = "tm.event='NewBlock' AND propose-span.span-id EXISTS"
+	stateSyncQuery  = "tm.event='NewBlock' AND record.record-id EXISTS"
+)
+
 // HeimdallWSClient represents a websocket client with auto-reconnection.
+// Every subscription runs on its own connection so that a broken stream
+// doesn't affect the others.
 type HeimdallWSClient struct {
-	conn   *websocket.Conn
-	url    string // store the URL for reconnection
-	events chan *milestone.Milestone
-	done   chan struct{}
-	mu     sync.Mutex
+	url   string // store the URL for reconnection
+	conns map[string]*websocket.Conn
+	done  chan struct{}
+	mu    sync.Mutex
 }
 
 // NewHeimdallWSClient creates a new WS client for Heimdall.
 func NewHeimdallWSClient(url string) (*HeimdallWSClient, error) {
 	return &HeimdallWSClient{
-		conn:   nil,
-		url:    url,
-		events: make(chan *milestone.Milestone),
-		done:   make(chan struct{}),
+		url:   url,
+		conns: make(map[string]*websocket.Conn),
+		done:  make(chan struct{}),
 	}, nil
 }
 
 // SubscribeMilestoneEvents sends the subscription request and starts processing incoming messages.
 func (c *HeimdallWSClient) SubscribeMilestoneEvents(ctx context.Context) <-chan *milestone.Milestone {
-	c.tryUntilSubscribeMilestoneEvents(ctx)
+	return subscribe(ctx, c, milestoneQuery, parseMilestoneEvent)
+}
+
+// SubscribeCheckpointEvents subscribes to checkpoint acknowledgements and returns
+// the numbers of the acknowledged checkpoints.
+func (c *HeimdallWSClient) SubscribeCheckpointEvents(ctx context.Context) <-chan uint64 {
+	return subscribe(ctx, c, checkpointQuery, idParser("checkpoint-ack", "header-index"))
+}
+
+// SubscribeSpanEvents subscribes to committed spans and returns their ids.
+func (c *HeimdallWSClient) SubscribeSpanEvents(ctx context.Context) <-chan uint64 {
+	return subscribe(ctx, c, spanQuery, idParser("propose-span", "span-id"))
+}
+
+// SubscribeStateSyncEvents subscribes to committed state-sync (clerk) records and
+// returns their ids.
+func (c *HeimdallWSClient) SubscribeStateSyncEvents(ctx context.Context) <-chan uint64 {
+	return subscribe(ctx, c, stateSyncQuery, idParser("record", "record-id"))
+}
+
+// subscribe starts a subscription for the given query and delivers every message
+// which can be parsed into an event on the returned channel. The channel is closed
+// once the context is cancelled or the client is unsubscribed.
+func subscribe[T any](ctx context.Context, c *HeimdallWSClient, query string, parse func(*wsResponse) []T) <-chan T {
+	events := make(chan T)
+
+	c.tryUntilSubscribe(ctx, query)
 
 	// Start the goroutine to read messages.
-	go c.readMessages(ctx)
+	go readMessages(ctx, c, query, events, parse)
 
-	return c.events
+	return events
 }
 
 // retry until subscribe
-func (c *HeimdallWSClient) tryUntilSubscribeMilestoneEvents(ctx context.Context) {
+func (c *HeimdallWSClient) tryUntilSubscribe(ctx context.Context, query string) {
 	firstTime := true
 	for {
 		if !firstTime {
@@ -64,11 +98,15 @@ func (c *HeimdallWSClient) tryUntilSubscribeMilestoneEvents(ctx context.Context)
 
 		conn, _, err := websocket.DefaultDialer.Dial(c.url, nil)
 		if err != nil {
-			log.Error("failed to dial websocket on heimdall ws subscription", "err", err)
+			log.Error("failed to dial websocket on heimdall ws subscription", "query", query, "err", err)
 			continue
 		}
+
 		c.mu.Lock()
-		c.conn = conn
+		if old := c.conns[query]; old != nil {
+			old.Close()
+		}
+		c.conns[query] = conn
 		c.mu.Unlock()
 
 		// Build the subscription request.
@@ -77,20 +115,27 @@ func (c *HeimdallWSClient) tryUntilSubscribeMilestoneEvents(ctx context.Context)
 			Method:  "subscribe",
 			ID:      0,
 		}
-		req.Params.Query = "tm.event='NewBlock' AND milestone.number>0"
+		req.Params.Query = query
 
-		if err := c.conn.WriteJSON(req); err != nil {
-			log.Error("failed to send subscription request on heimdall ws subscription", "err", err)
+		if err := conn.WriteJSON(req); err != nil {
+			log.Error("failed to send subscription request on heimdall ws subscription", "query", query, "err", err)
 			continue
 		}
-		log.Info("Successfully connected on heimdall ws subscription")
+		log.Info("Successfully connected on heimdall ws subscription", "query", query)
 		return
 	}
 }
 
+func (c *HeimdallWSClient) conn(query string) *websocket.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.conns[query]
+}
+
 // readMessages continuously reads messages from the websocket, handling reconnections if necessary.
-func (c *HeimdallWSClient) readMessages(ctx context.Context) {
-	defer close(c.events)
+func readMessages[T any](ctx context.Context, c *HeimdallWSClient, query string, events chan T, parse func(*wsResponse) []T) {
+	defer close(events)
 	for {
 		// Check if the context or unsubscribe signal is set.
 		select {
@@ -102,18 +147,24 @@ func (c *HeimdallWSClient) readMessages(ctx context.Context) {
 			// continue to process messages
 		}
 
-		if err := c.conn.SetReadDeadline(time.Now().Add(30 * time.Second)); err != nil {
-			log.Error("failed to set read deadline on heimdall ws subscription", "err", err)
+		conn := c.conn(query)
+		if conn == nil {
+			c.tryUntilSubscribe(ctx, query)
+			continue
+		}
+
+		if err := conn.SetReadDeadline(time.Now().Add(30 * time.Second)); err != nil {
+			log.Error("failed to set read deadline on heimdall ws subscription", "query", query, "err", err)
 
-			c.tryUntilSubscribeMilestoneEvents(ctx)
+			c.tryUntilSubscribe(ctx, query)
 			continue
 		}
 
-		_, message, err := c.conn.ReadMessage()
+		_, message, err := conn.ReadMessage()
 		if err != nil {
-			log.Error("connection lost; will attempt to reconnect on heimdall ws subscription", "error", err)
+			log.Error("connection lost; will attempt to reconnect on heimdall ws subscription", "query", query, "error", err)
 
-			c.tryUntilSubscribeMilestoneEvents(ctx)
+			c.tryUntilSubscribe(ctx, query)
 			continue
 		}
 
@@ -123,54 +174,94 @@ func (c *HeimdallWSClient) readMessages(ctx context.Context) {
 			continue
 		}
 
-		// Find the milestone event.
-		var milestoneEvent *wsEvent
-		for _, event := range resp.Result.Data.Value.FinalizeBlock.Events {
-			if event.Type == "milestone" {
-				// In this case their types are set to the types of the respective iteration values
-				// and their scope is the block of the "for" statement; they are re-used in each iteration.
-				e := event
-				milestoneEvent = &e
-				break
+		// Deliver the events, respecting context cancellation.
+		for _, event := range parse(&resp) {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			case <-c.done:
+				return
 			}
 		}
-		if milestoneEvent == nil {
+	}
+}
+
+// findEvents returns the attributes of all the events of the given type in the
+// finalized block, mapped for easier lookup.
+func findEvents(resp *wsResponse, eventType string) []map[string]string {
+	var found []map[string]string
+
+	for _, event := range resp.Result.Data.Value.FinalizeBlock.Events {
+		if event.Type != eventType {
 			continue
 		}
 
-		// Map attributes for easier lookup.
 		attrs := make(map[string]string)
-		for _, attr := range milestoneEvent.Attributes {
+		for _, attr := range event.Attributes {
 			attrs[attr.Key] = attr.Value
 		}
 
-		// Build the Milestone object from attributes.
-		m := &milestone.Milestone{
-			Proposer:    common.HexToAddress(attrs["proposer"]),
-			Hash:        common.HexToHash(attrs["hash"]),
-			BorChainID:  attrs["bor_chain_id"],
-			MilestoneID: attrs["milestone_id"],
-		}
-		if startBlock, err := strconv.ParseUint(attrs["start_block"], 10, 64); err == nil {
-			m.StartBlock = startBlock
-		}
-		if endBlock, err := strconv.ParseUint(attrs["end_block"], 10, 64); err == nil {
-			m.EndBlock = endBlock
-		}
-		if timestamp, err := strconv.ParseUint(attrs["timestamp"], 10, 64); err == nil {
-			m.Timestamp = timestamp
-		}
+		found = append(found, attrs)
+	}
 
-		// Deliver the milestone event, respecting context cancellation.
-		select {
-		case c.events <- m:
-		case <-ctx.Done():
-			return
+	return found
+}
+
+func parseMilestoneEvent(resp *wsResponse) []*milestone.Milestone {
+	// Find the milestone event.
+	events := findEvents(resp, "milestone")
+	if len(events) == 0 {
+		return nil
+	}
+
+	attrs := events[0]
+
+	// Build the Milestone object from attributes.
+	m := &milestone.Milestone{
+		Proposer:    common.HexToAddress(attrs["proposer"]),
+		Hash:        common.HexToHash(attrs["hash"]),
+		BorChainID:  attrs["bor_chain_id"],
+		MilestoneID: attrs["milestone_id"],
+	}
+	if startBlock, err := strconv.ParseUint(attrs["start_block"], 10, 64); err == nil {
+		m.StartBlock = startBlock
+	}
+	if endBlock, err := strconv.ParseUint(attrs["end_block"], 10, 64); err == nil {
+		m.EndBlock = endBlock
+	}
+	if timestamp, err := strconv.ParseUint(attrs["timestamp"], 10, 64); err == nil {
+		m.Timestamp = timestamp
+	}
+
+	return []*milestone.Milestone{m}
+}
+
+// idParser returns a parser extracting the ids of the given event type. The side
+// tx events only carry ids, the full objects must be fetched from heimdall. Events
+// of side txs which were voted down are skipped.
+func idParser(eventType string, idKey string) func(*wsResponse) []uint64 {
+	return func(resp *wsResponse) []uint64 {
+		var ids []uint64
+
+		for _, attrs := range findEvents(resp, eventType) {
+			if result, ok := attrs["side-tx-result"]; ok && !strings.HasSuffix(result, "YES") {
+				continue
+			}
+
+			id, err := strconv.ParseUint(attrs[idKey], 10, 64)
+			if err != nil {
+				continue
+			}
+
+			ids = append(ids, id)
 		}
+
+		return ids
 	}
 }
 
-// Unsubscribe signals the reader goroutine to stop.
+// Unsubscribe signals the reader goroutines to stop.
 func (c *HeimdallWSClient) Unsubscribe(ctx context.Context) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -183,9 +274,20 @@ func (c *HeimdallWSClient) Unsubscribe(ctx context.Context) error {
 	return nil
 }
 
-// Close cleanly terminates the websocket connection.
+// Close cleanly terminates the websocket connections.
 func (c *HeimdallWSClient) Close() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	return c.conn.Close()
+
+	var firstErr error
+
+	for query, conn := range c.conns {
+		if err := conn.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+
+		delete(c.conns, query)
+	}
+
+	return firstErr
 }
@@ -38,15 +38,3 @@ func convertTo32(input []byte) (output [32]byte) {
 
 	return
 }
-
-func convert(input [][32]byte) [][]byte {
-	output := make([][]byte, 0, len(input))
-
-	for _, in := range input {
-		newInput := make([]byte, len(in[:]))
-		copy(newInput, in[:])
-		output = append(output, newInput)
-	}
-
-	return output
-}
@@ -0,0 +1,277 @@
+package bor
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	inTurnBlocksMeter    = metrics.NewRegisteredMeter("bor/producer/inturn", nil)
+	outOfTurnBlocksMeter = metrics.NewRegisteredMeter("bor/producer/outofturn", nil)
+	producerDelayTimer   = metrics.NewRegisteredTimer("bor/producer/delay", nil)
+	extraDelayTimer      = metrics.NewRegisteredTimer("bor/producer/extradelay", nil)
+)
+
+// maxProducerRecordsPerHead is the maximum number of blocks whose producer records are
+// persisted for a single new head. Older blocks are computed on demand when queried.
+const maxProducerRecordsPerHead = 1024
+
+// producerRecord is the performance of the producer of a single block. It's computed
+// as blocks become canonical and persisted to the database.
+type producerRecord struct {
+	Signer        common.Address // Producer which signed the block
+	Proposer      common.Address // In-turn producer of the block
+	Succession    uint64         // Succession number of the signer
+	Delay         uint64         // Seconds elapsed since the parent block
+	ExpectedDelay uint64         // Minimum delay for the succession number as per CalcProducerDelay
+}
+
+func newProducerRecord(header *types.Header, parent *types.Header, snap *Snapshot, signer common.Address, succession int, c *Bor) *producerRecord {
+	number := header.Number.Uint64()
+
+	record := &producerRecord{
+		Signer:        signer,
+		Proposer:      snap.ValidatorSet.GetProposer().Address,
+		Succession:    uint64(succession),
+		ExpectedDelay: CalcProducerDelay(number, succession, c.config),
+	}
+
+	if parent != nil && header.Time > parent.Time {
+		record.Delay = header.Time - parent.Time
+	}
+
+	return record
+}
+
+// RecordProducers persists the producer records of the blocks which became canonical with
+// the given head in a single batch, and drops the records of the blocks reorged out since
+// the previous head. It's meant to be called on every new chain head.
+func (c *Bor) RecordProducers(chain consensus.ChainHeaderReader, head *types.Header) {
+	if c.db == nil {
+		return
+	}
+
+	c.producerLock.Lock()
+	defer c.producerLock.Unlock()
+
+	batch := c.db.NewBatch()
+	number := head.Number.Uint64()
+
+	// Walk the previous head back to the canonical chain, dropping the reorged blocks
+	from := number
+	for last := c.producerHead; last != nil; {
+		n := last.Number.Uint64()
+
+		if canonical := chain.GetHeaderByNumber(n); canonical != nil && canonical.Hash() == last.Hash() {
+			from = n + 1
+			break
+		}
+
+		rawdb.DeleteBorProducerRecord(batch, n, last.Hash())
+
+		if n == 0 {
+			break
+		}
+
+		last = chain.GetHeader(last.ParentHash, n-1)
+	}
+
+	if from == 0 {
+		from = 1 // The genesis block has no producer
+	}
+
+	if from+maxProducerRecordsPerHead <= number {
+		from = number - maxProducerRecordsPerHead + 1
+	}
+
+	// Collect the new canonical blocks from the head, along with the parent of the first
+	var headers []*types.Header
+	for header := head; header != nil && header.Number.Uint64()+1 >= from; {
+		headers = append(headers, header)
+
+		if header.Number.Uint64() == 0 {
+			break
+		}
+
+		header = chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	}
+
+	for i := len(headers) - 2; i >= 0; i-- {
+		header, parent := headers[i], headers[i+1]
+
+		if rawdb.HasBorProducerRecord(c.db, header.Number.Uint64(), header.Hash()) {
+			continue
+		}
+
+		record, err := c.computeProducerRecord(chain, header, parent)
+		if err != nil {
+			log.Debug("Unable to compute producer record", "number", header.Number, "hash", header.Hash(), "err", err)
+			continue
+		}
+
+		data, err := rlp.EncodeToBytes(record)
+		if err != nil {
+			log.Warn("Unable to encode producer record", "number", header.Number, "hash", header.Hash(), "err", err)
+			continue
+		}
+
+		rawdb.WriteBorProducerRecord(batch, header.Number.Uint64(), header.Hash(), data)
+		updateProducerMetrics(record)
+	}
+
+	if err := batch.Write(); err != nil {
+		log.Warn("Unable to store producer records", "head", number, "err", err)
+		return
+	}
+
+	c.producerHead = head
+}
+
+// updateProducerMetrics updates the producer metrics with a newly recorded block
+func updateProducerMetrics(record *producerRecord) {
+	if !metrics.Enabled() {
+		return
+	}
+
+	signer := record.Signer.Hex()
+
+	if record.Succession == 0 {
+		inTurnBlocksMeter.Mark(1)
+		metrics.GetOrRegisterMeter("bor/producer/"+signer+"/inturn", nil).Mark(1)
+	} else {
+		outOfTurnBlocksMeter.Mark(1)
+		metrics.GetOrRegisterMeter("bor/producer/"+signer+"/outofturn", nil).Mark(1)
+		metrics.GetOrRegisterMeter("bor/producer/"+record.Proposer.Hex()+"/missed", nil).Mark(1)
+	}
+
+	producerDelayTimer.Update(time.Duration(record.Delay) * time.Second)
+
+	if record.Delay > record.ExpectedDelay {
+		extraDelayTimer.Update(time.Duration(record.Delay-record.ExpectedDelay) * time.Second)
+	} else {
+		extraDelayTimer.Update(0)
+	}
+}
+
+// computeProducerRecord computes the producer record of the given block from the snapshot
+// of its parent.
+func (c *Bor) computeProducerRecord(chain consensus.ChainHeaderReader, header *types.Header, parent *types.Header) (*producerRecord, error) {
+	snap, err := c.snapshot(chain, header.Number.Uint64()-1, header.ParentHash, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := ecrecover(header, c.signatures, c.config)
+	if err != nil {
+		return nil, err
+	}
+
+	succession, err := snap.GetSignerSuccessionNumber(signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return newProducerRecord(header, parent, snap, signer, succession, c), nil
+}
+
+// producerRecordOf returns the producer record of the given block. Blocks which weren't
+// recorded yet (e.g. blocks older than the recording) are computed on demand.
+func (c *Bor) producerRecordOf(chain consensus.ChainHeaderReader, header *types.Header, parent *types.Header) (*producerRecord, error) {
+	number, hash := header.Number.Uint64(), header.Hash()
+
+	if c.db != nil {
+		if data := rawdb.ReadBorProducerRecord(c.db, number, hash); data != nil {
+			record := new(producerRecord)
+			if err := rlp.DecodeBytes(data, record); err == nil {
+				return record, nil
+			}
+
+			log.Warn("Invalid producer record found in database", "number", number, "hash", hash)
+		}
+	}
+
+	return c.computeProducerRecord(chain, header, parent)
+}
+
+// ProducerStats is the performance of a single producer over a block range
+type ProducerStats struct {
+	Address              common.Address `json:"address"`
+	ExpectedSlots        uint64         `json:"expectedSlots"`   // Blocks for which it was the in-turn producer
+	SignedBlocks         uint64         `json:"signedBlocks"`    // Blocks signed in or out of turn
+	InTurnBlocks         uint64         `json:"inTurnBlocks"`    // Blocks signed in turn
+	OutOfTurnBlocks      uint64         `json:"outOfTurnBlocks"` // Blocks signed out of turn
+	MissedSlots          uint64         `json:"missedSlots"`     // Expected slots signed by another producer
+	AverageDelay         float64        `json:"averageDelay"`    // Average seconds since the parent block
+	AverageExpectedDelay float64        `json:"averageExpectedDelay"`
+}
+
+// producerStatsCollector aggregates producer records into per producer stats
+type producerStatsCollector struct {
+	stats         map[common.Address]*ProducerStats
+	delay         map[common.Address]uint64
+	expectedDelay map[common.Address]uint64
+}
+
+func newProducerStatsCollector() *producerStatsCollector {
+	return &producerStatsCollector{
+		stats:         make(map[common.Address]*ProducerStats),
+		delay:         make(map[common.Address]uint64),
+		expectedDelay: make(map[common.Address]uint64),
+	}
+}
+
+func (p *producerStatsCollector) producer(address common.Address) *ProducerStats {
+	stats, ok := p.stats[address]
+	if !ok {
+		stats = &ProducerStats{Address: address}
+		p.stats[address] = stats
+	}
+
+	return stats
+}
+
+func (p *producerStatsCollector) add(record *producerRecord) {
+	p.producer(record.Proposer).ExpectedSlots++
+
+	signer := p.producer(record.Signer)
+	signer.SignedBlocks++
+
+	if record.Succession == 0 {
+		signer.InTurnBlocks++
+	} else {
+		signer.OutOfTurnBlocks++
+		p.producer(record.Proposer).MissedSlots++
+	}
+
+	p.delay[record.Signer] += record.Delay
+	p.expectedDelay[record.Signer] += record.ExpectedDelay
+}
+
+// result returns the stats of every producer ordered by address
+func (p *producerStatsCollector) result() []*ProducerStats {
+	result := make([]*ProducerStats, 0, len(p.stats))
+
+	for address, stats := range p.stats {
+		if stats.SignedBlocks > 0 {
+			stats.AverageDelay = float64(p.delay[address]) / float64(stats.SignedBlocks)
+			stats.AverageExpectedDelay = float64(p.expectedDelay[address]) / float64(stats.SignedBlocks)
+		}
+
+		result = append(result, stats)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i].Address.Bytes(), result[j].Address.Bytes()) < 0
+	})
+
+	return result
+}
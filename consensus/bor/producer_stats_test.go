@@ -0,0 +1,161 @@
+package bor
+
+import (
+	"math/big"
+	"testing"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestProducerStatsCollector(t *testing.T) {
+	t.Parallel()
+
+	var (
+		val1 = common.HexToAddress("0x1")
+		val2 = common.HexToAddress("0x2")
+		val3 = common.HexToAddress("0x3")
+	)
+
+	collector := newProducerStatsCollector()
+
+	// val1 fills its slots
+	collector.add(&producerRecord{Signer: val1, Proposer: val1, Delay: 2, ExpectedDelay: 2})
+	collector.add(&producerRecord{Signer: val1, Proposer: val1, Delay: 4, ExpectedDelay: 2})
+
+	// val2 misses its slots, which are filled by val3 and val1
+	collector.add(&producerRecord{Signer: val3, Proposer: val2, Succession: 1, Delay: 6, ExpectedDelay: 6})
+	collector.add(&producerRecord{Signer: val1, Proposer: val2, Succession: 2, Delay: 12, ExpectedDelay: 10})
+
+	stats := collector.result()
+	require.Len(t, stats, 3)
+
+	require.Equal(t, &ProducerStats{
+		Address:              val1,
+		ExpectedSlots:        2,
+		SignedBlocks:         3,
+		InTurnBlocks:         2,
+		OutOfTurnBlocks:      1,
+		AverageDelay:         6,
+		AverageExpectedDelay: 14.0 / 3,
+	}, stats[0])
+
+	require.Equal(t, &ProducerStats{
+		Address:       val2,
+		ExpectedSlots: 2,
+		MissedSlots:   2,
+	}, stats[1])
+
+	require.Equal(t, &ProducerStats{
+		Address:              val3,
+		SignedBlocks:         1,
+		OutOfTurnBlocks:      1,
+		AverageDelay:         6,
+		AverageExpectedDelay: 6,
+	}, stats[2])
+}
+
+func TestRecordProducers(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+	validator := valset.NewValidator(crypto.PubkeyToAddress(key.PublicKey), 1000)
+
+	config := &params.BorConfig{
+		Period:           map[string]uint64{"0": 2},
+		ProducerDelay:    map[string]uint64{"0": 6},
+		Sprint:           map[string]uint64{"0": 16},
+		BackupMultiplier: map[string]uint64{"0": 2},
+		JaipurBlock:      big.NewInt(0),
+	}
+
+	recents, _ := lru.NewARC(inmemorySnapshots)
+	signatures, _ := lru.NewARC(inmemorySignatures)
+
+	chain := &rootHashTestChain{db: rawdb.NewMemoryDatabase()}
+	c := &Bor{chainConfig: params.TestChainConfig, config: config, db: chain.db, recents: recents, signatures: signatures}
+	c.authorizedSigner.Store(&signer{})
+
+	// newHeader signs a child of the given header, caching the snapshot it's verified with
+	newHeader := func(parent *types.Header, seed uint64) *types.Header {
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).Add(parent.Number, common.Big1),
+			Time:       parent.Time + 2 + seed,
+			Extra:      make([]byte, types.ExtraVanityLength+types.ExtraSealLength),
+		}
+
+		sig, err := crypto.Sign(SealHash(header, config).Bytes(), key)
+		require.NoError(t, err)
+		copy(header.Extra[types.ExtraVanityLength:], sig)
+
+		rawdb.WriteHeader(chain.db, header)
+		recents.Add(parent.Hash(), newSnapshot(c.chainConfig, signatures, parent.Number.Uint64(), parent.Hash(), []*valset.Validator{validator.Copy()}))
+
+		return header
+	}
+
+	// setCanonical makes the given headers canonical, dropping the ones after them
+	setCanonical := func(headers ...*types.Header) {
+		for _, header := range headers {
+			number := header.Number.Uint64()
+
+			chain.headers = append(chain.headers[:number], header)
+			rawdb.WriteCanonicalHash(chain.db, header.Hash(), number)
+		}
+	}
+
+	recorded := func(header *types.Header) bool {
+		return rawdb.HasBorProducerRecord(chain.db, header.Number.Uint64(), header.Hash())
+	}
+
+	genesis := &types.Header{Number: big.NewInt(0), Time: 1_700_000_000}
+	rawdb.WriteHeader(chain.db, genesis)
+
+	b1 := newHeader(genesis, 0)
+	b2 := newHeader(b1, 0)
+	b3 := newHeader(b2, 0)
+	setCanonical(genesis, b1, b2, b3)
+
+	// The first head is recorded on its own, the blocks of the next heads in a batch
+	c.RecordProducers(chain, b1)
+	require.True(t, recorded(b1))
+
+	c.RecordProducers(chain, b3)
+	require.True(t, recorded(b2))
+	require.True(t, recorded(b3))
+
+	record, err := c.producerRecordOf(chain, b3, b2)
+	require.NoError(t, err)
+	require.Equal(t, &producerRecord{Signer: validator.Address, Proposer: validator.Address, Delay: 2, ExpectedDelay: 2}, record)
+
+	// The records of the blocks reorged out are dropped
+	fork3 := newHeader(b2, 1)
+	fork4 := newHeader(fork3, 0)
+	setCanonical(fork3, fork4)
+
+	c.RecordProducers(chain, fork4)
+	require.False(t, recorded(b3))
+	require.True(t, recorded(b2))
+	require.True(t, recorded(fork3))
+	require.True(t, recorded(fork4))
+
+	record, err = c.producerRecordOf(chain, fork3, b2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), record.Delay)
+
+	// As are the ones of the blocks rewound
+	setCanonical(b1, b2)
+
+	c.RecordProducers(chain, b2)
+	require.False(t, recorded(fork3))
+	require.False(t, recorded(fork4))
+	require.True(t, recorded(b2))
+}
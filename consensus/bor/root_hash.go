@@ -0,0 +1,370 @@
+package bor
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// minCachedSubtreeLevel is the level of the smallest subtrees whose roots are persisted.
+	// Smaller subtrees are cheap to recompute from the leaves.
+	minCachedSubtreeLevel = 4
+
+	// maxRootHashIndexPerHead is the maximum number of blocks indexed for a single new head.
+	// Older blocks are read from the headers when queried.
+	maxRootHashIndexPerHead = 1024
+)
+
+// maxCachedSubtreeLevel is the level of the largest subtrees whose roots are persisted,
+// i.e. the level of the root hash tree of the longest checkpoint.
+var maxCachedSubtreeLevel = rootHashLevel(MaxCheckpointLength)
+
+// zeroSubtreeRoots holds the roots of the subtrees made of zero leaves per level, which
+// pad the root hash tree of a block range to a power of two leaves.
+var zeroSubtreeRoots = func() [][32]byte {
+	roots := make([][32]byte, 64)
+
+	for level := 1; level < len(roots); level++ {
+		roots[level] = hashPair(roots[level-1], roots[level-1])
+	}
+
+	return roots
+}()
+
+// hashPair returns the root of a subtree given the roots of its two children
+func hashPair(left [32]byte, right [32]byte) (root [32]byte) {
+	copy(root[:], crypto.Keccak256(left[:], right[:]))
+	return root
+}
+
+// rootHashLeaf returns the leaf of the given block in the root hash tree
+func rootHashLeaf(header *types.Header) (leaf [32]byte) {
+	copy(leaf[:], crypto.Keccak256(appendBytes32(
+		header.Number.Bytes(),
+		new(big.Int).SetUint64(header.Time).Bytes(),
+		header.TxHash.Bytes(),
+		header.ReceiptHash.Bytes(),
+	)))
+
+	return leaf
+}
+
+// rootHashSubtree identifies the complete subtree of the given level over the blocks from
+// first to first+1<<level-1.
+type rootHashSubtree struct {
+	level uint8
+	first uint64
+}
+
+// rootHashStore computes the root hash of block ranges, i.e. the merkle root of the leaves of
+// the blocks padded with zero leaves to a power of two. The tree of a range is built from the
+// maximal complete subtrees the range decomposes into, aligned on its start, and the zero
+// subtrees padding them.
+//
+// As checkpoints may start at any block, the leaves of the canonical blocks and the roots of
+// the complete subtrees of every cached level ending at each of them are persisted as the
+// blocks become canonical, see index. A range is then answered from O(log n) persisted
+// subtrees, and a proof from O(log n) per level. Subtrees which aren't persisted (yet) are
+// recomputed from their children.
+type rootHashStore struct {
+	chain consensus.ChainHeaderReader
+	db    ethdb.Database // nil if leaves and subtrees aren't persisted
+
+	pending map[rootHashSubtree][32]byte // Leaves and subtrees indexed but not persisted yet
+}
+
+// leaf returns the leaf of the canonical block with the given number, reading it from the
+// database when it's persisted.
+func (s *rootHashStore) leaf(number uint64) ([32]byte, error) {
+	if leaf, ok := s.pending[rootHashSubtree{0, number}]; ok {
+		return leaf, nil
+	}
+
+	if s.db == nil {
+		header := s.chain.GetHeaderByNumber(number)
+		if header == nil {
+			return [32]byte{}, errUnknownBlock
+		}
+
+		return rootHashLeaf(header), nil
+	}
+
+	hash := rawdb.ReadCanonicalHash(s.db, number)
+	if hash == (common.Hash{}) {
+		return [32]byte{}, errUnknownBlock
+	}
+
+	if blockHash, leaf, ok := rawdb.ReadBorRootHashLeaf(s.db, number); ok && blockHash == hash {
+		return leaf, nil
+	}
+
+	// Handle no header case, which is possible if ancient pruning was done
+	header := s.chain.GetHeader(hash, number)
+	if header == nil {
+		return [32]byte{}, errUnknownBlock
+	}
+
+	return rootHashLeaf(header), nil
+}
+
+// cached returns the root of the complete subtree of the given level starting at the given
+// block if it's indexed for the canonical chain.
+func (s *rootHashStore) cached(first uint64, level uint8) ([32]byte, bool) {
+	if root, ok := s.pending[rootHashSubtree{level, first}]; ok {
+		return root, true
+	}
+
+	if s.db == nil || level < minCachedSubtreeLevel || level > maxCachedSubtreeLevel {
+		return [32]byte{}, false
+	}
+
+	hash, root, ok := rawdb.ReadBorRootHashSubtree(s.db, level, first)
+	if !ok || hash != rawdb.ReadCanonicalHash(s.db, first+uint64(1)<<level-1) {
+		return [32]byte{}, false
+	}
+
+	return root, true
+}
+
+// complete returns the root of the complete subtree of the given level starting at the given
+// block, reading it from the database when it's indexed and hashing its children otherwise.
+func (s *rootHashStore) complete(first uint64, level uint8) ([32]byte, error) {
+	if level == 0 {
+		return s.leaf(first)
+	}
+
+	if root, ok := s.cached(first, level); ok {
+		return root, nil
+	}
+
+	left, err := s.complete(first, level-1)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	right, err := s.complete(first+uint64(1)<<(level-1), level-1)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return hashPair(left, right), nil
+}
+
+// decompose returns the levels of the maximal complete subtrees a range of the given length
+// is made of, from the first to the last one. Each subtree starts right after the previous one.
+func decompose(length uint64) []uint8 {
+	levels := make([]uint8, 0, bits.OnesCount64(length))
+
+	for level := 63; level >= 0; level-- {
+		if length&(uint64(1)<<level) != 0 {
+			levels = append(levels, uint8(level))
+		}
+	}
+
+	return levels
+}
+
+// subtree returns the root of the subtree of the given level starting at the given block,
+// where the leaves of the blocks after end are zero.
+func (s *rootHashStore) subtree(first uint64, level uint8, end uint64) ([32]byte, error) {
+	if first > end {
+		return zeroSubtreeRoots[level], nil
+	}
+
+	if last := first + uint64(1)<<level - 1; last > end {
+		return s.padded(first, level, end)
+	}
+
+	return s.complete(first, level)
+}
+
+// padded returns the root of the subtree of the given level starting at the given block
+// which covers end, built from the maximal complete subtrees between first and end and the
+// zero subtrees padding them.
+func (s *rootHashStore) padded(first uint64, level uint8, end uint64) ([32]byte, error) {
+	var (
+		levels = decompose(end - first + 1)
+		roots  = make([][32]byte, len(levels))
+	)
+
+	for i, l := range levels {
+		root, err := s.complete(first, l)
+		if err != nil {
+			return [32]byte{}, err
+		}
+
+		roots[i] = root
+		first += uint64(1) << l
+	}
+
+	// Fold the subtrees from the last one, padding each partial node with zero subtrees
+	// up to the level of the complete subtree on its left.
+	root, current := roots[len(roots)-1], levels[len(levels)-1]
+
+	for i := len(roots) - 2; i >= 0; i-- {
+		for ; current < levels[i]; current++ {
+			root = hashPair(root, zeroSubtreeRoots[current])
+		}
+
+		root = hashPair(roots[i], root)
+		current++
+	}
+
+	for ; current < level; current++ {
+		root = hashPair(root, zeroSubtreeRoots[current])
+	}
+
+	return root, nil
+}
+
+// rootHashLevel returns the level of the root hash tree of a range of the given length
+func rootHashLevel(length uint64) uint8 {
+	return uint8(bits.TrailingZeros64(nextPowerOfTwo(length)))
+}
+
+// root returns the root hash of the blocks between start and end (both inclusive)
+func (s *rootHashStore) root(start uint64, end uint64) ([32]byte, error) {
+	return s.subtree(start, rootHashLevel(end-start+1), end)
+}
+
+// proof returns the leaf of the given block along with the roots of the sibling subtrees
+// on the path from the leaf up to the root hash of the blocks between start and end.
+func (s *rootHashStore) proof(start uint64, end uint64, number uint64) ([32]byte, [][32]byte, error) {
+	level := rootHashLevel(end - start + 1)
+	proof := make([][32]byte, level)
+	first := start
+
+	for l := level; l > 0; l-- {
+		half := uint64(1) << (l - 1)
+
+		var (
+			sibling [32]byte
+			err     error
+		)
+
+		if number < first+half {
+			sibling, err = s.subtree(first+half, l-1, end)
+		} else {
+			sibling, err = s.subtree(first, l-1, end)
+			first += half
+		}
+
+		if err != nil {
+			return [32]byte{}, nil, err
+		}
+
+		proof[l-1] = sibling
+	}
+
+	leaf, err := s.leaf(number)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+
+	return leaf, proof, nil
+}
+
+// index persists the leaves of the canonical blocks up to the given head along with the
+// roots of the complete subtrees of the cached levels ending at each of them. The blocks are
+// walked back from the head to the last one whose leaf is persisted for the same block, so a
+// reorg reindexes the blocks of the new chain, which overwrites the subtrees of the old one.
+// A subtree is only indexed if the one on its left is, hence the higher levels are filled as
+// the indexed chain grows.
+func (s *rootHashStore) index(head *types.Header) error {
+	var headers []*types.Header
+
+	for header := head; header != nil && len(headers) < maxRootHashIndexPerHead; {
+		number := header.Number.Uint64()
+
+		if hash, _, ok := rawdb.ReadBorRootHashLeaf(s.db, number); ok && hash == header.Hash() {
+			break
+		}
+
+		headers = append(headers, header)
+
+		if number == 0 {
+			break
+		}
+
+		header = s.chain.GetHeader(header.ParentHash, number-1)
+	}
+
+	if len(headers) == 0 {
+		return nil
+	}
+
+	s.pending = make(map[rootHashSubtree][32]byte)
+	defer func() { s.pending = nil }()
+
+	batch := s.db.NewBatch()
+
+	for i := len(headers) - 1; i >= 0; i-- {
+		var (
+			header = headers[i]
+			number = header.Number.Uint64()
+			hash   = header.Hash()
+			node   = rootHashLeaf(header)
+		)
+
+		rawdb.WriteBorRootHashLeaf(batch, number, hash, node)
+		s.pending[rootHashSubtree{0, number}] = node
+
+		for level := uint8(1); level <= maxCachedSubtreeLevel && uint64(1)<<level <= number+1; level++ {
+			first := number + 1 - uint64(1)<<level
+
+			var (
+				left [32]byte
+				ok   bool
+			)
+
+			if level-1 < minCachedSubtreeLevel {
+				left, ok = s.recompute(first, level-1)
+			} else {
+				left, ok = s.cached(first, level-1)
+			}
+
+			if !ok {
+				break
+			}
+
+			node = hashPair(left, node)
+			s.pending[rootHashSubtree{level, first}] = node
+
+			if level >= minCachedSubtreeLevel {
+				rawdb.WriteBorRootHashSubtree(batch, level, first, hash, node)
+			}
+		}
+	}
+
+	return batch.Write()
+}
+
+// recompute returns the root of the complete subtree of a level which isn't persisted from
+// its leaves, if they're all available.
+func (s *rootHashStore) recompute(first uint64, level uint8) ([32]byte, bool) {
+	root, err := s.complete(first, level)
+	return root, err == nil
+}
+
+// IndexRootHashes persists the root hash leaves and subtrees of the blocks which became
+// canonical with the given head, so the root hashes of the checkpoints including them are
+// answered from a few persisted subtrees. It's meant to be called on every new chain head.
+func (c *Bor) IndexRootHashes(chain consensus.ChainHeaderReader, head *types.Header) {
+	if c.db == nil {
+		return
+	}
+
+	store := &rootHashStore{chain: chain, db: c.db}
+
+	if err := store.index(head); err != nil {
+		log.Warn("Unable to index root hashes", "head", head.Number, "err", err)
+	}
+}
@@ -0,0 +1,237 @@
+package bor
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xsleonard/go-merkle"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// rootHashTestChain is a canonical chain of headers backed by a database
+type rootHashTestChain struct {
+	db      ethdb.Database
+	headers []*types.Header
+}
+
+func newRootHashTestChain(length int, seed int64) *rootHashTestChain {
+	chain := &rootHashTestChain{db: rawdb.NewMemoryDatabase()}
+	chain.reorg(0, length, seed)
+
+	return chain
+}
+
+// reorg replaces the canonical blocks from the given one on with a chain of the given length
+func (c *rootHashTestChain) reorg(from int, length int, seed int64) {
+	c.headers = c.headers[:from]
+
+	for i := from; i < length; i++ {
+		header := &types.Header{
+			Number:      big.NewInt(int64(i)),
+			Time:        uint64(1_700_000_000 + 2*i),
+			TxHash:      common.BigToHash(big.NewInt(seed*1_000_000 + int64(i))),
+			ReceiptHash: common.BigToHash(big.NewInt(int64(i))),
+		}
+
+		if i > 0 {
+			header.ParentHash = c.headers[i-1].Hash()
+		}
+
+		c.headers = append(c.headers, header)
+		rawdb.WriteHeader(c.db, header)
+		rawdb.WriteCanonicalHash(c.db, header.Hash(), uint64(i))
+	}
+}
+
+func (c *rootHashTestChain) Config() *params.ChainConfig { return params.TestChainConfig }
+func (c *rootHashTestChain) CurrentHeader() *types.Header {
+	return c.headers[len(c.headers)-1]
+}
+func (c *rootHashTestChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	return rawdb.ReadHeader(c.db, hash, number)
+}
+func (c *rootHashTestChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number >= uint64(len(c.headers)) {
+		return nil
+	}
+
+	return c.headers[number]
+}
+func (c *rootHashTestChain) GetHeaderByHash(hash common.Hash) *types.Header { return nil }
+func (c *rootHashTestChain) GetTd(hash common.Hash, number uint64) *big.Int { return nil }
+
+// expectedRootHash computes the root hash of the range by hashing every header
+func expectedRootHash(t *testing.T, chain *rootHashTestChain, start, end uint64) [32]byte {
+	t.Helper()
+
+	leaves := make([][]byte, nextPowerOfTwo(end-start+1))
+	for i := range leaves {
+		leaves[i] = make([]byte, 32)
+	}
+
+	for number := start; number <= end; number++ {
+		leaf := rootHashLeaf(chain.headers[number])
+		copy(leaves[number-start], leaf[:])
+	}
+
+	tree := merkle.NewTreeWithOpts(merkle.TreeOptions{EnableHashSorting: false, DisableHashLeaves: true})
+	require.NoError(t, tree.Generate(leaves, sha3.NewLegacyKeccak256()))
+
+	var root [32]byte
+
+	copy(root[:], tree.Root().Hash)
+
+	return root
+}
+
+func TestRootHashStore(t *testing.T) {
+	t.Parallel()
+
+	chain := newRootHashTestChain(600, 1)
+
+	ranges := [][2]uint64{{1, 1}, {1, 2}, {5, 100}, {5, 300}, {17, 273}, {0, 511}, {100, 599}, {5, 21}}
+
+	check := func(store *rootHashStore, when string) {
+		t.Helper()
+
+		for _, r := range ranges {
+			root, err := store.root(r[0], r[1])
+			require.NoError(t, err)
+			require.Equal(t, expectedRootHash(t, chain, r[0], r[1]), root, "range %d-%d %s", r[0], r[1], when)
+		}
+	}
+
+	check(&rootHashStore{chain: chain}, "without database")
+	check(&rootHashStore{chain: chain, db: chain.db}, "before indexing")
+
+	store := &rootHashStore{chain: chain, db: chain.db}
+	require.NoError(t, store.index(chain.CurrentHeader()))
+	check(store, "after indexing")
+
+	// Indexed leaves and subtrees of blocks which aren't canonical anymore are ignored
+	chain.reorg(300, 600, 2)
+
+	check(store, "after reorg")
+
+	// Indexing the new head overwrites them
+	require.NoError(t, store.index(chain.CurrentHeader()))
+	check(store, "after reindexing")
+
+	hash, _, ok := rawdb.ReadBorRootHashSubtree(chain.db, 5, 300)
+	require.True(t, ok)
+	require.Equal(t, chain.headers[331].Hash(), hash)
+}
+
+func TestRootHashStoreProof(t *testing.T) {
+	t.Parallel()
+
+	chain := newRootHashTestChain(300, 1)
+	indexed := &rootHashStore{chain: chain, db: chain.db}
+
+	require.NoError(t, indexed.index(chain.CurrentHeader()))
+
+	start, end := uint64(7), uint64(250)
+
+	for _, store := range []*rootHashStore{{chain: chain}, indexed} {
+		root, err := store.root(start, end)
+		require.NoError(t, err)
+		require.Equal(t, expectedRootHash(t, chain, start, end), root)
+
+		for _, number := range []uint64{start, 8, 100, 128, end} {
+			leaf, proof, err := store.proof(start, end, number)
+			require.NoError(t, err)
+			require.Len(t, proof, int(rootHashLevel(end-start+1)))
+			require.Equal(t, rootHashLeaf(chain.headers[number]), leaf)
+
+			// Recompute the root from the leaf and its siblings
+			node, index := leaf, number-start
+			for _, sibling := range proof {
+				if index%2 == 0 {
+					node = hashPair(node, sibling)
+				} else {
+					node = hashPair(sibling, node)
+				}
+
+				index /= 2
+			}
+
+			require.Equal(t, root, node, "invalid proof for block %d", number)
+		}
+	}
+}
+
+func TestRootHashStoreIndex(t *testing.T) {
+	t.Parallel()
+
+	chain := newRootHashTestChain(600, 1)
+	store := &rootHashStore{chain: chain, db: chain.db}
+
+	// Index the chain over a few heads
+	for _, head := range []uint64{0, 100, 101, 599} {
+		require.NoError(t, store.index(chain.headers[head]))
+	}
+
+	for _, number := range []uint64{0, 5, 128, 599} {
+		hash, leaf, ok := rawdb.ReadBorRootHashLeaf(chain.db, number)
+		require.True(t, ok, "leaf %d", number)
+		require.Equal(t, chain.headers[number].Hash(), hash)
+		require.Equal(t, rootHashLeaf(chain.headers[number]), [32]byte(leaf))
+	}
+
+	// Subtrees of every cached level are indexed at every block they end at
+	for _, subtree := range []rootHashSubtree{{4, 0}, {4, 5}, {4, 90}, {4, 584}, {6, 37}, {9, 0}, {9, 88}} {
+		last := subtree.first + uint64(1)<<subtree.level - 1
+
+		hash, root, ok := rawdb.ReadBorRootHashSubtree(chain.db, subtree.level, subtree.first)
+		require.True(t, ok, "subtree %d at %d", subtree.level, subtree.first)
+		require.Equal(t, chain.headers[last].Hash(), hash)
+		require.Equal(t, expectedRootHash(t, chain, subtree.first, last), [32]byte(root))
+	}
+
+	_, _, ok := rawdb.ReadBorRootHashSubtree(chain.db, 3, 0)
+	require.False(t, ok)
+
+	// Ranges made of cached subtrees don't read their leaves nor the headers
+	for number := uint64(4); number <= 560; number++ {
+		rawdb.DeleteHeader(chain.db, chain.headers[number].Hash(), number)
+	}
+
+	db := &leafCountingDB{Database: chain.db}
+	store = &rootHashStore{chain: chain, db: db}
+
+	for _, r := range [][2]uint64{{5, 260}, {16, 303}, {17, 544}, {100, 515}} {
+		root, err := store.root(r[0], r[1])
+		require.NoError(t, err)
+		require.Equal(t, expectedRootHash(t, chain, r[0], r[1]), root, "range %d-%d", r[0], r[1])
+	}
+
+	require.Zero(t, db.leaves)
+
+	// Other ranges read the leaves of their smaller subtrees only
+	root, err := store.root(5, 300)
+	require.NoError(t, err)
+	require.Equal(t, expectedRootHash(t, chain, 5, 300), root)
+	require.Equal(t, 8, db.leaves)
+}
+
+// leafCountingDB counts the root hash leaves read from the database
+type leafCountingDB struct {
+	ethdb.Database
+	leaves int
+}
+
+func (db *leafCountingDB) Get(key []byte) ([]byte, error) {
+	if bytes.HasPrefix(key, []byte("matic-bor-roothash-leaf-")) {
+		db.leaves++
+	}
+
+	return db.Database.Get(key)
+}
@@ -0,0 +1,284 @@
+package bor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor/contract"
+	borSpan "github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/statefull"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	borTypes "github.com/0xPolygon/heimdall-v2/x/bor/types"
+	stakeTypes "github.com/0xPolygon/heimdall-v2/x/stake/types"
+)
+
+const (
+	// defaultSimulatedSprints is the number of sprints of the span simulated if not specified
+	defaultSimulatedSprints = 16
+
+	// maxSimulatedSprints is the maximum number of sprints of the span which can be simulated
+	maxSimulatedSprints = 1024
+)
+
+// errSimulationUnavailable is returned when a span is simulated on a node which can't
+// execute the validator set contract, e.g. a node without state.
+var errSimulationUnavailable = errors.New("span simulation unavailable")
+
+// stateReader is implemented by chains which give access to the state, e.g. core.BlockChain
+type stateReader interface {
+	StateAt(root common.Hash) (*state.StateDB, error)
+}
+
+// SimulatedProducer is a producer of a sprint along with its position in the producer order
+type SimulatedProducer struct {
+	Address          common.Address `json:"address"`
+	ID               uint64         `json:"id"`
+	VotingPower      int64          `json:"votingPower"`
+	ProposerPriority int64          `json:"proposerPriority"`
+	Succession       int            `json:"succession"` // 0 for the in-turn producer
+	Difficulty       uint64         `json:"difficulty"`
+}
+
+// SimulatedSprint is the producer order of a sprint of the simulated span
+type SimulatedSprint struct {
+	StartBlock uint64               `json:"startBlock"`
+	EndBlock   uint64               `json:"endBlock"`
+	Proposer   common.Address       `json:"proposer"`
+	Producers  []*SimulatedProducer `json:"producers"` // Ordered by succession number
+}
+
+// SpanSimulation is the outcome of committing a candidate span on top of the current state
+type SpanSimulation struct {
+	ID         uint64             `json:"id"`
+	StartBlock uint64             `json:"startBlock"`
+	EndBlock   uint64             `json:"endBlock"`
+	Head       uint64             `json:"head"` // Block the span was committed on top of
+	Sprints    []*SimulatedSprint `json:"sprints"`
+}
+
+func newSimulatedSprint(validatorSet *valset.ValidatorSet, startBlock uint64, endBlock uint64) *SimulatedSprint {
+	proposer := validatorSet.GetProposer().Address
+	proposerIndex, _ := validatorSet.GetByAddress(proposer)
+
+	sprint := &SimulatedSprint{
+		StartBlock: startBlock,
+		EndBlock:   endBlock,
+		Proposer:   proposer,
+		Producers:  make([]*SimulatedProducer, 0, len(validatorSet.Validators)),
+	}
+
+	for succession := range validatorSet.Validators {
+		validator := validatorSet.Validators[(proposerIndex+succession)%len(validatorSet.Validators)]
+
+		sprint.Producers = append(sprint.Producers, &SimulatedProducer{
+			Address:          validator.Address,
+			ID:               validator.ID,
+			VotingPower:      validator.VotingPower,
+			ProposerPriority: validator.ProposerPriority,
+			Succession:       succession,
+			Difficulty:       Difficulty(validatorSet, validator.Address),
+		})
+	}
+
+	return sprint
+}
+
+// SimulateSpan commits the given span on top of a copy of the state of the current block
+// and returns the producer order of the first sprints of the span, without altering the
+// chain. The span is expected in the JSON format served by heimdall. The validator set is
+// rotated at every sprint end up to the span as done by the snapshots, with the producers
+// returned by the validator set contract once the span is committed.
+func (api *API) SimulateSpan(ctx context.Context, spanJSON json.RawMessage, sprints *uint64) (*SpanSimulation, error) {
+	count := uint64(defaultSimulatedSprints)
+	if sprints != nil {
+		count = *sprints
+	}
+
+	if count == 0 || count > maxSimulatedSprints {
+		return nil, fmt.Errorf("invalid number of sprints %d, expected between 1 and %d", count, maxSimulatedSprints)
+	}
+
+	span, err := borSpan.UnmarshalSpanJSON(spanJSON)
+	if err != nil {
+		return nil, fmt.Errorf("invalid span: %w", err)
+	}
+
+	if span.BorChainId != api.bor.chainConfig.ChainID.String() {
+		return nil, fmt.Errorf("chain id of the span, %s, and bor chain id, %s, don't match", span.BorChainId, api.bor.chainConfig.ChainID)
+	}
+
+	head := api.chain.CurrentHeader()
+	number := head.Number.Uint64()
+
+	// The validator set of the next block is already known, so the span can't affect it
+	if span.StartBlock <= number+1 || span.EndBlock < span.StartBlock {
+		return nil, &valset.InvalidStartEndBlockError{Start: span.StartBlock, End: span.EndBlock, CurrentHeader: number}
+	}
+
+	chain, ok := api.chain.(stateReader)
+	if !ok || api.bor.spanner == nil || api.bor.ethAPI == nil {
+		return nil, errSimulationUnavailable
+	}
+
+	statedb, err := chain.StateAt(head.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	statedb = statedb.Copy()
+
+	var validators, producers []stakeTypes.MinimalVal
+
+	for _, val := range span.ValidatorSet.Validators {
+		validators = append(validators, val.MinimalVal())
+	}
+
+	for _, val := range span.SelectedProducers {
+		producers = append(producers, val.MinimalVal())
+	}
+
+	header := types.CopyHeader(head)
+	header.ParentHash = head.Hash()
+	header.Number = new(big.Int).SetUint64(number + 1)
+
+	minSpan := borTypes.Span{
+		Id:         span.Id,
+		StartBlock: span.StartBlock,
+		EndBlock:   span.EndBlock,
+	}
+
+	if err := api.bor.spanner.CommitSpan(ctx, minSpan, validators, producers, statedb, header, statefull.ChainContext{Chain: api.chain, Bor: api.bor}); err != nil {
+		return nil, fmt.Errorf("failed to commit span: %w", err)
+	}
+
+	// Producers returned by the contract for the sprints before the span and during the span
+	currentProducers, err := api.bor.validatorsWithState(ctx, statedb, head, number+1)
+	if err != nil {
+		return nil, err
+	}
+
+	spanProducers, err := api.bor.validatorsWithState(ctx, statedb, head, span.StartBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	snap, err := api.bor.snapshot(api.chain, number, head.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	spanValidators := borSpan.ConvertHeimdallValSetToBorValSet(span.ValidatorSet).Validators
+	validatorSet := snap.ValidatorSet.Copy()
+
+	simulation := &SpanSimulation{
+		ID:         span.Id,
+		StartBlock: span.StartBlock,
+		EndBlock:   span.EndBlock,
+		Head:       number,
+		Sprints:    make([]*SimulatedSprint, 0, count),
+	}
+
+	// The snapshot of the current block holds the validator set of the next block
+	for block := number + 2; block <= span.EndBlock && uint64(len(simulation.Sprints)) < count; block++ {
+		if !api.bor.config.IsSprintStart(block) {
+			continue
+		}
+
+		newVals := currentProducers
+		if block >= span.StartBlock {
+			newVals = spanProducers
+		}
+
+		validatorSet = getUpdatedValidatorSet(validatorSet.Copy(), copyValidators(newVals))
+		validatorSet.IncrementProposerPriority(1)
+
+		if validatorSet.CheckEmptyId() {
+			validatorSet.IncludeIds(spanValidators)
+		}
+
+		if block < span.StartBlock {
+			continue
+		}
+
+		end := block + api.bor.config.CalculateSprint(block) - 1
+		if end > span.EndBlock {
+			end = span.EndBlock
+		}
+
+		simulation.Sprints = append(simulation.Sprints, newSimulatedSprint(validatorSet, block, end))
+	}
+
+	return simulation, nil
+}
+
+// validatorsWithState returns the producers of the given block as per the validator set
+// contract, executed against the given state on top of the given header.
+func (c *Bor) validatorsWithState(ctx context.Context, statedb *state.StateDB, header *types.Header, blockNumber uint64) ([]*valset.Validator, error) {
+	const method = "getBorValidators"
+
+	validatorSet := contract.ValidatorSet()
+
+	data, err := validatorSet.Pack(method, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	msgData := (hexutil.Bytes)(data)
+	toAddress := common.HexToAddress(c.config.ValidatorContract)
+	gas := (hexutil.Uint64)(uint64(math.MaxUint64 / 2))
+	hash := header.Hash()
+
+	result, err := c.ethAPI.CallWithState(ctx, ethapi.TransactionArgs{
+		Gas:  &gas,
+		To:   &toAddress,
+		Data: &msgData,
+	}, &rpc.BlockNumberOrHash{BlockHash: &hash}, statedb.Copy(), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ret0 = new([]common.Address)
+		ret1 = new([]*big.Int)
+	)
+
+	out := &[]interface{}{
+		ret0,
+		ret1,
+	}
+
+	if err := validatorSet.UnpackIntoInterface(out, method, result); err != nil {
+		return nil, err
+	}
+
+	validators := make([]*valset.Validator, len(*ret0))
+	for i, a := range *ret0 {
+		validators[i] = &valset.Validator{
+			Address:     a,
+			VotingPower: (*ret1)[i].Int64(),
+		}
+	}
+
+	return validators, nil
+}
+
+// copyValidators returns a deep copy of the validators, which are altered when applied
+func copyValidators(validators []*valset.Validator) []*valset.Validator {
+	copied := make([]*valset.Validator, len(validators))
+	for i, validator := range validators {
+		copied[i] = validator.Copy()
+	}
+
+	return copied
+}
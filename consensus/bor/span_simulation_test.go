@@ -0,0 +1,44 @@
+package bor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+)
+
+func TestSimulatedSprintOrder(t *testing.T) {
+	t.Parallel()
+
+	validators := []*valset.Validator{
+		{ID: 1, Address: common.HexToAddress("0x1"), VotingPower: 10},
+		{ID: 2, Address: common.HexToAddress("0x2"), VotingPower: 10},
+		{ID: 3, Address: common.HexToAddress("0x3"), VotingPower: 10},
+	}
+
+	validatorSet := valset.NewValidatorSet(copyValidators(validators))
+
+	// Rotating with the same validators moves the proposer along
+	proposers := make(map[common.Address]bool)
+
+	for i := 0; i < len(validators); i++ {
+		validatorSet = getUpdatedValidatorSet(validatorSet.Copy(), copyValidators(validators))
+		validatorSet.IncrementProposerPriority(1)
+
+		sprint := newSimulatedSprint(validatorSet, 16*uint64(i+1), 16*uint64(i+2)-1)
+
+		require.Len(t, sprint.Producers, len(validators))
+		require.Equal(t, sprint.Proposer, sprint.Producers[0].Address)
+
+		for succession, producer := range sprint.Producers {
+			require.Equal(t, succession, producer.Succession)
+			require.Equal(t, uint64(len(validators)-succession), producer.Difficulty)
+		}
+
+		proposers[sprint.Proposer] = true
+	}
+
+	require.Len(t, proposers, len(validators))
+}
@@ -2,10 +2,14 @@ package bor
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
 	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -19,30 +23,47 @@ import (
 // hence we set a very high limit. It can be reduced later.
 const maxSpanFetchLimit = 10_000
 
+// maxSpanPrefetchLimit denotes maximum number of spans fetched ahead of time when heimdall
+// announces a new span. Older spans are fetched on demand.
+const maxSpanPrefetchLimit = 10
+
 // SpanStore acts as a simple middleware to cache span data populated from heimdall. It is used
-// in multiple places of bor consensus for verification.
+// in multiple places of bor consensus for verification. Spans are also persisted to the database
+// (if available) so that they needn't be fetched from heimdall again after a restart.
 type SpanStore struct {
 	store *lru.ARCCache
 
 	heimdallClient IHeimdallClient
 	spanner        Spanner
 
-	latestKnownSpanId uint64
+	latestKnownSpanId atomic.Uint64 // Accessed by the heimdall ws subscription as well
 	chainId           string
 
-	db ethdb.Database
+	db        ethdb.Database
+	writeLock sync.Mutex // Serialises the updates of the last span id stored in the database
 }
 
-func NewSpanStore(heimdallClient IHeimdallClient, spanner Spanner, chainId string, db ethdb.Database) SpanStore {
+func NewSpanStore(heimdallClient IHeimdallClient, spanner Spanner, chainId string, db ethdb.Database) *SpanStore {
 	cache, _ := lru.NewARC(10)
-	return SpanStore{
-		store:             cache,
-		heimdallClient:    heimdallClient,
-		spanner:           spanner,
-		latestKnownSpanId: 0,
-		chainId:           chainId,
-		db:                db,
+
+	// Restore the latest known span id from the database (if any)
+	var latestKnownSpanId uint64
+	if db != nil {
+		if id := rawdb.ReadLastBorSpanId(db); id != nil {
+			latestKnownSpanId = *id
+		}
+	}
+
+	store := &SpanStore{
+		store:          cache,
+		heimdallClient: heimdallClient,
+		spanner:        spanner,
+		chainId:        chainId,
+		db:             db,
 	}
+	store.latestKnownSpanId.Store(latestKnownSpanId)
+
+	return store
 }
 
 // spanById returns a span given its id. It fetches span from heimdall if not found in cache.
@@ -56,8 +77,18 @@ func (s *SpanStore) spanById(ctx context.Context, spanId uint64) (*borTypes.Span
 		return currentSpan, nil
 	}
 
+	// Look up the span in database before reaching out to heimdall
+	if currentSpan = s.readSpan(spanId); currentSpan != nil {
+		s.store.Add(spanId, currentSpan)
+		s.updateLatestKnownSpanId(currentSpan.Id)
+
+		return currentSpan, nil
+	}
+
 	var err error
-	if s.heimdallClient == nil {
+
+	client := s.heimdallClient
+	if client == nil {
 		if spanId == 0 {
 			currentSpan, err = getMockSpan0(ctx, s.spanner, s.chainId)
 			if err != nil {
@@ -68,7 +99,7 @@ func (s *SpanStore) spanById(ctx context.Context, spanId uint64) (*borTypes.Span
 			return nil, fmt.Errorf("unable to create test span without heimdall client for id %d", spanId)
 		}
 	} else {
-		currentSpan, err = s.heimdallClient.GetSpan(ctx, spanId)
+		currentSpan, err = client.GetSpan(ctx, spanId)
 		if err != nil {
 			log.Warn("Unable to fetch span from heimdall", "id", spanId, "err", err)
 			return nil, err
@@ -80,21 +111,143 @@ func (s *SpanStore) spanById(ctx context.Context, spanId uint64) (*borTypes.Span
 	}
 
 	s.store.Add(spanId, currentSpan)
-	if currentSpan.Id > s.latestKnownSpanId {
-		s.latestKnownSpanId = currentSpan.Id
+	s.updateLatestKnownSpanId(currentSpan.Id)
+
+	// Only the spans committed on heimdall are persisted, never the mock span
+	if client != nil {
+		s.writeSpan(currentSpan)
 	}
 
 	return currentSpan, nil
 }
 
+// updateLatestKnownSpanId raises the latest known span id to the given one if it's higher
+func (s *SpanStore) updateLatestKnownSpanId(spanId uint64) {
+	for {
+		latest := s.latestKnownSpanId.Load()
+		if spanId <= latest || s.latestKnownSpanId.CompareAndSwap(latest, spanId) {
+			return
+		}
+	}
+}
+
+// knownSpanById returns a span given its id if it's present in cache or database. Unlike
+// spanById, it never reaches out to heimdall.
+func (s *SpanStore) knownSpanById(spanId uint64) *borTypes.Span {
+	if value, ok := s.store.Get(spanId); ok {
+		if currentSpan, _ := value.(*borTypes.Span); currentSpan != nil {
+			return currentSpan
+		}
+	}
+
+	return s.readSpan(spanId)
+}
+
+// knownSpanByBlockNumber returns the span containing the given block number if it's present
+// in cache or database. Spans are contiguous, hence the lookup starts at the estimated span
+// id and walks towards the block number.
+func (s *SpanStore) knownSpanByBlockNumber(blockNumber uint64) *borTypes.Span {
+	latestKnownSpanId := s.latestKnownSpanId.Load()
+
+	spanId := estimateSpanId(blockNumber)
+	if spanId > latestKnownSpanId {
+		spanId = latestKnownSpanId
+	}
+
+	// The direction of the walk is fixed by the first span looked up, so that
+	// the walk ends even if the stored spans aren't contiguous.
+	var walkDown, walkUp bool
+
+	for {
+		currentSpan := s.knownSpanById(spanId)
+		if currentSpan == nil {
+			return nil
+		}
+
+		switch {
+		case blockNumber < currentSpan.StartBlock:
+			if spanId == 0 || walkUp {
+				return nil
+			}
+			walkDown = true
+			spanId--
+		case blockNumber > currentSpan.EndBlock:
+			if spanId >= latestKnownSpanId || walkDown {
+				return nil
+			}
+			walkUp = true
+			spanId++
+		default:
+			return currentSpan
+		}
+	}
+}
+
+// readSpan returns the span stored in database against the given id. It returns
+// nil if the database is not available or if the span is not found.
+func (s *SpanStore) readSpan(spanId uint64) *borTypes.Span {
+	if s.db == nil {
+		return nil
+	}
+
+	data := rawdb.ReadBorSpan(s.db, spanId)
+	if data == nil {
+		return nil
+	}
+
+	var currentSpan borTypes.Span
+	if err := json.Unmarshal(data, &currentSpan); err != nil {
+		log.Warn("Unable to decode span from database", "id", spanId, "err", err)
+		return nil
+	}
+
+	// Ensure that the data stored is for the span requested
+	if currentSpan.Id != spanId {
+		log.Warn("Invalid span found in database", "id", spanId, "stored id", currentSpan.Id)
+		return nil
+	}
+
+	return &currentSpan
+}
+
+// writeSpan persists the span to database along with the latest known span id. Spans
+// are immutable once committed on heimdall and hence are safe to be stored regardless
+// of reorgs.
+func (s *SpanStore) writeSpan(currentSpan *borTypes.Span) {
+	if s.db == nil {
+		return
+	}
+
+	data, err := json.Marshal(currentSpan)
+	if err != nil {
+		log.Warn("Unable to encode span to store in database", "id", currentSpan.Id, "err", err)
+		return
+	}
+
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	batch := s.db.NewBatch()
+	rawdb.WriteBorSpan(batch, currentSpan.Id, data)
+
+	if lastId := rawdb.ReadLastBorSpanId(s.db); lastId == nil || currentSpan.Id > *lastId {
+		rawdb.WriteLastBorSpanId(batch, currentSpan.Id)
+	}
+
+	if err := batch.Write(); err != nil {
+		log.Warn("Unable to store span in database", "id", currentSpan.Id, "err", err)
+	}
+}
+
 // spanByBlockNumber returns a span given a block number. It fetches span from heimdall if not found in cache. It
 // assumes that a span has been committed before (i.e. is current or past span) and returns an error if
 // asked for a future span. This is safe to assume as we don't have a way to find out span id for a future block
 // unless we hardcode the span length (which we don't want to).
 func (s *SpanStore) spanByBlockNumber(ctx context.Context, blockNumber uint64) (*borTypes.Span, error) {
-	// As we don't persist latest known span to db, we loose the value on restarts. This leads to multiple heimdall calls
-	// which can be avoided. Hence we estimate the span id from block number which updates the latest known span id. Note
-	// that we still check if the block number lies in the range of span before returning it.
+	// The latest known span id is restored from db on restarts but it could still lag behind (e.g. on a fresh node or
+	// while syncing a long range of headers). This leads to multiple heimdall calls which can be avoided. Hence we
+	// estimate the span id from block number which updates the latest known span id. Note that we still check if the
+	// block number lies in the range of span before returning it.
 	estimatedSpanId := estimateSpanId(blockNumber)
 	// Ignore the return value of this span as we validate it later in the loop
 	_, err := s.spanById(ctx, estimatedSpanId)
@@ -105,7 +258,7 @@ func (s *SpanStore) spanByBlockNumber(ctx context.Context, blockNumber uint64) (
 	// https://github.com/0xPolygon/genesis-contracts/blob/master/contracts/BorValidatorSet.template#L118-L134
 	// This logic is independent of the span length (bit extra effort but maintains equivalence) and will work
 	// for all span lengths (even if we change it in future).
-	latestKnownSpanId := s.latestKnownSpanId
+	latestKnownSpanId := s.latestKnownSpanId.Load()
 	for id := int(latestKnownSpanId); id >= 0; id-- {
 		span, err := s.spanById(ctx, uint64(id))
 		if err != nil {
@@ -180,3 +333,25 @@ func getMockSpan0(ctx context.Context, spanner Spanner, chainId string) (*borTyp
 		BorChainId:        chainId,
 	}, nil
 }
+
+// ProcessSpanEvent fetches the spans announced by heimdall into the span store so that
+// they are available before they are needed. Spans after the latest known one are fetched
+// as well to recover the ones missed while the subscription was down.
+func (c *Bor) ProcessSpanEvent(ctx context.Context, id uint64) error {
+	from := c.spanStore.latestKnownSpanId.Load() + 1
+	if from > id {
+		from = id
+	}
+
+	if id-from >= maxSpanPrefetchLimit {
+		from = id - maxSpanPrefetchLimit + 1
+	}
+
+	for spanId := from; spanId <= id; spanId++ {
+		if _, err := c.spanStore.spanById(ctx, spanId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
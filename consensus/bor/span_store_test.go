@@ -2,13 +2,19 @@ package bor
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/0xPolygon/heimdall-v2/x/bor/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -46,10 +52,10 @@ func TestSpanStore_SpanById(t *testing.T) {
 	require.Len(t, keys, 3, "invalid length of keys in span store")
 
 	// Ensure latest known span id is updated
-	require.Equal(t, uint64(2), spanStore.latestKnownSpanId, "invalid latest known span id in span store")
+	require.Equal(t, uint64(2), spanStore.latestKnownSpanId.Load(), "invalid latest known span id in span store")
 
 	// Ask for a few more spans
-	for i := spanStore.latestKnownSpanId; i <= 20; i++ {
+	for i := spanStore.latestKnownSpanId.Load(); i <= 20; i++ {
 		_, err := spanStore.spanById(ctx, i)
 		require.NoError(t, err, "err in spanById for id=%d", i)
 	}
@@ -59,7 +65,7 @@ func TestSpanStore_SpanById(t *testing.T) {
 	require.Len(t, keys, 10, "invalid length of keys in span store")
 
 	// Ensure latest known span id is updated
-	require.Equal(t, uint64(20), spanStore.latestKnownSpanId, "invalid latest known span id in span store")
+	require.Equal(t, uint64(20), spanStore.latestKnownSpanId.Load(), "invalid latest known span id in span store")
 
 	// Ensure we're still able to fetch old spans even though they're evicted from cache
 	span, err := spanStore.spanById(ctx, 0)
@@ -69,7 +75,7 @@ func TestSpanStore_SpanById(t *testing.T) {
 	require.Equal(t, uint64(255), span.EndBlock, "invalid end block in spanById after eviction for id=0")
 
 	// Ensure latest known span is still the old one
-	require.Equal(t, uint64(20), spanStore.latestKnownSpanId, "invalid latest known span id in span store")
+	require.Equal(t, uint64(20), spanStore.latestKnownSpanId.Load(), "invalid latest known span id in span store")
 }
 
 func TestSpanStore_SpanByBlockNumber(t *testing.T) {
@@ -84,7 +90,7 @@ func TestSpanStore_SpanByBlockNumber(t *testing.T) {
 	}
 
 	// Insert a few spans
-	for i := spanStore.latestKnownSpanId; i < 3; i++ {
+	for i := spanStore.latestKnownSpanId.Load(); i < 3; i++ {
 		_, err := spanStore.spanById(ctx, i)
 		require.NoError(t, err, "err in spanById for id=%d", i)
 	}
@@ -94,7 +100,7 @@ func TestSpanStore_SpanByBlockNumber(t *testing.T) {
 	require.Len(t, keys, 3, "invalid length of keys in span store")
 
 	// Ensure latest known span id is updated
-	require.Equal(t, uint64(2), spanStore.latestKnownSpanId, "invalid latest known span id in span store")
+	require.Equal(t, uint64(2), spanStore.latestKnownSpanId.Load(), "invalid latest known span id in span store")
 
 	// Ask for current and past spans via block number
 	testcases := []Testcase{
@@ -121,7 +127,7 @@ func TestSpanStore_SpanByBlockNumber(t *testing.T) {
 	}
 
 	// Insert a few more spans to trigger eviction
-	for i := spanStore.latestKnownSpanId; i <= 20; i++ {
+	for i := spanStore.latestKnownSpanId.Load(); i <= 20; i++ {
 		_, err := spanStore.spanById(ctx, i)
 		require.NoError(t, err, "err in spanById for id=%d", i)
 	}
@@ -131,7 +137,7 @@ func TestSpanStore_SpanByBlockNumber(t *testing.T) {
 	require.Len(t, keys, 10, "invalid length of keys in span store")
 
 	// Ensure latest known span id is updated
-	require.Equal(t, uint64(20), spanStore.latestKnownSpanId, "invalid latest known span id in span store")
+	require.Equal(t, uint64(20), spanStore.latestKnownSpanId.Load(), "invalid latest known span id in span store")
 
 	// Ask for current and past spans
 	testcases = append(testcases, Testcase{blockNumber: 57856, id: 10, startBlock: 57856, endBlock: 64255})
@@ -159,6 +165,114 @@ func TestSpanStore_SpanByBlockNumber(t *testing.T) {
 	require.Equal(t, uint64(134655), span.EndBlock, "invalid end block in spanByBlockNumber for future block 128256")
 }
 
+func TestSpanStore_Persistence(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	spanStore := NewSpanStore(&MockHeimdallClient{}, nil, "1337", db)
+	ctx := t.Context()
+
+	// Fetch a few spans which should be written to db
+	for i := uint64(0); i <= 20; i++ {
+		_, err := spanStore.spanById(ctx, i)
+		require.NoError(t, err, "err in spanById for id=%d", i)
+		require.True(t, rawdb.HasBorSpan(db, i), "span not persisted for id=%d", i)
+	}
+
+	lastId := rawdb.ReadLastBorSpanId(db)
+	require.NotNil(t, lastId, "latest known span id not persisted")
+	require.Equal(t, uint64(20), *lastId, "invalid latest known span id in db")
+
+	// Simulate a restart without a heimdall client. All spans should be served from db.
+	restarted := NewSpanStore(nil, nil, "1337", db)
+	require.Equal(t, uint64(20), restarted.latestKnownSpanId.Load(), "latest known span id not restored from db")
+
+	for i := uint64(0); i <= 20; i++ {
+		span, err := restarted.spanById(ctx, i)
+		require.NoError(t, err, "err in spanById from db for id=%d", i)
+		require.Equal(t, i, span.Id, "invalid id in spanById from db for id=%d", i)
+	}
+
+	span, err := restarted.spanByBlockNumber(ctx, 60000)
+	require.NoError(t, err, "err in spanByBlockNumber from db")
+	require.Equal(t, uint64(10), span.Id, "invalid id in spanByBlockNumber from db")
+
+	// Spans not present in db still require heimdall
+	_, err = restarted.spanById(ctx, 21)
+	require.Error(t, err, "expected error for span not present in db")
+
+	// Spans read from db raise the latest known span id
+	data, err := json.Marshal(&types.Span{Id: 25, StartBlock: 153856, EndBlock: 160255})
+	require.NoError(t, err, "err in marshalling span")
+	rawdb.WriteBorSpan(db, 25, data)
+
+	_, err = restarted.spanById(ctx, 25)
+	require.NoError(t, err, "err in spanById from db for id=25")
+	require.Equal(t, uint64(25), restarted.latestKnownSpanId.Load(), "latest known span id not raised by span from db")
+}
+
+func TestSpanStore_MockSpanNotPersisted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	spanner := NewMockSpanner(ctrl)
+	spanner.EXPECT().GetCurrentValidatorsByBlockNrOrHash(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]*valset.Validator{{Address: common.Address{0x1}, VotingPower: 10}}, nil)
+
+	db := rawdb.NewMemoryDatabase()
+	spanStore := NewSpanStore(nil, spanner, "1337", db)
+
+	span, err := spanStore.spanById(t.Context(), 0)
+	require.NoError(t, err, "err in spanById for mock span")
+	require.Equal(t, uint64(0), span.Id, "invalid id for mock span")
+
+	// The mock span is only cached, as it isn't committed on heimdall
+	require.False(t, rawdb.HasBorSpan(db, 0), "mock span persisted")
+	require.Nil(t, rawdb.ReadLastBorSpanId(db), "mock span id persisted")
+}
+
+func TestSpanStore_KnownSpans(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	spanStore := NewSpanStore(&MockHeimdallClient{}, nil, "1337", db)
+	ctx := t.Context()
+
+	// Nothing is known before the spans are fetched
+	require.Nil(t, spanStore.knownSpanById(0), "unexpected span known before fetching")
+	require.Nil(t, spanStore.knownSpanByBlockNumber(100), "unexpected span known before fetching")
+
+	for i := uint64(0); i <= 20; i++ {
+		_, err := spanStore.spanById(ctx, i)
+		require.NoError(t, err, "err in spanById for id=%d", i)
+	}
+
+	// Spans evicted from cache are served from db
+	span := spanStore.knownSpanById(0)
+	require.NotNil(t, span, "known span not found for id=0")
+	require.Equal(t, uint64(0), span.Id, "invalid id in knownSpanById for id=0")
+
+	require.Nil(t, spanStore.knownSpanById(21), "unexpected span known for id=21")
+
+	type Testcase struct {
+		blockNumber uint64
+		id          uint64
+	}
+
+	testcases := []Testcase{
+		{blockNumber: 0, id: 0},
+		{blockNumber: 255, id: 0},
+		{blockNumber: 256, id: 1},
+		{blockNumber: 60000, id: 10},
+		{blockNumber: 6400*20 + 255, id: 20},
+	}
+
+	for _, tc := range testcases {
+		span := spanStore.knownSpanByBlockNumber(tc.blockNumber)
+		require.NotNil(t, span, "known span not found for block=%d", tc.blockNumber)
+		require.Equal(t, tc.id, span.Id, "invalid id in knownSpanByBlockNumber for block=%d", tc.blockNumber)
+	}
+
+	// Blocks beyond the latest known span are never fetched from heimdall
+	require.Nil(t, spanStore.knownSpanByBlockNumber(6400*20+256), "unexpected span known for future block")
+}
+
 // Irrelevant to the tests above but necessary for interface compatibility
 func (h *MockHeimdallClient) StateSyncEvents(ctx context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
 	panic("implement me")
@@ -209,3 +323,38 @@ func (h *MockHeimdallClient) FetchMilestoneCount(ctx context.Context) (int64, er
 func (h *MockHeimdallClient) Close() {
 	panic("implement me")
 }
+
+func TestSpanStore_ConcurrentLatestKnownSpanId(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	spanStore := NewSpanStore(&MockHeimdallClient{}, nil, "1337", db)
+	ctx := t.Context()
+
+	var wg sync.WaitGroup
+
+	// Spans are fetched by the heimdall ws subscription while blocks are verified
+	for i := uint64(0); i <= 20; i++ {
+		wg.Add(2)
+
+		go func(id uint64) {
+			defer wg.Done()
+
+			_, err := spanStore.spanById(ctx, id)
+			require.NoError(t, err, "err in spanById for id=%d", id)
+		}(i)
+
+		go func(blockNumber uint64) {
+			defer wg.Done()
+
+			spanStore.knownSpanByBlockNumber(blockNumber)
+		}(i * 6400)
+	}
+
+	wg.Wait()
+
+	// The latest known span id never goes backwards, neither in memory nor in the database
+	require.Equal(t, uint64(20), spanStore.latestKnownSpanId.Load(), "invalid latest known span id in span store")
+
+	lastId := rawdb.ReadLastBorSpanId(db)
+	require.NotNil(t, lastId, "latest known span id not persisted")
+	require.Equal(t, uint64(20), *lastId, "invalid latest known span id in db")
+}
@@ -0,0 +1,80 @@
+package bor
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// StateSyncWindowEnd returns the time before which state-sync events are committed in
+// the given sprint start block. Post Indore, the window ends the state-sync confirmation
+// delay before the block. Before that, it ends at the time of the block one sprint back,
+// which is looked up using getHeaderByNumber.
+func StateSyncWindowEnd(config *params.BorConfig, header *types.Header, getHeaderByNumber func(uint64) *types.Header) time.Time {
+	number := header.Number.Uint64()
+
+	if config.IsIndore(header.Number) {
+		stateSyncDelay := config.CalculateStateSyncDelay(number)
+		return time.Unix(int64(header.Time-stateSyncDelay), 0)
+	}
+
+	return time.Unix(int64(getHeaderByNumber(number-config.CalculateSprint(number)).Time), 0)
+}
+
+// hasStateSyncOverride returns whether the number of records committed in the given
+// block is overridden.
+func hasStateSyncOverride(config *params.BorConfig, number uint64) bool {
+	if _, ok := config.OverrideStateSyncRecords[strconv.FormatUint(number, 10)]; ok {
+		return true
+	}
+
+	_, ok := config.GetOverrideStateSyncRecord(number)
+
+	return ok
+}
+
+// FilterStateSyncEvents returns the event records committed in the given block out of the
+// records fetched from heimdall. The state-sync record overrides of the block are applied
+// first, then records are taken in order until one is out of sequence, belongs to another
+// chain or falls outside the time window.
+func FilterStateSyncEvents(config *params.BorConfig, number uint64, eventRecords []*clerk.EventRecordWithTime, lastStateID uint64, to time.Time, chainID string) []*clerk.EventRecordWithTime {
+	// This if statement checks if there are any state sync record overrides configured for the current block number.
+	// If there are, it truncates the eventRecords array to the specified number of records.
+	if config.OverrideStateSyncRecords != nil {
+		if val, ok := config.OverrideStateSyncRecords[strconv.FormatUint(number, 10)]; ok {
+			eventRecords = eventRecords[0:val]
+		}
+	}
+
+	// This if statement checks if there are any state sync record overrides configured for the current block number.
+	// If there are, it truncates the eventRecords array to the specified number of records.
+	if config.OverrideStateSyncRecordsInRange != nil {
+		overrideStateSyncRecord, ok := config.GetOverrideStateSyncRecord(number)
+		if ok {
+			eventRecords = eventRecords[0:overrideStateSyncRecord]
+		}
+	}
+
+	committed := make([]*clerk.EventRecordWithTime, 0, len(eventRecords))
+
+	for _, eventRecord := range eventRecords {
+		if eventRecord.ID <= lastStateID {
+			continue
+		}
+
+		if err := validateEventRecord(eventRecord, number, to, lastStateID, chainID); err != nil {
+			log.Error("while validating event record", "block", number, "to", to, "stateID", lastStateID+1, "error", err.Error())
+			break
+		}
+
+		committed = append(committed, eventRecord)
+
+		lastStateID++
+	}
+
+	return committed
+}
@@ -0,0 +1,136 @@
+package bor
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// maxBufferedStateSyncs is the maximum number of state-sync records kept in the buffer
+const maxBufferedStateSyncs = 1024
+
+// stateSyncBuffer keeps the state-sync records pushed by heimdall so that they
+// don't have to be fetched while committing states. The buffer only answers a
+// query if it can prove that it holds every record heimdall would have returned.
+type stateSyncBuffer struct {
+	lock    sync.RWMutex
+	records map[uint64]*clerk.EventRecordWithTime
+	lastID  uint64 // id of the last record of the contiguous run of records
+}
+
+func newStateSyncBuffer() *stateSyncBuffer {
+	return &stateSyncBuffer{
+		records: make(map[uint64]*clerk.EventRecordWithTime),
+	}
+}
+
+// add stores the records and evicts the oldest ones if the buffer is full
+func (b *stateSyncBuffer) add(records ...*clerk.EventRecordWithTime) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.lastID == 0 && len(records) > 0 {
+		b.lastID = records[0].ID - 1
+	}
+
+	for _, record := range records {
+		b.records[record.ID] = record
+	}
+
+	for b.records[b.lastID+1] != nil {
+		b.lastID++
+	}
+
+	// Evict the oldest records, they belong to blocks which are already committed
+	if len(b.records) > maxBufferedStateSyncs {
+		ids := make([]uint64, 0, len(b.records))
+		for id := range b.records {
+			ids = append(ids, id)
+		}
+
+		slices.Sort(ids)
+
+		for _, id := range ids[:len(ids)-maxBufferedStateSyncs] {
+			delete(b.records, id)
+		}
+	}
+}
+
+// last returns the id of the last buffered record or 0 if the buffer is empty
+func (b *stateSyncBuffer) last() uint64 {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return b.lastID
+}
+
+// get returns the records starting at fromID which happened before to. It fails
+// unless the buffer holds a contiguous run of records from fromID up to a record
+// at or after to, as otherwise a matching record may still be missing.
+func (b *stateSyncBuffer) get(fromID uint64, to time.Time) ([]*clerk.EventRecordWithTime, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	records := make([]*clerk.EventRecordWithTime, 0)
+
+	for id := fromID; ; id++ {
+		record, ok := b.records[id]
+		if !ok {
+			return nil, false
+		}
+
+		if !record.Time.Before(to) {
+			return records, true
+		}
+
+		records = append(records, record)
+	}
+}
+
+// ProcessStateSyncEvent fetches the state-sync records announced by heimdall into
+// the buffer. Records are fetched from the last buffered one so that records missed
+// while the subscription was down are recovered as well.
+func (c *Bor) ProcessStateSyncEvent(ctx context.Context, id uint64) error {
+	from := c.stateSyncs.last() + 1
+	if id < from {
+		// Already buffered
+		return nil
+	}
+
+	if from == 1 {
+		// Nothing buffered yet, start at the announced record. Older
+		// records are fetched while committing states.
+		from = id
+	}
+
+	return c.fetchStateSyncEvents(ctx, from, id)
+}
+
+// PollStateSyncEvents fetches the state-sync records following the last buffered one
+// into the buffer, for records to be buffered while the subscription is broken.
+func (c *Bor) PollStateSyncEvents(ctx context.Context) error {
+	from := c.stateSyncs.last() + 1
+	if from == 1 {
+		// Nothing to resume from, records are fetched while committing states
+		return nil
+	}
+
+	return c.fetchStateSyncEvents(ctx, from, 0)
+}
+
+func (c *Bor) fetchStateSyncEvents(ctx context.Context, from uint64, announced uint64) error {
+	records, err := c.HeimdallClient.StateSyncEvents(ctx, from, time.Now().Unix())
+	if err != nil {
+		return err
+	}
+
+	c.stateSyncs.add(records...)
+
+	log.Debug("Buffered state-sync events", "from", from, "announced", announced, "records", len(records))
+
+	return nil
+}
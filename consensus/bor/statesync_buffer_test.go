@@ -0,0 +1,169 @@
+package bor
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func makeStateSyncRecords(from, to uint64, start time.Time) []*clerk.EventRecordWithTime {
+	records := make([]*clerk.EventRecordWithTime, 0, to-from+1)
+
+	for id := from; id <= to; id++ {
+		records = append(records, &clerk.EventRecordWithTime{
+			EventRecord: clerk.EventRecord{ID: id},
+			Time:        start.Add(time.Duration(id) * time.Second),
+		})
+	}
+
+	return records
+}
+
+func TestStateSyncBuffer(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1_700_000_000, 0)
+	buffer := newStateSyncBuffer()
+
+	_, ok := buffer.get(1, start.Add(time.Hour))
+	require.False(t, ok, "empty buffer must not answer queries")
+
+	buffer.add(makeStateSyncRecords(10, 20, start)...)
+	require.Equal(t, uint64(20), buffer.last())
+
+	// Records before the buffered range are unknown
+	_, ok = buffer.get(5, start.Add(15*time.Second))
+	require.False(t, ok)
+
+	// A record at or after `to` proves that the result is complete
+	records, ok := buffer.get(10, start.Add(15*time.Second))
+	require.True(t, ok)
+	require.Len(t, records, 5)
+	require.Equal(t, uint64(14), records[4].ID)
+
+	// Without such a record, more records may still be announced
+	_, ok = buffer.get(10, start.Add(time.Hour))
+	require.False(t, ok)
+
+	// Gaps are not bridged until the missing records are added
+	buffer.add(makeStateSyncRecords(25, 30, start)...)
+	require.Equal(t, uint64(20), buffer.last())
+
+	_, ok = buffer.get(18, start.Add(28*time.Second))
+	require.False(t, ok)
+
+	buffer.add(makeStateSyncRecords(21, 24, start)...)
+	require.Equal(t, uint64(30), buffer.last())
+
+	records, ok = buffer.get(18, start.Add(28*time.Second))
+	require.True(t, ok)
+	require.Len(t, records, 10)
+
+	// The oldest records are evicted once the buffer is full
+	buffer.add(makeStateSyncRecords(31, 30+maxBufferedStateSyncs, start)...)
+	require.Len(t, buffer.records, maxBufferedStateSyncs)
+
+	_, ok = buffer.get(10, start.Add(15*time.Second))
+	require.False(t, ok)
+}
+
+// heimdallStateSyncEvents returns the records heimdall returns for the given query,
+// i.e. the records starting at fromID which happened before to, in order of ids.
+func heimdallStateSyncEvents(records []*clerk.EventRecordWithTime, fromID uint64, to time.Time) []*clerk.EventRecordWithTime {
+	result := make([]*clerk.EventRecordWithTime, 0)
+
+	for _, record := range records {
+		if record.ID >= fromID && record.Time.Before(to) {
+			result = append(result, record)
+		}
+	}
+
+	return result
+}
+
+// The buffered records must commit the same records as the ones fetched from heimdall,
+// including when the records are out of time order or belong to another chain.
+func TestStateSyncBufferMatchesHeimdall(t *testing.T) {
+	t.Parallel()
+
+	const chainID = "137"
+
+	var (
+		config = &params.BorConfig{}
+		start  = time.Unix(1_700_000_000, 0)
+		rng    = rand.New(rand.NewSource(1))
+	)
+
+	for i := 0; i < 200; i++ {
+		records := makeStateSyncRecords(1, 40, start)
+
+		for _, record := range records {
+			record.ChainID = chainID
+
+			// Shuffle the time of some records and move some to another chain
+			if rng.Intn(8) == 0 {
+				record.Time = start.Add(time.Duration(rng.Intn(40)) * time.Second)
+			}
+
+			if rng.Intn(20) == 0 {
+				record.ChainID = "80001"
+			}
+		}
+
+		buffer := newStateSyncBuffer()
+		buffer.add(records...)
+
+		lastStateID := uint64(rng.Intn(30))
+		to := start.Add(time.Duration(rng.Intn(45)) * time.Second)
+
+		buffered, ok := buffer.get(lastStateID+1, to)
+		if !ok {
+			continue
+		}
+
+		fetched := heimdallStateSyncEvents(records, lastStateID+1, to)
+
+		require.Equal(t,
+			FilterStateSyncEvents(config, 1, fetched, lastStateID, to, chainID),
+			FilterStateSyncEvents(config, 1, buffered, lastStateID, to, chainID),
+			"from %d, to %v", lastStateID+1, to)
+	}
+}
+
+// stateSyncHeimdall is a heimdall client serving the given state-sync records.
+type stateSyncHeimdall struct {
+	MockHeimdallClient
+
+	records []*clerk.EventRecordWithTime
+}
+
+func (h *stateSyncHeimdall) StateSyncEvents(_ context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	return heimdallStateSyncEvents(h.records, fromID, time.Unix(to, 0)), nil
+}
+
+func TestPollStateSyncEvents(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1_700_000_000, 0)
+	heimdall := &stateSyncHeimdall{records: makeStateSyncRecords(1, 30, start)}
+	c := &Bor{HeimdallClient: heimdall, stateSyncs: newStateSyncBuffer()}
+
+	// Without any announced record there is nothing to resume from
+	require.NoError(t, c.PollStateSyncEvents(context.Background()))
+	require.Equal(t, uint64(0), c.stateSyncs.last())
+
+	require.NoError(t, c.ProcessStateSyncEvent(context.Background(), 10))
+	require.Equal(t, uint64(30), c.stateSyncs.last())
+
+	// Records missed while the subscription is broken are polled
+	heimdall.records = append(heimdall.records, makeStateSyncRecords(31, 35, start)...)
+
+	require.NoError(t, c.PollStateSyncEvents(context.Background()))
+	require.Equal(t, uint64(35), c.stateSyncs.last())
+}
@@ -0,0 +1,90 @@
+package bor
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+// TxDependencyStats is the accuracy of the transaction dependency metadata embedded by a
+// single producer in its blocks over a block range, as checked by the node on import
+type TxDependencyStats struct {
+	Address      common.Address `json:"address"`
+	Blocks       uint64         `json:"blocks"`       // Checked blocks with transactions
+	Absent       uint64         `json:"absent"`       // Blocks without metadata
+	Invalid      uint64         `json:"invalid"`      // Blocks with malformed metadata, which is ignored
+	Accurate     uint64         `json:"accurate"`     // Blocks whose metadata matches the detected dependencies
+	Incomplete   uint64         `json:"incomplete"`   // Blocks whose metadata misses detected dependencies
+	Conservative uint64         `json:"conservative"` // Blocks whose metadata declares needless dependencies
+	Declared     uint64         `json:"declared"`
+	Detected     uint64         `json:"detected"`
+	Missing      uint64         `json:"missing"`
+	Extra        uint64         `json:"extra"`
+	Accuracy     float64        `json:"accuracy"` // Share of the blocks with valid metadata which are accurate
+}
+
+// txDependencyStatsCollector aggregates tx dependency records into per producer stats
+type txDependencyStatsCollector struct {
+	stats map[common.Address]*TxDependencyStats
+}
+
+func newTxDependencyStatsCollector() *txDependencyStatsCollector {
+	return &txDependencyStatsCollector{stats: make(map[common.Address]*TxDependencyStats)}
+}
+
+func (t *txDependencyStatsCollector) add(record *rawdb.TxDependencyRecord) {
+	stats, ok := t.stats[record.Producer]
+	if !ok {
+		stats = &TxDependencyStats{Address: record.Producer}
+		t.stats[record.Producer] = stats
+	}
+
+	stats.Blocks++
+	stats.Detected += record.Detected
+
+	switch {
+	case !record.Present:
+		stats.Absent++
+		return
+	case !record.Valid:
+		stats.Invalid++
+		return
+	}
+
+	stats.Declared += record.Declared
+	stats.Missing += record.Missing
+	stats.Extra += record.Extra
+
+	if record.Missing > 0 {
+		stats.Incomplete++
+	}
+
+	if record.Extra > 0 {
+		stats.Conservative++
+	}
+
+	if record.Missing == 0 && record.Extra == 0 {
+		stats.Accurate++
+	}
+}
+
+// result returns the stats of every producer ordered by address
+func (t *txDependencyStatsCollector) result() []*TxDependencyStats {
+	result := make([]*TxDependencyStats, 0, len(t.stats))
+
+	for _, stats := range t.stats {
+		if valid := stats.Blocks - stats.Absent - stats.Invalid; valid > 0 {
+			stats.Accuracy = float64(stats.Accurate) / float64(valid)
+		}
+
+		result = append(result, stats)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i].Address.Bytes(), result[j].Address.Bytes()) < 0
+	})
+
+	return result
+}
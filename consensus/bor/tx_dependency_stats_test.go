@@ -0,0 +1,44 @@
+package bor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+func TestTxDependencyStatsCollector(t *testing.T) {
+	t.Parallel()
+
+	var (
+		producer1 = common.HexToAddress("0x1")
+		producer2 = common.HexToAddress("0x2")
+	)
+
+	collector := newTxDependencyStatsCollector()
+
+	collector.add(&rawdb.TxDependencyRecord{Producer: producer2, Present: true, Valid: true, Declared: 3, Detected: 3})
+	collector.add(&rawdb.TxDependencyRecord{Producer: producer2, Present: true, Valid: true, Declared: 2, Detected: 3, Missing: 1, Extra: 1})
+	collector.add(&rawdb.TxDependencyRecord{Producer: producer2, Present: true, Detected: 1})
+	collector.add(&rawdb.TxDependencyRecord{Producer: producer1})
+
+	stats := collector.result()
+	require.Len(t, stats, 2)
+
+	require.Equal(t, &TxDependencyStats{Address: producer1, Blocks: 1, Absent: 1}, stats[0])
+	require.Equal(t, &TxDependencyStats{
+		Address:      producer2,
+		Blocks:       3,
+		Invalid:      1,
+		Accurate:     1,
+		Incomplete:   1,
+		Conservative: 1,
+		Declared:     5,
+		Detected:     7,
+		Missing:      1,
+		Extra:        1,
+		Accuracy:     0.5,
+	}, stats[1])
+}
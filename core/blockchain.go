@@ -46,7 +46,6 @@ import (
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
-	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/syncx"
@@ -122,6 +121,7 @@ const (
 	txLookupCacheLimit  = 1024
 	maxFutureBlocks     = 256
 	maxTimeFutureBlocks = 30
+	committedAheadLimit = 16
 
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
 	//
@@ -298,9 +298,12 @@ type BlockChain struct {
 	engine                       consensus.Engine
 	validator                    Validator // Block and state validator interface
 	prefetcher                   Prefetcher
-	processor                    Processor // Block transaction processor interface
-	parallelProcessor            Processor // Parallel block transaction processor interface
-	parallelSpeculativeProcesses int       // Number of parallel speculative processes
+	processor                    Processor                                  // Block transaction processor interface
+	parallelProcessor            Processor                                  // Parallel block transaction processor interface
+	parallelSpeculativeProcesses int                                        // Number of parallel speculative processes
+	parallelScheduler            *parallelScheduler                         // Picks the number of speculative processes per block, nil if fixed
+	txDependencyCh               chan *txDependencyTask                     // Blocks whose tx dependency metadata is checked in the background
+	txDependencyPending          *lru.Cache[common.Hash, *txDependencyTask] // Executed blocks whose tx dependency metadata is checked once written
 	enforceParallelProcessor     bool
 	forker                       *ForkChoice
 	vmConfig                     vm.Config
@@ -308,10 +311,12 @@ type BlockChain struct {
 
 	// Bor related changes
 	borReceiptsCache *lru.Cache[common.Hash, *types.Receipt] // Cache for the most recent bor receipt receipts per block
+	committedAhead   *lru.Cache[common.Hash, struct{}]       // State roots committed ahead of writing their block
 	stateSyncData    []*types.StateSyncData                  // State sync data
 	stateSyncFeed    event.Feed                              // State sync feed
 	chain2HeadFeed   event.Feed                              // Reorg/NewHead/Fork data feed
 	chainSideFeed    event.Feed                              // Side chain data feed (removed from geth but needed in bor)
+	finalizedFeed    event.Feed                              // Milestone/checkpoint finality feed
 }
 
 // NewBlockChain returns a fully initialised block chain using information
@@ -371,6 +376,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		vmConfig:      vmConfig,
 
 		borReceiptsCache: lru.NewCache[common.Hash, *types.Receipt](receiptsCacheLimit),
+		committedAhead:   lru.NewCache[common.Hash, struct{}](committedAheadLimit),
 		logger:           vmConfig.Tracer,
 	}
 
@@ -585,10 +591,26 @@ func NewParallelBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis
 	bc.parallelProcessor = NewParallelStateProcessor(bc.chainConfig, bc, engine)
 	bc.parallelSpeculativeProcesses = numprocs
 	bc.enforceParallelProcessor = enforce
+	bc.txDependencyCh = make(chan *txDependencyTask, txDependencyQueueSize)
+	bc.txDependencyPending = lru.NewCache[common.Hash, *txDependencyTask](txDependencyQueueSize)
+
+	bc.wg.Add(1)
+	go bc.txDependencyLoop()
 
 	return bc, nil
 }
 
+// EnableAdaptiveParallelism makes the number of speculative processes used to execute each
+// block adaptive, up to the configured number. Blocks whose predicted parallelism is below
+// minParallelism are executed by the serial state processor only.
+func (bc *BlockChain) EnableAdaptiveParallelism(minParallelism float64) {
+	if bc.parallelProcessor == nil {
+		return
+	}
+
+	bc.parallelScheduler = newParallelScheduler(bc.parallelSpeculativeProcesses, minParallelism)
+}
+
 func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header, witness *stateless.Witness) (_ types.Receipts, _ []*types.Log, _ uint64, _ *state.StateDB, vtime time.Duration, blockEndErr error) {
 	// Process the block using processor and parallelProcessor at the same time, take the one which finishes first, cancel the other, and return the result
 	ctx, cancel := context.WithCancel(context.Background())
@@ -620,8 +642,27 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header, wit
 		parallel bool
 	}
 
+	useParallelProcessor := bc.parallelProcessor != nil
+	enforceParallelProcessor := bc.enforceParallelProcessor
+
+	// Number of speculative workers picked for the block, the configured number if 0
+	var workers int
+
+	if useParallelProcessor && bc.parallelScheduler != nil {
+		workers = bc.parallelScheduler.workers(block)
+
+		if workers == 0 {
+			log.Debug("Processing block using the serial processor only", "number", block.NumberU64())
+			bc.parallelScheduler.skip()
+
+			useParallelProcessor, enforceParallelProcessor = false, false
+		} else {
+			parallelWorkersGauge.Update(int64(workers))
+		}
+	}
+
 	var resultChanLen int = 2
-	if bc.enforceParallelProcessor {
+	if enforceParallelProcessor {
 		log.Debug("Processing block using Block STM only", "number", block.NumberU64())
 		resultChanLen = 1
 	}
@@ -629,7 +670,7 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header, wit
 
 	processorCount := 0
 
-	if bc.parallelProcessor != nil {
+	if useParallelProcessor {
 		parallelStatedb, err := state.New(parent.Root, bc.statedb)
 		if err != nil {
 			return nil, nil, 0, nil, 0, err
@@ -640,7 +681,15 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header, wit
 		go func() {
 			parallelStatedb.StartPrefetcher("chain", witness)
 			pstart := time.Now()
-			res, err := bc.parallelProcessor.Process(block, parallelStatedb, bc.vmConfig, ctx)
+			var (
+				res *ProcessResult
+				err error
+			)
+			if processor, ok := bc.parallelProcessor.(*ParallelStateProcessor); ok {
+				res, err = processor.ProcessWithWorkers(block, parallelStatedb, bc.vmConfig, ctx, workers)
+			} else {
+				res, err = bc.parallelProcessor.Process(block, parallelStatedb, bc.vmConfig, ctx)
+			}
 			blockExecutionParallelTimer.UpdateSince(pstart)
 			if err == nil {
 				vstart := time.Now()
@@ -654,7 +703,7 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header, wit
 		}()
 	}
 
-	if bc.processor != nil && !bc.enforceParallelProcessor {
+	if bc.processor != nil && !enforceParallelProcessor {
 		statedb, err := state.New(parent.Root, bc.statedb)
 		if err != nil {
 			return nil, nil, 0, nil, 0, err
@@ -1934,11 +1983,20 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	if err := blockBatch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
 	}
-	// Commit all cached state changes into underlying memory database.
-	root, err := statedb.Commit(block.NumberU64(), bc.chainConfig.IsEIP158(block.Number()), bc.chainConfig.IsCancun(block.Number()))
-	if err != nil {
-		return []*types.Log{}, err
+	// Commit all cached state changes into underlying memory database, unless the state was
+	// committed ahead of the block, see CommitStateAhead.
+	root := block.Root()
+	if _, ok := bc.committedAhead.Peek(root); ok {
+		bc.committedAhead.Remove(root)
+	} else {
+		var err error
+		if root, err = statedb.Commit(block.NumberU64(), bc.chainConfig.IsEIP158(block.Number()), bc.chainConfig.IsCancun(block.Number())); err != nil {
+			return []*types.Log{}, err
+		}
 	}
+	// The block is accepted, its tx dependency metadata can be checked
+	bc.acceptTxDependencyCheck(block.Hash())
+
 	// If node is running in path mode, skip explicit gc operation
 	// which is unnecessary in this mode.
 	if bc.triedb.Scheme() == rawdb.PathScheme {
@@ -2002,6 +2060,25 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	return stateSyncLogs, nil
 }
 
+// CommitStateAhead commits the state of a block before the block is written, e.g. for the
+// miner to build the next block on top of it while the block is being sealed. Writing the
+// block then skips committing its state again, which would replace the state layer the
+// next block is built upon.
+func (bc *BlockChain) CommitStateAhead(block *types.Block, statedb *state.StateDB) error {
+	root, err := statedb.Commit(block.NumberU64(), bc.chainConfig.IsEIP158(block.Number()), bc.chainConfig.IsCancun(block.Number()))
+	if err != nil {
+		return err
+	}
+
+	if root != block.Root() {
+		return fmt.Errorf("state root mismatch: have %x, want %x", root, block.Root())
+	}
+
+	bc.committedAhead.Add(root, struct{}{})
+
+	return nil
+}
+
 // WriteBlockAndSetHead writes the given block and all associated state to the database,
 // and applies the block as the new chain head.
 func (bc *BlockChain) WriteBlockAndSetHead(block *types.Block, receipts []*types.Receipt, logs []*types.Log, state *state.StateDB, emitHeadEvent bool) (status WriteStatus, err error) {
@@ -2198,7 +2275,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 	if !isValid {
 		// The chain to be imported is invalid as the blocks doesn't match with
 		// the whitelisted block number.
-		return nil, it.index, whitelist.ErrMismatch
+		return nil, it.index, bc.forker.ChainConflict(bc.CurrentBlock(), headers)
 	}
 
 	// Left-trim all the known blocks that don't need to build snapshot
@@ -2508,7 +2585,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 		}
 
 		if !isValid {
-			return nil, it.index, whitelist.ErrMismatch
+			return nil, it.index, bc.forker.ChainConflict(block.Header(), []*types.Header{block.Header()})
 		}
 
 		if !setHead {
@@ -3368,6 +3445,41 @@ func (bc *BlockChain) InsertHeaderChain(chain []*types.Header) (int, error) {
 	return 0, err
 }
 
+// InsertVerifiedHeaderChain inserts the given header chain in to the local chain like
+// InsertHeaderChain, but verifies the headers with the given function instead of the
+// consensus engine, as they were verified by other means, e.g. linked by hash to a
+// finalized block. If an error is returned, it will return the index number of the
+// failing header as well an error describing what went wrong.
+func (bc *BlockChain) InsertVerifiedHeaderChain(chain []*types.Header, verify func(*types.Header) error) (int, error) {
+	if len(chain) == 0 {
+		return 0, nil
+	}
+
+	start := time.Now()
+
+	if !bc.HasHeader(chain[0].ParentHash, chain[0].Number.Uint64()-1) {
+		return 0, consensus.ErrUnknownAncestor
+	}
+
+	for i, header := range chain {
+		if i > 0 && (header.Number.Uint64() != chain[i-1].Number.Uint64()+1 || header.ParentHash != chain[i-1].Hash()) {
+			return i, fmt.Errorf("non contiguous insert: item %d is #%d [%x..], item %d is #%d [%x..] (parent [%x..])", i-1, chain[i-1].Number,
+				chain[i-1].Hash().Bytes()[:4], i, header.Number, header.Hash().Bytes()[:4], header.ParentHash[:4])
+		}
+
+		if err := verify(header); err != nil {
+			return i, err
+		}
+	}
+
+	if !bc.chainmu.TryLock() {
+		return 0, errChainStopped
+	}
+	defer bc.chainmu.Unlock()
+	_, err := bc.hc.InsertHeaderChain(chain, start, bc.forker)
+	return 0, err
+}
+
 func (bc *BlockChain) GetChainConfig() *params.ChainConfig {
 	return bc.chainConfig
 }
@@ -1,11 +1,13 @@
 package core
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -141,3 +143,48 @@ func TestChain2HeadEvent(t *testing.T) {
 			replacementBlocks[3].Hash(),
 		}})
 }
+
+// Tests that inserting verified headers reports the index of the first header failing
+// the verification, and inserts nothing in that case.
+func TestInsertVerifiedHeaderChain(t *testing.T) {
+	t.Parallel()
+
+	_, genesis, blockchain, err := newCanonical(ethash.NewFaker(), 0, false, rawdb.HashScheme)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer blockchain.Stop()
+
+	_, headers := makeHeaderChainWithGenesis(genesis, 10, ethash.NewFaker(), canonicalSeed)
+
+	errRejected := errors.New("rejected")
+	reject := func(number uint64) func(*types.Header) error {
+		return func(header *types.Header) error {
+			if header.Number.Uint64() == number {
+				return errRejected
+			}
+
+			return nil
+		}
+	}
+
+	if n, err := blockchain.InsertVerifiedHeaderChain(headers, reject(6)); !errors.Is(err, errRejected) || n != 5 {
+		t.Fatalf("failing header mismatch: have %d (%v), want 5 (%v)", n, err, errRejected)
+	}
+
+	if head := blockchain.CurrentHeader().Number.Uint64(); head != 0 {
+		t.Fatalf("headers inserted despite the failure: head %d", head)
+	}
+
+	if n, err := blockchain.InsertVerifiedHeaderChain(headers[1:], reject(0)); !errors.Is(err, consensus.ErrUnknownAncestor) || n != 0 {
+		t.Fatalf("unlinked header mismatch: have %d (%v), want 0 (%v)", n, err, consensus.ErrUnknownAncestor)
+	}
+
+	if _, err := blockchain.InsertVerifiedHeaderChain(headers, reject(0)); err != nil {
+		t.Fatalf("failed to insert verified headers: %v", err)
+	}
+
+	if head := blockchain.CurrentHeader().Number.Uint64(); head != 10 {
+		t.Fatalf("head mismatch: have %d, want 10", head)
+	}
+}
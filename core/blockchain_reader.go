@@ -552,3 +552,14 @@ func (bc *BlockChain) GetStateSync() []*types.StateSyncData {
 func (bc *BlockChain) SubscribeStateSyncEvent(ch chan<- StateSyncEvent) event.Subscription {
 	return bc.scope.Track(bc.stateSyncFeed.Subscribe(ch))
 }
+
+// SubscribeFinalizedHeadEvent registers a subscription of FinalizedHeadEvent.
+func (bc *BlockChain) SubscribeFinalizedHeadEvent(ch chan<- FinalizedHeadEvent) event.Subscription {
+	return bc.scope.Track(bc.finalizedFeed.Subscribe(ch))
+}
+
+// PostFinalizedHeadEvent notifies the subscribers that the finalized block advanced or
+// was rolled back.
+func (bc *BlockChain) PostFinalizedHeadEvent(ev FinalizedHeadEvent) {
+	bc.finalizedFeed.Send(ev)
+}
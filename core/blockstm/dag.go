@@ -72,6 +72,11 @@ func BuildDAG(deps TxnInputOutput) (d DAG) {
 		}
 	}
 
+	// The first transaction has no vertex yet if no other transaction depends on it
+	if _, ok := ids[0]; !ok && len(deps.inputs) > 0 {
+		_, _ = d.AddVertex(0)
+	}
+
 	return
 }
 
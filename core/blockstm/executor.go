@@ -160,10 +160,22 @@ func (pq *SafePriorityQueue) Len() int {
 }
 
 type ParallelExecutionResult struct {
-	TxIO    *TxnInputOutput
-	Stats   *map[int]ExecutionStat
-	Deps    *DAG
-	AllDeps map[int]map[int]bool
+	TxIO         *TxnInputOutput
+	Stats        *map[int]ExecutionStat
+	Deps         *DAG
+	AllDeps      map[int]map[int]bool
+	Incarnations []int                    // Number of incarnations of each transaction, only set when profiling
+	Aborts       map[int][]ExecutionAbort // Aborted incarnations of each transaction, only set when profiling
+	Executions   int                      // Number of executions of transactions, including re-executions
+	MVHashMap    *MVHashMap               // Writes of the last incarnation of every transaction
+}
+
+// ExecutionAbort describes why an incarnation of a transaction had to be re-executed
+type ExecutionAbort struct {
+	Incarnation int
+	Dependency  int  // Transaction whose pending writes were read, -1 if unknown
+	Validation  bool // True if the incarnation completed but its reads were invalidated afterwards
+	Reason      string
 }
 
 const numGoProcs = 1
@@ -219,6 +231,9 @@ type ParallelExecutor struct {
 
 	diagExecSuccess, diagExecAbort []int
 
+	// Stores the aborted incarnations of each transaction when profiling
+	aborts map[int][]ExecutionAbort
+
 	// Multi-version hash map
 	mvh *MVHashMap
 
@@ -283,6 +298,7 @@ func NewParallelExecutor(tasks []ExecTask, profile bool, metadata bool, numProcs
 		validateTasks:       makeStatusManager(0),
 		diagExecSuccess:     make([]int, numTasks),
 		diagExecAbort:       make([]int, numTasks),
+		aborts:              make(map[int][]ExecutionAbort),
 		mvh:                 MakeMVHashMap(),
 		lastTxIO:            MakeTxnInputOutput(numTasks),
 		txIncarnations:      make([]int, numTasks),
@@ -463,6 +479,19 @@ func (pe *ParallelExecutor) Step(res *ExecResult) (result ParallelExecutionResul
 			pe.execTasks.pushPending(tx)
 		}
 
+		if pe.profile {
+			reason := execErr.Error()
+			if execErr.OriginError != nil {
+				reason = execErr.OriginError.Error()
+			}
+
+			pe.aborts[tx] = append(pe.aborts[tx], ExecutionAbort{
+				Incarnation: res.ver.Incarnation,
+				Dependency:  execErr.Dependency,
+				Reason:      reason,
+			})
+		}
+
 		pe.txIncarnations[tx]++
 		pe.diagExecAbort[tx]++
 		pe.cntAbort++
@@ -536,6 +565,15 @@ func (pe *ParallelExecutor) Step(res *ExecResult) (result ParallelExecutionResul
 			pe.execTasks.clearComplete(tx)
 			pe.execTasks.pushPending(tx)
 
+			if pe.profile {
+				pe.aborts[tx] = append(pe.aborts[tx], ExecutionAbort{
+					Incarnation: pe.txIncarnations[tx],
+					Dependency:  -1,
+					Validation:  true,
+					Reason:      "read set invalidated",
+				})
+			}
+
 			pe.preValidated[tx] = false
 			pe.txIncarnations[tx]++
 		}
@@ -562,12 +600,23 @@ func (pe *ParallelExecutor) Step(res *ExecResult) (result ParallelExecutionResul
 
 		var deps DAG
 
+		var incarnations []int
+
+		var aborts map[int][]ExecutionAbort
+
 		if pe.profile {
 			allDeps = GetDep(*pe.lastTxIO)
 			deps = BuildDAG(*pe.lastTxIO)
+
+			incarnations = make([]int, len(pe.tasks))
+			for i := range incarnations {
+				incarnations[i] = pe.txIncarnations[i] + 1
+			}
+
+			aborts = pe.aborts
 		}
 
-		return ParallelExecutionResult{pe.lastTxIO, &pe.stats, &deps, allDeps}, err
+		return ParallelExecutionResult{pe.lastTxIO, &pe.stats, &deps, allDeps, incarnations, aborts, pe.cntExec, pe.mvh}, err
 	}
 
 	// Send the next immediate pending transaction to be executed
@@ -603,7 +652,7 @@ type PropertyCheck func(*ParallelExecutor) error
 
 func executeParallelWithCheck(tasks []ExecTask, profile bool, check PropertyCheck, metadata bool, numProcs int, interruptCtx context.Context) (result ParallelExecutionResult, err error) {
 	if len(tasks) == 0 {
-		return ParallelExecutionResult{MakeTxnInputOutput(len(tasks)), nil, nil, nil}, nil
+		return ParallelExecutionResult{MakeTxnInputOutput(len(tasks)), nil, nil, nil, nil, nil, 0, MakeMVHashMap()}, nil
 	}
 
 	pe := NewParallelExecutor(tasks, profile, metadata, numProcs)
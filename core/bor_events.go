@@ -21,3 +21,28 @@ type Chain2HeadEvent struct {
 	OldChain []*types.Header
 	Type     string
 }
+
+var (
+	FinalizedByMilestone  = "milestone"
+	FinalizedByCheckpoint = "checkpoint"
+	FinalityRollback      = "rollback"
+)
+
+// FinalizedHeadEvent is posted when a milestone or a checkpoint advances the finalized
+// block, or when the chain is rewound because it diverged from the finalized chain.
+type FinalizedHeadEvent struct {
+	Type string
+
+	// Milestone or checkpoint which finalized the blocks, empty or zero if unknown
+	MilestoneID      string
+	CheckpointNumber uint64
+
+	// Blocks finalized by the milestone or the checkpoint, the start block being zero if
+	// unknown, or the blocks rewound by the rollback
+	StartBlock uint64
+	EndBlock   uint64
+
+	// Finalized header, or the head the chain was rewound to by the rollback. It is nil if
+	// the finalized block isn't in the local chain.
+	Header *types.Header
+}
@@ -23,6 +23,23 @@ import (
 // NewTxsEvent is posted when a batch of transactions enter the transaction pool.
 type NewTxsEvent struct{ Txs []*types.Transaction }
 
+// Reasons a conditional transaction (PIP-15) is dropped from the transaction pool for,
+// naming the option which can't be satisfied anymore
+const (
+	ConditionalKnownAccounts = "knownAccounts"
+	ConditionalBlockNumber   = "blockNumber"
+	ConditionalTimestamp     = "timestamp"
+)
+
+// DroppedConditionalTxEvent is posted when a conditional transaction (PIP-15) is dropped
+// from the transaction pool because its options aren't satisfied by the new head anymore.
+type DroppedConditionalTxEvent struct {
+	Tx     *types.Transaction
+	Number uint64 // Number of the head the options were checked against
+	Reason string
+	Err    error
+}
+
 // NewMinedBlockEvent is posted when a block has been imported.
 type NewMinedBlockEvent struct{ Block *types.Block }
 
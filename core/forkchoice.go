@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -58,6 +59,12 @@ type ForkChoice struct {
 	validator ethereum.ChainValidator
 }
 
+// chainConflictReporter is implemented by the chain validators able to tell which whitelist
+// entry an invalid chain conflicts with.
+type chainConflictReporter interface {
+	ChainConflict(currentHeader *types.Header, chain []*types.Header) error
+}
+
 type Floater interface {
 	Float64() float64
 }
@@ -131,3 +138,15 @@ func (f *ForkChoice) ValidateReorg(current *types.Header, chain []*types.Header)
 
 	return true, nil
 }
+
+// ChainConflict returns the error a chain rejected by ValidateReorg is reported with, telling
+// which whitelist entry it conflicts with if the validator knows.
+func (f *ForkChoice) ChainConflict(current *types.Header, chain []*types.Header) error {
+	if reporter, ok := f.validator.(chainConflictReporter); ok {
+		if err := reporter.ChainConflict(current, chain); err != nil {
+			return err
+		}
+	}
+
+	return whitelist.ErrMismatch
+}
@@ -0,0 +1,227 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum/go-ethereum/common"
+	cmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/blockstm"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ParallelPrestates holds the outcome of the execution of the transactions of a block by
+// blockstm, from which the state each transaction was executed on is derived. Unlike
+// replaying the block serially, it allows re-executing the transactions (e.g. to trace
+// them) concurrently.
+type ParallelPrestates struct {
+	config      *params.ChainConfig
+	blockNumber *big.Int
+	base        *state.StateDB // State before the first transaction
+	final       *state.StateDB // State after the last transaction
+	mvh         *blockstm.MVHashMap
+	roots       []common.Hash // Intermediate state roots after each transaction, if computed
+
+	// Fees are not written to the multi-version hash map when they're delayed, hence the
+	// fees paid by the previous transactions are added to the state of every transaction.
+	// Fees are credited with the multi-version hash map paused when re-executing
+	// a transaction, hence the fee recipients are copied to the state otherwise
+	delayedFees   bool
+	coinbase      common.Address
+	burntContract common.Address
+	tips          []*uint256.Int // Tips paid by the transactions before each transaction
+	burns         []*uint256.Int // Fees burnt by the transactions before each transaction
+}
+
+// ExecuteParallelPrestates executes the transactions of the block on top of the given state
+// with blockstm, using the dependency metadata of the block if valid. The given state isn't
+// modified. The intermediate state roots after every transaction are computed if requested.
+// nolint:gocognit
+func ExecuteParallelPrestates(ctx context.Context, config *params.ChainConfig, chain ChainContext, block *types.Block, statedb *state.StateDB, numProcs int, roots bool) (*ParallelPrestates, error) {
+	var (
+		header      = block.Header()
+		blockHash   = block.Hash()
+		blockNumber = block.Number()
+		signer      = types.MakeSigner(config, header.Number, header.Time)
+		final       = statedb.Copy()
+		receipts    types.Receipts
+		allLogs     []*types.Log
+		usedGas     = new(uint64)
+		metadata    bool
+	)
+
+	coinbase, _ := chain.Engine().Author(header)
+
+	deps := GetDeps(block.GetTxDependency())
+	if len(deps) == len(block.Transactions()) && VerifyDeps(deps) {
+		metadata = len(deps) > 0
+	} else {
+		deps = make(map[int][]int)
+	}
+
+	shouldDelayFeeCal := true
+
+	var intermediateRoots *[]common.Hash
+	if roots {
+		intermediateRoots = new([]common.Hash)
+	}
+
+	tasks := make([]blockstm.ExecTask, 0, len(block.Transactions()))
+
+	for i, tx := range block.Transactions() {
+		msg, err := TransactionToMessage(tx, signer, header.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+		}
+
+		if msg.From == coinbase {
+			shouldDelayFeeCal = false
+		}
+
+		tasks = append(tasks, &ExecutionTask{
+			msg:               *msg,
+			config:            config,
+			gasLimit:          block.GasLimit(),
+			blockNumber:       blockNumber,
+			blockHash:         blockHash,
+			tx:                tx,
+			index:             i,
+			cleanStateDB:      statedb.Copy(),
+			finalStateDB:      final,
+			header:            header,
+			evmConfig:         vm.Config{},
+			shouldDelayFeeCal: &shouldDelayFeeCal,
+			sender:            msg.From,
+			totalUsedGas:      usedGas,
+			receipts:          &receipts,
+			allLogs:           &allLogs,
+			dependencies:      deps[i],
+			coinbase:          coinbase,
+			// The block hash lookup of a block context isn't safe for concurrent use
+			blockContext:      NewEVMBlockContext(header, chain, nil),
+			intermediateRoots: intermediateRoots,
+		})
+	}
+
+	result, err := blockstm.ExecuteParallel(tasks, false, metadata, numProcs, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks {
+		if !task.(*ExecutionTask).shouldRerunWithoutFeeDelay {
+			continue
+		}
+
+		shouldDelayFeeCal = false
+		final = statedb.Copy()
+
+		if roots {
+			*intermediateRoots = nil
+		}
+
+		for _, t := range tasks {
+			t.(*ExecutionTask).finalStateDB = final
+		}
+
+		if result, err = blockstm.ExecuteParallel(tasks, false, metadata, numProcs, ctx); err != nil {
+			return nil, err
+		}
+
+		break
+	}
+
+	prestates := &ParallelPrestates{
+		config:      config,
+		blockNumber: blockNumber,
+		base:        statedb.Copy(),
+		final:       final,
+		mvh:         result.MVHashMap,
+		delayedFees: shouldDelayFeeCal,
+		coinbase:    coinbase,
+	}
+
+	if roots {
+		prestates.roots = *intermediateRoots
+	}
+
+	if len(tasks) > 0 && config.IsLondon(blockNumber) {
+		prestates.burntContract = tasks[0].(*ExecutionTask).result.BurntContractAddress
+	}
+
+	if shouldDelayFeeCal {
+		tip, burn := new(uint256.Int), new(uint256.Int)
+
+		for _, task := range tasks {
+			task := task.(*ExecutionTask)
+
+			prestates.tips = append(prestates.tips, tip.Clone())
+			prestates.burns = append(prestates.burns, burn.Clone())
+
+			tip.Add(tip, cmath.BigIntToUint256Int(task.result.FeeTipped))
+
+			if config.IsLondon(blockNumber) {
+				burn.Add(burn, cmath.BigIntToUint256Int(task.result.FeeBurnt))
+			}
+		}
+	}
+
+	return prestates, nil
+}
+
+// StateAt returns the state the transaction with the given index was executed on. The
+// returned state must only be used to re-execute the transaction, as it reads the writes
+// of the previous transactions from the multi-version hash map.
+func (p *ParallelPrestates) StateAt(index int) *state.StateDB {
+	statedb := p.base.Copy()
+	statedb.SetMVHashmap(p.mvh)
+	statedb.SetTxContext(common.Hash{}, index)
+
+	if p.delayedFees {
+		if index < len(p.tips) {
+			if !p.tips[index].IsZero() {
+				statedb.AddBalance(p.coinbase, p.tips[index], tracing.BalanceChangeTransfer)
+			}
+
+			if !p.burns[index].IsZero() {
+				statedb.AddBalance(p.burntContract, p.burns[index], tracing.BalanceChangeTransfer)
+			}
+		}
+
+		return statedb
+	}
+
+	for _, recipient := range []common.Address{p.coinbase, p.burntContract} {
+		if recipient == (common.Address{}) {
+			continue
+		}
+
+		if balance := statedb.GetBalance(recipient); !balance.IsZero() {
+			statedb.SetBalance(recipient, balance, tracing.BalanceChangeUnspecified)
+		}
+	}
+
+	return statedb
+}
+
+// FinalState returns a copy of the state after the last transaction of the block
+func (p *ParallelPrestates) FinalState() *state.StateDB {
+	return p.final.Copy()
+}
+
+// IntermediateRoot returns the state root after the transaction with the given index, if
+// intermediate roots were computed.
+func (p *ParallelPrestates) IntermediateRoot(index int) common.Hash {
+	if index >= len(p.roots) {
+		return common.Hash{}
+	}
+
+	return p.roots[index]
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"math"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// reexecRateWeight is the weight of the re-execution rate of the latest block in the
+// moving average of the re-execution rate
+const reexecRateWeight = 0.1
+
+var (
+	parallelWorkersGauge        = metrics.NewRegisteredGauge("chain/parallel/workers", nil)
+	parallelPredictionGauge     = metrics.NewRegisteredGaugeFloat64("chain/parallel/prediction", nil)
+	parallelSerialFallbackMeter = metrics.NewRegisteredMeter("chain/parallel/serialfallback", nil)
+)
+
+// parallelScheduler picks the number of speculative workers used to execute each block in
+// parallel from the parallelism predicted for the block. Blocks whose predicted parallelism
+// is below the threshold are executed by the serial state processor only.
+type parallelScheduler struct {
+	maxProcs       int     // Maximum number of speculative workers
+	minParallelism float64 // Predicted parallelism below which blocks are executed serially
+
+	reexecRate float64 // Moving average of the re-executions per transaction of the recent blocks
+	lock       sync.Mutex
+}
+
+func newParallelScheduler(maxProcs int, minParallelism float64) *parallelScheduler {
+	return &parallelScheduler{
+		maxProcs:       maxProcs,
+		minParallelism: minParallelism,
+	}
+}
+
+// workers returns the number of speculative workers to execute the block with, or 0 if the
+// block is expected to be executed faster by the serial state processor.
+func (s *parallelScheduler) workers(block *types.Block) int {
+	parallelism := s.predict(block.GetTxDependency(), len(block.Transactions()))
+	parallelPredictionGauge.Update(parallelism)
+
+	if parallelism < s.minParallelism {
+		return 0
+	}
+
+	workers := int(math.Ceil(parallelism))
+	if workers > s.maxProcs {
+		workers = s.maxProcs
+	}
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	return workers
+}
+
+// predict returns the predicted parallelism of a block of n transactions, i.e. the speed-up
+// of an ideal parallel execution over a serial one. It's derived from the longest chain of
+// dependencies declared by the producer if the block embeds valid dependency metadata, and
+// from the re-execution rate of the recent blocks otherwise.
+func (s *parallelScheduler) predict(txDependency [][]uint64, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+
+	if deps := GetDeps(txDependency); txDependency != nil && len(txDependency) == n && VerifyDeps(deps) {
+		return float64(n) / float64(dependencyDepth(deps, n))
+	}
+
+	s.lock.Lock()
+	conflicts := math.Min(s.reexecRate, 1)
+	s.lock.Unlock()
+
+	// Amdahl's law, assuming conflicting transactions are executed serially
+	return 1 / (conflicts + (1-conflicts)/float64(n))
+}
+
+// observe updates the re-execution rate with the number of executions needed to execute a
+// block of n transactions in parallel
+func (s *parallelScheduler) observe(n int, executions int) {
+	if n == 0 {
+		return
+	}
+
+	rate := math.Max(float64(executions-n)/float64(n), 0)
+
+	s.lock.Lock()
+	s.reexecRate = (1-reexecRateWeight)*s.reexecRate + reexecRateWeight*rate
+	s.lock.Unlock()
+}
+
+// skip decays the re-execution rate for a block executed serially, so that parallel
+// execution is attempted again eventually.
+func (s *parallelScheduler) skip() {
+	parallelSerialFallbackMeter.Mark(1)
+
+	s.lock.Lock()
+	s.reexecRate *= 1 - reexecRateWeight
+	s.lock.Unlock()
+}
+
+// dependencyDepth returns the number of transactions of the longest chain of dependencies
+// among the n transactions
+func dependencyDepth(deps map[int][]int, n int) int {
+	depth := make([]int, n)
+	maxDepth := 0
+
+	for i := 0; i < n; i++ {
+		depth[i] = 1
+
+		for _, dep := range deps[i] {
+			if dep >= 0 && dep < i && depth[dep]+1 > depth[i] {
+				depth[i] = depth[dep] + 1
+			}
+		}
+
+		if depth[i] > maxDepth {
+			maxDepth = depth[i]
+		}
+	}
+
+	return maxDepth
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestParallelSchedulerPredict(t *testing.T) {
+	t.Parallel()
+
+	scheduler := newParallelScheduler(8, 2)
+
+	require.Zero(t, scheduler.predict(nil, 0))
+
+	// Without metadata nor conflicts every transaction is expected to run in parallel
+	require.InDelta(t, 10, scheduler.predict(nil, 10), 1e-9)
+
+	// The longest chain of declared dependencies is 0 -> 1 -> 3
+	require.InDelta(t, 4.0/3, scheduler.predict([][]uint64{{}, {0}, {}, {1}}, 4), 1e-9)
+
+	// Malformed metadata is ignored
+	require.InDelta(t, 4, scheduler.predict([][]uint64{{}, {3}, {}, {}}, 4), 1e-9)
+
+	// Every transaction was executed twice, so the latest block counts for a tenth of the conflicts
+	scheduler.observe(10, 20)
+	require.InDelta(t, 0.1, scheduler.reexecRate, 1e-9)
+	require.InDelta(t, 1/(0.1+0.9/10), scheduler.predict(nil, 10), 1e-9)
+
+	scheduler.skip()
+	require.InDelta(t, 0.09, scheduler.reexecRate, 1e-9)
+}
+
+func TestParallelSchedulerWorkers(t *testing.T) {
+	t.Parallel()
+
+	scheduler := newParallelScheduler(8, 2)
+
+	block := func(n int) *types.Block {
+		txs := make([]*types.Transaction, n)
+		for i := range txs {
+			txs[i] = types.NewTx(&types.LegacyTx{Nonce: uint64(i), GasPrice: big.NewInt(1)})
+		}
+
+		return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)}).WithBody(types.Body{Transactions: txs})
+	}
+
+	// Tiny blocks are executed serially
+	require.Zero(t, scheduler.workers(block(0)))
+	require.Zero(t, scheduler.workers(block(1)))
+
+	require.Equal(t, 3, scheduler.workers(block(3)))
+	require.Equal(t, 8, scheduler.workers(block(100)))
+
+	// Highly contended blocks are executed serially as well
+	scheduler.reexecRate = 0.9
+	require.Zero(t, scheduler.workers(block(100)))
+}
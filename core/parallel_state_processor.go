@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"runtime"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -41,6 +42,8 @@ type ParallelEVMConfig struct {
 	Enable               bool
 	SpeculativeProcesses int
 	Enforce              bool
+	Adaptive             bool    // Pick the number of speculative processes per block
+	MinParallelism       float64 // Predicted parallelism below which blocks are executed serially when adaptive
 }
 
 // StateProcessor is a basic Processor, which takes care of transitioning
@@ -92,6 +95,9 @@ type ExecutionTask struct {
 	dependencies []int
 	coinbase     common.Address
 	blockContext vm.BlockContext
+
+	// Intermediate state roots after each settled transaction, only computed if not nil
+	intermediateRoots *[]common.Hash
 }
 
 func (task *ExecutionTask) Execute(mvh *blockstm.MVHashMap, incarnation int) (err error) {
@@ -225,6 +231,10 @@ func (task *ExecutionTask) Settle() {
 		root = task.finalStateDB.IntermediateRoot(task.config.IsEIP158(task.blockNumber)).Bytes()
 	}
 
+	if task.intermediateRoots != nil {
+		*task.intermediateRoots = append(*task.intermediateRoots, task.finalStateDB.IntermediateRoot(task.config.IsEIP158(task.blockNumber)))
+	}
+
 	*task.totalUsedGas += task.result.UsedGas
 
 	// Create a new receipt for the transaction, storing the intermediate root and gas used
@@ -264,8 +274,29 @@ var parallelizabilityTimer = metrics.NewRegisteredTimer("block/parallelizability
 // Process returns the receipts and logs accumulated during the process and
 // returns the amount of gas that was used in the process. If any of the
 // transactions failed to execute due to insufficient gas it will return an error.
-// nolint:gocognit
 func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg vm.Config, interruptCtx context.Context) (*ProcessResult, error) {
+	res, _, err := p.process(block, statedb, cfg, interruptCtx, 0, false)
+	return res, err
+}
+
+// ProcessWithWorkers processes the block like Process with the given number of speculative
+// workers, as picked for the block by the parallel scheduler of the chain. The configured
+// number of workers is used if it's 0.
+func (p *ParallelStateProcessor) ProcessWithWorkers(block *types.Block, statedb *state.StateDB, cfg vm.Config, interruptCtx context.Context, workers int) (*ProcessResult, error) {
+	res, _, err := p.process(block, statedb, cfg, interruptCtx, workers, false)
+	return res, err
+}
+
+// ProcessWithProfile processes the block like Process with profiling enabled, and
+// additionally returns the outcome of the parallel execution of the transactions,
+// i.e. the dependencies observed between transactions, the execution statistics,
+// the number of incarnations of each transaction and why they were aborted.
+func (p *ParallelStateProcessor) ProcessWithProfile(block *types.Block, statedb *state.StateDB, cfg vm.Config, interruptCtx context.Context) (*ProcessResult, *blockstm.ParallelExecutionResult, error) {
+	return p.process(block, statedb, cfg, interruptCtx, 0, true)
+}
+
+// nolint:gocognit
+func (p *ParallelStateProcessor) process(block *types.Block, statedb *state.StateDB, cfg vm.Config, interruptCtx context.Context, workers int, profile bool) (*ProcessResult, *blockstm.ParallelExecutionResult, error) {
 	var (
 		receipts    types.Receipts
 		header      = block.Header()
@@ -293,6 +324,7 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 	coinbase, _ := p.bc.Engine().Author(header)
 
 	blockTxDependency := block.GetTxDependency()
+	declaredTxDependency := blockTxDependency
 
 	deps := GetDeps(blockTxDependency)
 
@@ -322,7 +354,7 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 		msg, err := TransactionToMessage(tx, types.MakeSigner(p.config, header.Number, header.Time), header.BaseFee)
 		if err != nil {
 			log.Error("error creating message", "err", err)
-			return nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+			return nil, nil, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
 
 		cleansdb := statedb.Copy()
@@ -359,21 +391,17 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 
 	backupStateDB := statedb.Copy()
 
-	profile := false
-	result, err := blockstm.ExecuteParallel(tasks, profile, metadata, p.bc.parallelSpeculativeProcesses, interruptCtx)
-
-	if err == nil && profile && result.Deps != nil {
-		_, weight := result.Deps.LongestPath(*result.Stats)
-
-		serialWeight := uint64(0)
-
-		for i := 0; i < len(result.Deps.GetVertices()); i++ {
-			serialWeight += (*result.Stats)[i].End - (*result.Stats)[i].Start
-		}
+	numProcs := p.bc.parallelSpeculativeProcesses
+	if workers > 0 {
+		numProcs = workers
+	}
 
-		parallelizabilityTimer.Update(time.Duration(serialWeight * 100 / weight))
+	if profile && numProcs <= 0 {
+		numProcs = runtime.NumCPU()
 	}
 
+	result, err := blockstm.ExecuteParallel(tasks, profile, metadata, numProcs, interruptCtx)
+
 	for _, task := range tasks {
 		task := task.(*ExecutionTask)
 		if task.shouldRerunWithoutFeeDelay {
@@ -394,14 +422,43 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 				t.totalUsedGas = usedGas
 			}
 
-			_, err = blockstm.ExecuteParallel(tasks, false, metadata, p.bc.parallelSpeculativeProcesses, interruptCtx)
+			result, err = blockstm.ExecuteParallel(tasks, profile, metadata, numProcs, interruptCtx)
 
 			break
 		}
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if p.bc.parallelScheduler != nil && !profile {
+		p.bc.parallelScheduler.observe(len(tasks), result.Executions)
+	}
+
+	// Check the dependency metadata of the producer off the import path, once the block is written
+	if !profile && len(tasks) > 0 {
+		p.bc.deferTxDependencyCheck(&txDependencyTask{
+			header:       header,
+			producer:     coinbase,
+			txDependency: declaredTxDependency,
+			txio:         result.TxIO,
+			n:            len(tasks),
+		})
+	}
+
+	if profile && result.Deps != nil {
+		_, weight := result.Deps.LongestPath(*result.Stats)
+
+		serialWeight := uint64(0)
+
+		for i := 0; i < len(result.Deps.GetVertices()); i++ {
+			serialWeight += (*result.Stats)[i].End - (*result.Stats)[i].Start
+		}
+
+		if weight > 0 {
+			parallelizabilityTimer.Update(time.Duration(serialWeight * 100 / weight))
+		}
 	}
 
 	// Polygon/bor: EIP-6110, EIP-7002, and EIP-7251 are not supported
@@ -415,7 +472,7 @@ func (p *ParallelStateProcessor) Process(block *types.Block, statedb *state.Stat
 		Requests: requests,
 		Logs:     allLogs,
 		GasUsed:  *usedGas,
-	}, nil
+	}, &result, nil
 }
 
 func GetDeps(txDependency [][]uint64) map[int][]int {
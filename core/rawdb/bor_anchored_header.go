@@ -0,0 +1,56 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// borAnchoredHeaderPrefix + block number (uint64 big endian) -> rlp encoded header
+//
+// Milestone sync downloads and verifies the headers backwards from the finalized block
+// it's anchored at, storing them until they're imported. They're kept apart from the
+// skeleton headers of the beacon sync, which are managed on their own.
+var borAnchoredHeaderPrefix = []byte("matic-anchored-header-")
+
+// borAnchoredHeaderKey = borAnchoredHeaderPrefix + block number (uint64 big endian)
+func borAnchoredHeaderKey(number uint64) []byte {
+	return append(borAnchoredHeaderPrefix, encodeBlockNumber(number)...)
+}
+
+// ReadAnchoredHeader retrieves the verified header with the given number downloaded by
+// the milestone sync. It returns nil if the header is not found.
+func ReadAnchoredHeader(db ethdb.KeyValueReader, number uint64) *types.Header {
+	data, _ := db.Get(borAnchoredHeaderKey(number))
+	if len(data) == 0 {
+		return nil
+	}
+
+	header := new(types.Header)
+	if err := rlp.DecodeBytes(data, header); err != nil {
+		log.Error("Invalid anchored header RLP", "number", number, "err", err)
+		return nil
+	}
+
+	return header
+}
+
+// WriteAnchoredHeader stores a verified header downloaded by the milestone sync.
+func WriteAnchoredHeader(db ethdb.KeyValueWriter, header *types.Header) {
+	data, err := rlp.EncodeToBytes(header)
+	if err != nil {
+		log.Crit("Failed to RLP encode header", "err", err)
+	}
+
+	if err := db.Put(borAnchoredHeaderKey(header.Number.Uint64()), data); err != nil {
+		log.Crit("Failed to store anchored header", "err", err)
+	}
+}
+
+// DeleteAnchoredHeader removes the verified header with the given number once imported.
+func DeleteAnchoredHeader(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Delete(borAnchoredHeaderKey(number)); err != nil {
+		log.Crit("Failed to delete anchored header", "err", err)
+	}
+}
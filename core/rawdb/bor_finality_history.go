@@ -0,0 +1,216 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	json "github.com/json-iterator/go"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// checkpointHistoryPrefix + end block (uint64 big endian) -> json encoded finality record
+	checkpointHistoryPrefix = []byte("matic-checkpoint-history-")
+
+	// checkpointNumberPrefix + checkpoint number (uint64 big endian) -> end block (uint64 big endian)
+	checkpointNumberPrefix = []byte("matic-checkpoint-number-")
+
+	// milestoneHistoryPrefix + end block (uint64 big endian) -> json encoded finality record
+	milestoneHistoryPrefix = []byte("matic-milestone-history-")
+
+	// milestoneIDPrefix + milestone id -> end block (uint64 big endian)
+	milestoneIDPrefix = []byte("matic-milestone-id-")
+
+	// lastCheckpointHistoryKey tracks the end block of the latest checkpoint in the history
+	lastCheckpointHistoryKey = []byte("LastCheckpointHistory")
+)
+
+// FinalityRecord is a checkpoint or a milestone processed by the node. Records are
+// kept for every processed checkpoint and milestone, unlike the last finality.
+type FinalityRecord struct {
+	Number     uint64         `json:"number,omitempty"` // Checkpoint number, 0 if unknown
+	ID         string         `json:"id,omitempty"`     // Milestone id
+	StartBlock uint64         `json:"startBlock"`
+	EndBlock   uint64         `json:"endBlock"`
+	Hash       common.Hash    `json:"hash"` // Root hash for checkpoints, end block hash for milestones
+	Proposer   common.Address `json:"proposer"`
+	Timestamp  uint64         `json:"timestamp"`         // Time of the checkpoint or milestone on heimdall
+	ReceivedAt uint64         `json:"receivedAt"`        // Time the node processed the checkpoint or milestone
+	Outcome    string         `json:"outcome,omitempty"` // Outcome of the milestone verification, empty for checkpoints
+}
+
+// Outcomes of the verification of a milestone against the local chain
+const (
+	MilestoneWhitelisted = "whitelisted" // The milestone was verified and whitelisted
+	MilestoneDeferred    = "deferred"    // The local chain is behind the milestone
+	MilestoneMismatch    = "mismatch"    // The local chain doesn't match the milestone
+	MilestoneFailed      = "failed"      // The milestone couldn't be verified
+)
+
+func checkpointHistoryKey(endBlock uint64) []byte {
+	return append(checkpointHistoryPrefix, encodeBlockNumber(endBlock)...)
+}
+
+func checkpointNumberKey(number uint64) []byte {
+	return append(checkpointNumberPrefix, encodeBlockNumber(number)...)
+}
+
+func milestoneHistoryKey(endBlock uint64) []byte {
+	return append(milestoneHistoryPrefix, encodeBlockNumber(endBlock)...)
+}
+
+func milestoneIDKey(id string) []byte {
+	return append(milestoneIDPrefix, []byte(id)...)
+}
+
+func readFinalityRecord(db ethdb.KeyValueReader, key []byte) *FinalityRecord {
+	data, _ := db.Get(key)
+	if len(data) == 0 {
+		return nil
+	}
+
+	var record FinalityRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		log.Error("Invalid finality record", "key", string(key), "err", err)
+		return nil
+	}
+
+	return &record
+}
+
+func writeFinalityRecord(db ethdb.KeyValueWriter, key []byte, record *FinalityRecord) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		log.Crit("Failed to encode finality record", "err", err)
+	}
+
+	if err := db.Put(key, data); err != nil {
+		log.Crit("Failed to store finality record", "err", err)
+	}
+}
+
+// findFinalityRecord returns the record of the given history containing the block,
+// i.e. the first record ending at or after the block which starts at or before it.
+func findFinalityRecord(db ethdb.Iteratee, prefix []byte, number uint64) *FinalityRecord {
+	it := db.NewIterator(prefix, encodeBlockNumber(number))
+	defer it.Release()
+
+	if !it.Next() {
+		return nil
+	}
+
+	var record FinalityRecord
+	if err := json.Unmarshal(it.Value(), &record); err != nil {
+		log.Error("Invalid finality record", "key", string(it.Key()), "err", err)
+		return nil
+	}
+
+	if record.StartBlock > number {
+		return nil
+	}
+
+	return &record
+}
+
+func readEndBlock(db ethdb.KeyValueReader, key []byte) *uint64 {
+	data, _ := db.Get(key)
+	if len(data) != 8 {
+		return nil
+	}
+
+	endBlock := binary.BigEndian.Uint64(data)
+
+	return &endBlock
+}
+
+// ReadCheckpointRecord retrieves the checkpoint with the given number from the history.
+func ReadCheckpointRecord(db ethdb.KeyValueReader, number uint64) *FinalityRecord {
+	endBlock := readEndBlock(db, checkpointNumberKey(number))
+	if endBlock == nil {
+		return nil
+	}
+
+	return readFinalityRecord(db, checkpointHistoryKey(*endBlock))
+}
+
+// ReadCheckpointRecordByEndBlock retrieves the checkpoint ending at the given block from the history.
+func ReadCheckpointRecordByEndBlock(db ethdb.KeyValueReader, endBlock uint64) *FinalityRecord {
+	return readFinalityRecord(db, checkpointHistoryKey(endBlock))
+}
+
+// ReadLastCheckpointRecord retrieves the latest checkpoint in the history.
+func ReadLastCheckpointRecord(db ethdb.KeyValueReader) *FinalityRecord {
+	endBlock := readEndBlock(db, lastCheckpointHistoryKey)
+	if endBlock == nil {
+		return nil
+	}
+
+	return readFinalityRecord(db, checkpointHistoryKey(*endBlock))
+}
+
+// FindCheckpointRecord retrieves the checkpoint containing the given block from the history.
+func FindCheckpointRecord(db ethdb.Iteratee, number uint64) *FinalityRecord {
+	return findFinalityRecord(db, checkpointHistoryPrefix, number)
+}
+
+// WriteCheckpointRecord stores the checkpoint in the history, indexed by its number if known.
+func WriteCheckpointRecord(db ethdb.KeyValueStore, record *FinalityRecord) {
+	batch := db.NewBatch()
+
+	writeFinalityRecord(batch, checkpointHistoryKey(record.EndBlock), record)
+
+	if record.Number != 0 {
+		if err := batch.Put(checkpointNumberKey(record.Number), encodeBlockNumber(record.EndBlock)); err != nil {
+			log.Crit("Failed to store checkpoint number", "err", err)
+		}
+	}
+
+	if last := readEndBlock(db, lastCheckpointHistoryKey); last == nil || record.EndBlock > *last {
+		if err := batch.Put(lastCheckpointHistoryKey, encodeBlockNumber(record.EndBlock)); err != nil {
+			log.Crit("Failed to store last checkpoint history", "err", err)
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to store checkpoint record", "err", err)
+	}
+}
+
+// ReadMilestoneRecord retrieves the milestone with the given id from the history.
+func ReadMilestoneRecord(db ethdb.KeyValueReader, id string) *FinalityRecord {
+	endBlock := readEndBlock(db, milestoneIDKey(id))
+	if endBlock == nil {
+		return nil
+	}
+
+	return readFinalityRecord(db, milestoneHistoryKey(*endBlock))
+}
+
+// ReadMilestoneRecordByEndBlock retrieves the milestone ending at the given block from the history.
+func ReadMilestoneRecordByEndBlock(db ethdb.KeyValueReader, endBlock uint64) *FinalityRecord {
+	return readFinalityRecord(db, milestoneHistoryKey(endBlock))
+}
+
+// FindMilestoneRecord retrieves the milestone containing the given block from the history.
+func FindMilestoneRecord(db ethdb.Iteratee, number uint64) *FinalityRecord {
+	return findFinalityRecord(db, milestoneHistoryPrefix, number)
+}
+
+// WriteMilestoneRecord stores the milestone in the history, indexed by its id if known.
+func WriteMilestoneRecord(db ethdb.KeyValueStore, record *FinalityRecord) {
+	batch := db.NewBatch()
+
+	writeFinalityRecord(batch, milestoneHistoryKey(record.EndBlock), record)
+
+	if record.ID != "" {
+		if err := batch.Put(milestoneIDKey(record.ID), encodeBlockNumber(record.EndBlock)); err != nil {
+			log.Crit("Failed to store milestone id", "err", err)
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to store milestone record", "err", err)
+	}
+}
@@ -0,0 +1,63 @@
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestFinalityHistory(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	for i, r := range []struct{ start, end uint64 }{{1, 256}, {257, 512}, {513, 768}} {
+		WriteCheckpointRecord(db, &FinalityRecord{
+			Number:     uint64(i + 1),
+			StartBlock: r.start,
+			EndBlock:   r.end,
+			Hash:       common.BigToHash(common.Big1),
+		})
+	}
+
+	WriteMilestoneRecord(db, &FinalityRecord{ID: "m1", StartBlock: 700, EndBlock: 712})
+	WriteMilestoneRecord(db, &FinalityRecord{ID: "m2", StartBlock: 713, EndBlock: 730})
+
+	if record := ReadCheckpointRecord(db, 2); record == nil || record.StartBlock != 257 || record.EndBlock != 512 {
+		t.Fatalf("invalid checkpoint 2: %+v", record)
+	}
+
+	if record := ReadCheckpointRecord(db, 4); record != nil {
+		t.Fatalf("unexpected checkpoint 4: %+v", record)
+	}
+
+	if record := ReadLastCheckpointRecord(db); record == nil || record.Number != 3 {
+		t.Fatalf("invalid last checkpoint: %+v", record)
+	}
+
+	if record := ReadMilestoneRecord(db, "m2"); record == nil || record.EndBlock != 730 {
+		t.Fatalf("invalid milestone m2: %+v", record)
+	}
+
+	for _, tc := range []struct {
+		number     uint64
+		checkpoint uint64 // 0 if not checkpointed
+		milestone  string // empty if not in a milestone
+	}{
+		{number: 1, checkpoint: 1},
+		{number: 256, checkpoint: 1},
+		{number: 600, checkpoint: 3},
+		{number: 712, checkpoint: 3, milestone: "m1"},
+		{number: 720, checkpoint: 3, milestone: "m2"},
+		{number: 769},
+		{number: 731, checkpoint: 3},
+	} {
+		checkpoint := FindCheckpointRecord(db, tc.number)
+		if tc.checkpoint == 0 && checkpoint != nil || tc.checkpoint != 0 && (checkpoint == nil || checkpoint.Number != tc.checkpoint) {
+			t.Errorf("block %d: invalid checkpoint %+v, want %d", tc.number, checkpoint, tc.checkpoint)
+		}
+
+		milestone := FindMilestoneRecord(db, tc.number)
+		if tc.milestone == "" && milestone != nil || tc.milestone != "" && (milestone == nil || milestone.ID != tc.milestone) {
+			t.Errorf("block %d: invalid milestone %+v, want %q", tc.number, milestone, tc.milestone)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	json "github.com/json-iterator/go"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// MaxFinalityIncidents is the number of latest finality incidents retained
+const MaxFinalityIncidents = 128
+
+var (
+	// finalityIncidentPrefix + incident id (uint64 big endian) -> json encoded finality incident
+	finalityIncidentPrefix = []byte("matic-bor-finality-incident-")
+
+	// lastFinalityIncidentKey tracks the id of the latest finality incident
+	lastFinalityIncidentKey = []byte("LastFinalityIncident")
+)
+
+// FinalityIncidentHeader is a header of one of the branches involved in a finality incident.
+type FinalityIncidentHeader struct {
+	Number     uint64         `json:"number"`
+	Hash       common.Hash    `json:"hash"`
+	ParentHash common.Hash    `json:"parentHash"`
+	Signer     common.Address `json:"signer"`
+	Peer       string         `json:"peer,omitempty"` // Peer which served the block, empty if unknown or produced locally
+}
+
+// FinalityIncident is a mismatch between the local chain and a milestone or a checkpoint,
+// recorded along with the evidence of the branch which was rewound because of it.
+type FinalityIncident struct {
+	ID         uint64      `json:"id"`
+	Time       uint64      `json:"time"`
+	Kind       string      `json:"kind"` // "milestone" or "checkpoint"
+	StartBlock uint64      `json:"startBlock"`
+	EndBlock   uint64      `json:"endBlock"`
+	Expected   common.Hash `json:"expected"` // End block hash for milestones, root hash for checkpoints
+	Local      common.Hash `json:"local"`    // Same hash computed on the local chain
+	Head       uint64      `json:"head"`     // Local head when the mismatch was detected
+	RewindTo   uint64      `json:"rewindTo"`
+
+	LocalHeaders     []*FinalityIncidentHeader `json:"localHeaders"`     // Local branch which was rewound
+	FinalizedHeaders []*FinalityIncidentHeader `json:"finalizedHeaders"` // Finalized branch, if known locally
+	Peers            []string                  `json:"peers"`            // Peers which served the local branch
+}
+
+// finalityIncidentKey = finalityIncidentPrefix + incident id (uint64 big endian)
+func finalityIncidentKey(id uint64) []byte {
+	return append(finalityIncidentPrefix, encodeBlockNumber(id)...)
+}
+
+// ReadLastFinalityIncidentID retrieves the id of the latest finality incident, 0 if none.
+func ReadLastFinalityIncidentID(db ethdb.KeyValueReader) uint64 {
+	data, _ := db.Get(lastFinalityIncidentKey)
+	if len(data) != 8 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(data)
+}
+
+// ReadFinalityIncident retrieves the finality incident with the given id.
+func ReadFinalityIncident(db ethdb.KeyValueReader, id uint64) *FinalityIncident {
+	data, _ := db.Get(finalityIncidentKey(id))
+	if len(data) == 0 {
+		return nil
+	}
+
+	var incident FinalityIncident
+	if err := json.Unmarshal(data, &incident); err != nil {
+		log.Error("Invalid finality incident", "id", id, "err", err)
+		return nil
+	}
+
+	return &incident
+}
+
+// ReadFinalityIncidents retrieves the given number of latest finality incidents, the
+// latest first.
+func ReadFinalityIncidents(db ethdb.KeyValueReader, count uint64) []*FinalityIncident {
+	incidents := make([]*FinalityIncident, 0)
+
+	for id := ReadLastFinalityIncidentID(db); id > 0 && uint64(len(incidents)) < count; id-- {
+		incident := ReadFinalityIncident(db, id)
+		if incident == nil {
+			break
+		}
+
+		incidents = append(incidents, incident)
+	}
+
+	return incidents
+}
+
+// WriteFinalityIncident stores a finality incident under the next incident id, which is
+// assigned to it, and removes the incidents beyond the retained ones. Concurrent writes
+// must be serialized by the caller, lest they are assigned the same id.
+func WriteFinalityIncident(db ethdb.KeyValueStore, incident *FinalityIncident) {
+	incident.ID = ReadLastFinalityIncidentID(db) + 1
+
+	data, err := json.Marshal(incident)
+	if err != nil {
+		log.Crit("Failed to encode finality incident", "err", err)
+	}
+
+	batch := db.NewBatch()
+
+	if err := batch.Put(finalityIncidentKey(incident.ID), data); err != nil {
+		log.Crit("Failed to store finality incident", "id", incident.ID, "err", err)
+	}
+
+	if err := batch.Put(lastFinalityIncidentKey, encodeBlockNumber(incident.ID)); err != nil {
+		log.Crit("Failed to store last finality incident id", "err", err)
+	}
+
+	if incident.ID > MaxFinalityIncidents {
+		if err := batch.Delete(finalityIncidentKey(incident.ID - MaxFinalityIncidents)); err != nil {
+			log.Crit("Failed to delete finality incident", "err", err)
+		}
+	}
+
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to store finality incident", "id", incident.ID, "err", err)
+	}
+}
@@ -0,0 +1,42 @@
+package rawdb
+
+import (
+	"testing"
+)
+
+func TestFinalityIncidents(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	if incidents := ReadFinalityIncidents(db, 10); len(incidents) != 0 {
+		t.Fatalf("have %d incidents, want none", len(incidents))
+	}
+
+	for i := uint64(1); i <= MaxFinalityIncidents+2; i++ {
+		incident := &FinalityIncident{Kind: "milestone", EndBlock: i * 16}
+		WriteFinalityIncident(db, incident)
+
+		if incident.ID != i {
+			t.Fatalf("incident %d: have id %d", i, incident.ID)
+		}
+	}
+
+	incidents := ReadFinalityIncidents(db, 3)
+	if len(incidents) != 3 {
+		t.Fatalf("have %d incidents, want 3", len(incidents))
+	}
+
+	for i, incident := range incidents {
+		if want := uint64(MaxFinalityIncidents + 2 - i); incident.ID != want || incident.EndBlock != want*16 {
+			t.Fatalf("incident %d: have id %d end %d, want id %d", i, incident.ID, incident.EndBlock, want)
+		}
+	}
+
+	// Only the latest incidents are retained
+	if incidents := ReadFinalityIncidents(db, MaxFinalityIncidents+2); len(incidents) != MaxFinalityIncidents {
+		t.Fatalf("have %d incidents, want %d", len(incidents), MaxFinalityIncidents)
+	}
+
+	if incident := ReadFinalityIncident(db, 2); incident != nil {
+		t.Fatalf("incident 2 not pruned: %+v", incident)
+	}
+}
@@ -0,0 +1,84 @@
+package rawdb
+
+import (
+	"time"
+
+	json "github.com/json-iterator/go"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// borPeerReputationPrefix + peer id -> json encoded peer reputation
+var borPeerReputationPrefix = []byte("matic-bor-peer-reputation-")
+
+// PeerReputation is the record of the chains served by a peer which conflicted with the
+// whitelisted milestones and checkpoints.
+type PeerReputation struct {
+	ID          string    `json:"id"`
+	Failures    uint64    `json:"failures"` // Conflicting chains served since the failures were last forgiven
+	LastFailure time.Time `json:"lastFailure"`
+	LastReason  string    `json:"lastReason"`
+}
+
+// borPeerReputationKey = borPeerReputationPrefix + peer id
+func borPeerReputationKey(id string) []byte {
+	return append(append([]byte{}, borPeerReputationPrefix...), id...)
+}
+
+// ReadPeerReputation retrieves the reputation of the peer with the given id. It returns
+// nil if the peer never served a conflicting chain.
+func ReadPeerReputation(db ethdb.KeyValueReader, id string) *PeerReputation {
+	data, _ := db.Get(borPeerReputationKey(id))
+	if len(data) == 0 {
+		return nil
+	}
+
+	var reputation PeerReputation
+	if err := json.Unmarshal(data, &reputation); err != nil {
+		log.Error("Invalid peer reputation", "id", id, "err", err)
+		return nil
+	}
+
+	return &reputation
+}
+
+// ReadPeerReputations retrieves the reputations of all the peers which served a
+// conflicting chain.
+func ReadPeerReputations(db ethdb.Iteratee) []*PeerReputation {
+	it := db.NewIterator(borPeerReputationPrefix, nil)
+	defer it.Release()
+
+	var reputations []*PeerReputation
+
+	for it.Next() {
+		var reputation PeerReputation
+		if err := json.Unmarshal(it.Value(), &reputation); err != nil {
+			log.Error("Invalid peer reputation", "key", string(it.Key()), "err", err)
+			continue
+		}
+
+		reputations = append(reputations, &reputation)
+	}
+
+	return reputations
+}
+
+// WritePeerReputation stores the reputation of a peer.
+func WritePeerReputation(db ethdb.KeyValueWriter, reputation *PeerReputation) {
+	data, err := json.Marshal(reputation)
+	if err != nil {
+		log.Crit("Failed to encode peer reputation", "err", err)
+	}
+
+	if err := db.Put(borPeerReputationKey(reputation.ID), data); err != nil {
+		log.Crit("Failed to store peer reputation", "id", reputation.ID, "err", err)
+	}
+}
+
+// DeletePeerReputation removes the reputation of the peer with the given id.
+func DeletePeerReputation(db ethdb.KeyValueWriter, id string) {
+	if err := db.Delete(borPeerReputationKey(id)); err != nil {
+		log.Crit("Failed to delete peer reputation", "id", id, "err", err)
+	}
+}
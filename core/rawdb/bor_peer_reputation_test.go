@@ -0,0 +1,34 @@
+package rawdb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeerReputations(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	if reputation := ReadPeerReputation(db, "a"); reputation != nil {
+		t.Fatalf("unexpected reputation: %+v", reputation)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	WritePeerReputation(db, &PeerReputation{ID: "a", Failures: 1, LastFailure: now, LastReason: "mismatch error"})
+	WritePeerReputation(db, &PeerReputation{ID: "b", Failures: 3, LastFailure: now})
+
+	reputation := ReadPeerReputation(db, "a")
+	if reputation == nil || reputation.Failures != 1 || !reputation.LastFailure.Equal(now) || reputation.LastReason != "mismatch error" {
+		t.Fatalf("invalid reputation: %+v", reputation)
+	}
+
+	if reputations := ReadPeerReputations(db); len(reputations) != 2 {
+		t.Fatalf("invalid reputations: have %d, want 2", len(reputations))
+	}
+
+	DeletePeerReputation(db, "a")
+
+	if reputations := ReadPeerReputations(db); len(reputations) != 1 || reputations[0].ID != "b" {
+		t.Fatalf("invalid reputations after deletion: %+v", reputations)
+	}
+}
@@ -0,0 +1,52 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// borProducerPrefix + block number (uint64 big endian) + block hash -> rlp encoded producer record
+var borProducerPrefix = []byte("matic-bor-producer-")
+
+// borProducerKey = borProducerPrefix + block number (uint64 big endian) + block hash
+func borProducerKey(number uint64, hash common.Hash) []byte {
+	return append(append(borProducerPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// ReadBorProducerRecord retrieves the encoded producer record of the given block. The
+// record is stored encoded so that rawdb doesn't need to depend on consensus types. It
+// returns nil if the record is not found.
+func ReadBorProducerRecord(db ethdb.KeyValueReader, number uint64, hash common.Hash) []byte {
+	data, _ := db.Get(borProducerKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+
+	return data
+}
+
+// WriteBorProducerRecord stores the encoded producer record of the given block. Records
+// are keyed by block hash as well, hence the ones of reorged blocks are never mistaken
+// for the canonical ones.
+func WriteBorProducerRecord(db ethdb.KeyValueWriter, number uint64, hash common.Hash, data []byte) {
+	if err := db.Put(borProducerKey(number, hash), data); err != nil {
+		log.Crit("Failed to store bor producer record", "number", number, "hash", hash, "err", err)
+	}
+}
+
+// HasBorProducerRecord verifies the existence of the producer record of the given block.
+func HasBorProducerRecord(db ethdb.KeyValueReader, number uint64, hash common.Hash) bool {
+	if has, err := db.Has(borProducerKey(number, hash)); !has || err != nil {
+		return false
+	}
+
+	return true
+}
+
+// DeleteBorProducerRecord removes the producer record of the given block.
+func DeleteBorProducerRecord(db ethdb.KeyValueWriter, number uint64, hash common.Hash) {
+	if err := db.Delete(borProducerKey(number, hash)); err != nil {
+		log.Crit("Failed to delete bor producer record", "number", number, "hash", hash, "err", err)
+	}
+}
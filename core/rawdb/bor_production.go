@@ -0,0 +1,106 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"time"
+
+	json "github.com/json-iterator/go"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// borProductionPrefix + block number (uint64 big endian) -> json encoded block production timeline
+var borProductionPrefix = []byte("matic-bor-production-")
+
+// BlockProductionEvent is a step of the production of a block by the local validator.
+type BlockProductionEvent struct {
+	Name     string        `json:"name"`
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration,omitempty"` // Time spent in the step, or the delay it computed
+	Detail   string        `json:"detail,omitempty"`
+}
+
+// RejectedTransaction is a transaction which couldn't be included in a produced block.
+type RejectedTransaction struct {
+	Hash   common.Hash `json:"hash"`
+	Reason string      `json:"reason"`
+}
+
+// BlockProductionTimeline is the timeline of the production of a block by the local
+// validator, from the start of the block building until the block is broadcast.
+type BlockProductionTimeline struct {
+	Number     uint64                  `json:"number"`
+	Hash       common.Hash             `json:"hash"`       // Hash of the sealed block, empty if it wasn't sealed
+	Sealed     bool                    `json:"sealed"`     // Whether the block was sealed and broadcast
+	HeaderTime uint64                  `json:"headerTime"` // Timestamp of the block header
+	Tried      uint64                  `json:"tried"`      // Transactions executed while building the block
+	Included   uint64                  `json:"included"`   // Transactions included in the sealed block
+	Rejected   []*RejectedTransaction  `json:"rejected"`
+	Omitted    uint64                  `json:"omitted"` // Rejected transactions omitted once the limit was reached
+	Events     []*BlockProductionEvent `json:"events"`
+}
+
+// borProductionKey = borProductionPrefix + block number (uint64 big endian)
+func borProductionKey(number uint64) []byte {
+	return append(borProductionPrefix, encodeBlockNumber(number)...)
+}
+
+// ReadBlockProductionTimeline retrieves the production timeline of the block with the
+// given number. It returns nil if the block wasn't produced by the local validator.
+func ReadBlockProductionTimeline(db ethdb.KeyValueReader, number uint64) *BlockProductionTimeline {
+	data, _ := db.Get(borProductionKey(number))
+	if len(data) == 0 {
+		return nil
+	}
+
+	var timeline BlockProductionTimeline
+	if err := json.Unmarshal(data, &timeline); err != nil {
+		log.Error("Invalid block production timeline", "number", number, "err", err)
+		return nil
+	}
+
+	return &timeline
+}
+
+// WriteBlockProductionTimeline stores the production timeline of a block, replacing the
+// timeline of any block previously produced at the same height.
+func WriteBlockProductionTimeline(db ethdb.KeyValueWriter, timeline *BlockProductionTimeline) {
+	data, err := json.Marshal(timeline)
+	if err != nil {
+		log.Crit("Failed to encode block production timeline", "err", err)
+	}
+
+	if err := db.Put(borProductionKey(timeline.Number), data); err != nil {
+		log.Crit("Failed to store block production timeline", "number", timeline.Number, "err", err)
+	}
+}
+
+// ReadBlockProductionTimelineNumbers retrieves the numbers of all the blocks whose
+// production timeline is stored, in ascending order.
+func ReadBlockProductionTimelineNumbers(db ethdb.Iteratee) []uint64 {
+	it := db.NewIterator(borProductionPrefix, nil)
+	defer it.Release()
+
+	var numbers []uint64
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(borProductionPrefix)+8 {
+			continue
+		}
+
+		numbers = append(numbers, binary.BigEndian.Uint64(key[len(borProductionPrefix):]))
+	}
+
+	return numbers
+}
+
+// DeleteBlockProductionTimeline removes the production timeline of the block with the
+// given number.
+func DeleteBlockProductionTimeline(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Delete(borProductionKey(number)); err != nil {
+		log.Crit("Failed to delete block production timeline", "number", number, "err", err)
+	}
+}
@@ -0,0 +1,33 @@
+package rawdb
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBlockProductionTimelineNumbers(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	for _, number := range []uint64{300, 1, 5, 2} {
+		WriteBlockProductionTimeline(db, &BlockProductionTimeline{Number: number})
+	}
+
+	if numbers := ReadBlockProductionTimelineNumbers(db); !reflect.DeepEqual(numbers, []uint64{1, 2, 5, 300}) {
+		t.Fatalf("numbers mismatch: have %v, want %v", numbers, []uint64{1, 2, 5, 300})
+	}
+
+	DeleteBlockProductionTimeline(db, 2)
+
+	for _, tc := range []struct {
+		number uint64
+		exists bool
+	}{{1, true}, {2, false}, {5, true}, {300, true}} {
+		if timeline := ReadBlockProductionTimeline(db, tc.number); (timeline != nil) != tc.exists {
+			t.Fatalf("timeline %d: have %+v, want exists %v", tc.number, timeline, tc.exists)
+		}
+	}
+
+	if numbers := ReadBlockProductionTimelineNumbers(db); !reflect.DeepEqual(numbers, []uint64{1, 5, 300}) {
+		t.Fatalf("numbers mismatch: have %v, want %v", numbers, []uint64{1, 5, 300})
+	}
+}
@@ -0,0 +1,63 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// borRootHashLeafPrefix + block number (uint64 big endian) -> block hash + leaf hash
+	borRootHashLeafPrefix = []byte("matic-bor-roothash-leaf-")
+
+	// borRootHashSubtreePrefix + level + first block number (uint64 big endian) -> last block hash + subtree root
+	borRootHashSubtreePrefix = []byte("matic-bor-roothash-subtree-")
+)
+
+func borRootHashLeafKey(number uint64) []byte {
+	return append(borRootHashLeafPrefix, encodeBlockNumber(number)...)
+}
+
+func borRootHashSubtreeKey(level uint8, first uint64) []byte {
+	return append(append(borRootHashSubtreePrefix, level), encodeBlockNumber(first)...)
+}
+
+// readHashPair decodes a value made of two hashes
+func readHashPair(db ethdb.KeyValueReader, key []byte) (common.Hash, common.Hash, bool) {
+	data, _ := db.Get(key)
+	if len(data) != 2*common.HashLength {
+		return common.Hash{}, common.Hash{}, false
+	}
+
+	return common.BytesToHash(data[:common.HashLength]), common.BytesToHash(data[common.HashLength:]), true
+}
+
+// ReadBorRootHashLeaf retrieves the root hash leaf of the block with the given number
+// along with the hash of the block it was computed for.
+func ReadBorRootHashLeaf(db ethdb.KeyValueReader, number uint64) (blockHash common.Hash, leaf common.Hash, ok bool) {
+	return readHashPair(db, borRootHashLeafKey(number))
+}
+
+// WriteBorRootHashLeaf stores the root hash leaf of the block with the given number and hash.
+// Leaves are keyed by number, hence readers have to check the block hash against the canonical one.
+func WriteBorRootHashLeaf(db ethdb.KeyValueWriter, number uint64, blockHash common.Hash, leaf common.Hash) {
+	if err := db.Put(borRootHashLeafKey(number), append(blockHash.Bytes(), leaf.Bytes()...)); err != nil {
+		log.Crit("Failed to store root hash leaf", "number", number, "err", err)
+	}
+}
+
+// ReadBorRootHashSubtree retrieves the root of the complete root hash subtree of the given level
+// starting at the given block, i.e. over the blocks from first to first+1<<level-1, along with
+// the hash of the last block of the subtree.
+func ReadBorRootHashSubtree(db ethdb.KeyValueReader, level uint8, first uint64) (lastBlockHash common.Hash, root common.Hash, ok bool) {
+	return readHashPair(db, borRootHashSubtreeKey(level, first))
+}
+
+// WriteBorRootHashSubtree stores the root of the complete root hash subtree of the given level
+// starting at the given block. As blocks are chained, the hash of the last block of the subtree
+// identifies all of its blocks, hence readers have to check it against the canonical one.
+func WriteBorRootHashSubtree(db ethdb.KeyValueWriter, level uint8, first uint64, lastBlockHash common.Hash, root common.Hash) {
+	if err := db.Put(borRootHashSubtreeKey(level, first), append(lastBlockHash.Bytes(), root.Bytes()...)); err != nil {
+		log.Crit("Failed to store root hash subtree", "level", level, "first", first, "err", err)
+	}
+}
@@ -0,0 +1,69 @@
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// borSpanPrefix + span id (uint64 big endian) -> json encoded heimdall span
+	borSpanPrefix = []byte("matic-bor-span-")
+
+	// lastBorSpanIdKey tracks the highest span id persisted to the database
+	lastBorSpanIdKey = []byte("LastBorSpanId")
+)
+
+// borSpanKey = borSpanPrefix + span id (uint64 big endian)
+func borSpanKey(id uint64) []byte {
+	return append(borSpanPrefix, encodeBlockNumber(id)...)
+}
+
+// ReadBorSpan retrieves the encoded span corresponding to the given id. The span
+// is stored in its json form as served by heimdall so that rawdb doesn't need to
+// depend on heimdall types. It returns nil if the span is not found.
+func ReadBorSpan(db ethdb.KeyValueReader, id uint64) []byte {
+	data, _ := db.Get(borSpanKey(id))
+	if len(data) == 0 {
+		return nil
+	}
+
+	return data
+}
+
+// WriteBorSpan stores the encoded span against the given id. Spans are immutable
+// once committed on heimdall, hence they're never deleted (e.g. during reorgs).
+func WriteBorSpan(db ethdb.KeyValueWriter, id uint64, data []byte) {
+	if err := db.Put(borSpanKey(id), data); err != nil {
+		log.Crit("Failed to store bor span", "id", id, "err", err)
+	}
+}
+
+// HasBorSpan verifies the existence of a span corresponding to the given id.
+func HasBorSpan(db ethdb.KeyValueReader, id uint64) bool {
+	if has, err := db.Has(borSpanKey(id)); !has || err != nil {
+		return false
+	}
+
+	return true
+}
+
+// ReadLastBorSpanId retrieves the id of the latest span persisted to the database.
+func ReadLastBorSpanId(db ethdb.KeyValueReader) *uint64 {
+	data, _ := db.Get(lastBorSpanIdKey)
+	if len(data) != 8 {
+		return nil
+	}
+
+	id := binary.BigEndian.Uint64(data)
+
+	return &id
+}
+
+// WriteLastBorSpanId stores the id of the latest span persisted to the database.
+func WriteLastBorSpanId(db ethdb.KeyValueWriter, id uint64) {
+	if err := db.Put(lastBorSpanIdKey, encodeBlockNumber(id)); err != nil {
+		log.Crit("Failed to store last bor span id", "err", err)
+	}
+}
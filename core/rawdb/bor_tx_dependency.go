@@ -0,0 +1,60 @@
+package rawdb
+
+import (
+	json "github.com/json-iterator/go"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// borTxDependencyPrefix + block number (uint64 big endian) + block hash -> json encoded tx dependency record
+var borTxDependencyPrefix = []byte("matic-bor-txdependency-")
+
+// TxDependencyRecord is the outcome of the comparison of the transaction dependency metadata
+// embedded in a block by its producer with the dependencies detected while importing it.
+type TxDependencyRecord struct {
+	Producer     common.Address `json:"producer"`
+	Transactions uint64         `json:"transactions"`
+	Present      bool           `json:"present"`  // Whether the block embeds dependency metadata
+	Valid        bool           `json:"valid"`    // Whether the metadata is well formed
+	Declared     uint64         `json:"declared"` // Dependencies declared by the metadata
+	Detected     uint64         `json:"detected"` // Dependencies detected during the execution
+	Missing      uint64         `json:"missing"`  // Detected dependencies not implied by the metadata
+	Extra        uint64         `json:"extra"`    // Declared dependencies not implied by the detected ones
+}
+
+// borTxDependencyKey = borTxDependencyPrefix + block number (uint64 big endian) + block hash
+func borTxDependencyKey(number uint64, hash common.Hash) []byte {
+	return append(append(borTxDependencyPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// ReadTxDependencyRecord retrieves the tx dependency record of the given block. It returns
+// nil if the block wasn't checked.
+func ReadTxDependencyRecord(db ethdb.KeyValueReader, number uint64, hash common.Hash) *TxDependencyRecord {
+	data, _ := db.Get(borTxDependencyKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+
+	var record TxDependencyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		log.Error("Invalid tx dependency record", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+
+	return &record
+}
+
+// WriteTxDependencyRecord stores the tx dependency record of the given block. Records are
+// keyed by block hash as well, hence they needn't be deleted during reorgs.
+func WriteTxDependencyRecord(db ethdb.KeyValueWriter, number uint64, hash common.Hash, record *TxDependencyRecord) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		log.Crit("Failed to encode tx dependency record", "err", err)
+	}
+
+	if err := db.Put(borTxDependencyKey(number, hash), data); err != nil {
+		log.Crit("Failed to store tx dependency record", "number", number, "hash", hash, "err", err)
+	}
+}
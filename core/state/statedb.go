@@ -1882,7 +1882,7 @@ func (s *StateDB) ValidateKnownAccounts(knownAccounts types.KnownAccounts) error
 			if trie != nil {
 				actualRootHash := trie.Hash()
 				if *v.Single != actualRootHash {
-					return fmt.Errorf("invalid root hash for: %v root hash: %v actual root hash: %v", k, v.Single, actualRootHash)
+					return &types.KnownAccountError{Address: k, Expected: *v.Single, Actual: actualRootHash}
 				}
 			} else {
 				return fmt.Errorf("Storage Trie is nil for: %v", k)
@@ -1891,7 +1891,7 @@ func (s *StateDB) ValidateKnownAccounts(knownAccounts types.KnownAccounts) error
 			for slot, value := range v.Storage {
 				actualValue := s.GetState(k, slot)
 				if value != actualValue {
-					return fmt.Errorf("invalid slot value at address: %v slot: %v value: %v actual value: %v", k, slot, value, actualValue)
+					return &types.KnownAccountError{Address: k, Slot: &slot, Expected: value, Actual: actualValue}
 				}
 			}
 		default:
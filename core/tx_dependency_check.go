@@ -0,0 +1,246 @@
+package core
+
+import (
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/blockstm"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	txDependencyAbsentMeter   = metrics.NewRegisteredMeter("chain/txdependency/absent", nil)
+	txDependencyInvalidMeter  = metrics.NewRegisteredMeter("chain/txdependency/invalid", nil)
+	txDependencyAccurateMeter = metrics.NewRegisteredMeter("chain/txdependency/accurate", nil)
+	txDependencyMissingMeter  = metrics.NewRegisteredMeter("chain/txdependency/missing", nil)
+	txDependencyExtraMeter    = metrics.NewRegisteredMeter("chain/txdependency/extra", nil)
+	txDependencyDroppedMeter  = metrics.NewRegisteredMeter("chain/txdependency/dropped", nil)
+)
+
+// txDependencyQueueSize is the number of imported blocks which can wait for the check of
+// their tx dependency metadata. The blocks imported while the queue is full aren't checked.
+// It also bounds the executed blocks waiting to be written, as the ones failing validation
+// never are.
+const txDependencyQueueSize = 64
+
+// TxDependencyEdge is a dependency of a transaction on a previous transaction of the block
+type TxDependencyEdge struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// TxDependencyCheck is the outcome of the comparison of the transaction dependency metadata
+// embedded in a block by its producer with the dependencies detected by blockstm
+type TxDependencyCheck struct {
+	Present  bool               `json:"present"`
+	Valid    bool               `json:"valid"` // Whether the metadata is well formed, otherwise it's ignored on import
+	Declared int                `json:"declared"`
+	Detected int                `json:"detected"`
+	Missing  []TxDependencyEdge `json:"missing"` // Detected dependencies not implied by the metadata
+	Extra    []TxDependencyEdge `json:"extra"`   // Dependencies of the metadata not implied by the detected ones
+}
+
+// DetectedDeps returns the sorted dependencies of every transaction as detected by blockstm
+func DetectedDeps(allDeps map[int]map[int]bool) map[int][]int {
+	deps := make(map[int][]int, len(allDeps))
+
+	for i, txDeps := range allDeps {
+		for dep := range txDeps {
+			deps[i] = append(deps[i], dep)
+		}
+
+		sort.Ints(deps[i])
+	}
+
+	return deps
+}
+
+// CheckTxDependency compares the dependency metadata of a block of n transactions with the
+// detected dependencies. Both are transitively reduced, hence a dependency is only missing
+// or extra if it isn't implied by the transitive closure of the other.
+func CheckTxDependency(txDependency [][]uint64, detected map[int][]int, n int) *TxDependencyCheck {
+	check := &TxDependencyCheck{
+		Present: txDependency != nil,
+		Missing: []TxDependencyEdge{},
+		Extra:   []TxDependencyEdge{},
+	}
+
+	for i := 0; i < n; i++ {
+		check.Detected += len(detected[i])
+	}
+
+	if !check.Present {
+		return check
+	}
+
+	declared := GetDeps(txDependency)
+
+	for _, deps := range declared {
+		check.Declared += len(deps)
+	}
+
+	// The parallel state processor ignores malformed metadata
+	check.Valid = VerifyDeps(declared) && len(txDependency) == n
+	if !check.Valid {
+		return check
+	}
+
+	declaredClosure := dependencyClosure(declared, n)
+	detectedClosure := dependencyClosure(detected, n)
+
+	for i := 0; i < n; i++ {
+		for _, dep := range detected[i] {
+			if !declaredClosure[i][dep] {
+				check.Missing = append(check.Missing, TxDependencyEdge{From: dep, To: i})
+			}
+		}
+
+		for _, dep := range declared[i] {
+			if !detectedClosure[i][dep] {
+				check.Extra = append(check.Extra, TxDependencyEdge{From: dep, To: i})
+			}
+		}
+	}
+
+	return check
+}
+
+// dependencyClosure returns the transactions each of the n transactions depends on, directly
+// or transitively, given their direct dependencies
+func dependencyClosure(deps map[int][]int, n int) []map[int]bool {
+	closure := make([]map[int]bool, n)
+
+	for i := 0; i < n; i++ {
+		closure[i] = make(map[int]bool)
+
+		for _, dep := range deps[i] {
+			if dep < 0 || dep >= i {
+				continue
+			}
+
+			closure[i][dep] = true
+
+			for ancestor := range closure[dep] {
+				closure[i][ancestor] = true
+			}
+		}
+	}
+
+	return closure
+}
+
+// txDependencyTask is an imported block whose tx dependency metadata is to be checked
+// against the dependencies detected while executing it.
+type txDependencyTask struct {
+	header       *types.Header
+	producer     common.Address
+	txDependency [][]uint64
+	txio         *blockstm.TxnInputOutput
+	n            int
+}
+
+// deferTxDependencyCheck keeps the check of the tx dependency metadata of an executed
+// block until the block is written, blocks failing validation never being checked.
+func (bc *BlockChain) deferTxDependencyCheck(task *txDependencyTask) {
+	if bc.txDependencyPending != nil {
+		bc.txDependencyPending.Add(task.header.Hash(), task)
+	}
+}
+
+// acceptTxDependencyCheck schedules the deferred check of the tx dependency metadata of a
+// block once it's written, if it was executed by the parallel processor.
+func (bc *BlockChain) acceptTxDependencyCheck(hash common.Hash) {
+	if bc.txDependencyPending == nil {
+		return
+	}
+
+	if task, ok := bc.txDependencyPending.Get(hash); ok {
+		bc.txDependencyPending.Remove(hash)
+		bc.queueTxDependencyCheck(task)
+	}
+}
+
+// queueTxDependencyCheck schedules the check of the tx dependency metadata of an imported
+// block, dropping it if the checks fall behind the import.
+func (bc *BlockChain) queueTxDependencyCheck(task *txDependencyTask) {
+	select {
+	case bc.txDependencyCh <- task:
+	default:
+		txDependencyDroppedMeter.Mark(1)
+		log.Debug("Dropped tx dependency check", "number", task.header.Number, "hash", task.header.Hash())
+	}
+}
+
+// txDependencyLoop checks the tx dependency metadata of the imported blocks and persists
+// the outcome until the chain is stopped.
+func (bc *BlockChain) txDependencyLoop() {
+	defer bc.wg.Done()
+
+	for {
+		select {
+		case task := <-bc.txDependencyCh:
+			record := checkTxDependency(task.header, task.producer, task.txDependency, task.txio, task.n)
+			rawdb.WriteTxDependencyRecord(bc.db, task.header.Number.Uint64(), task.header.Hash(), record)
+		case <-bc.quit:
+			return
+		}
+	}
+}
+
+// checkTxDependency checks the dependency metadata of an imported block against the
+// dependencies detected while executing it, updates the metrics and returns the outcome
+// along with the producer of the block to be persisted.
+func checkTxDependency(header *types.Header, producer common.Address, txDependency [][]uint64, txio *blockstm.TxnInputOutput, n int) *rawdb.TxDependencyRecord {
+	var detected map[int][]int
+
+	// Detecting the dependencies is only worth it if there is metadata to compare them with
+	if txDependency != nil {
+		detected = DetectedDeps(blockstm.GetDep(*txio))
+	}
+
+	check := CheckTxDependency(txDependency, detected, n)
+
+	record := &rawdb.TxDependencyRecord{
+		Producer:     producer,
+		Transactions: uint64(n),
+		Present:      check.Present,
+		Valid:        check.Valid,
+		Declared:     uint64(check.Declared),
+		Detected:     uint64(check.Detected),
+		Missing:      uint64(len(check.Missing)),
+		Extra:        uint64(len(check.Extra)),
+	}
+
+	switch {
+	case !check.Present:
+		txDependencyAbsentMeter.Mark(1)
+	case !check.Valid:
+		txDependencyInvalidMeter.Mark(1)
+		log.Warn("Malformed tx dependency metadata", "number", header.Number, "hash", header.Hash(), "producer", producer)
+	case len(check.Missing) == 0 && len(check.Extra) == 0:
+		txDependencyAccurateMeter.Mark(1)
+	default:
+		txDependencyMissingMeter.Mark(int64(len(check.Missing)))
+		txDependencyExtraMeter.Mark(int64(len(check.Extra)))
+		log.Debug("Inaccurate tx dependency metadata", "number", header.Number, "hash", header.Hash(), "producer", producer, "missing", len(check.Missing), "extra", len(check.Extra))
+	}
+
+	if !metrics.Enabled() || !check.Present {
+		return record
+	}
+
+	prefix := "chain/txdependency/" + producer.Hex()
+
+	if !check.Valid {
+		metrics.GetOrRegisterMeter(prefix+"/invalid", nil).Mark(1)
+		return record
+	}
+
+	metrics.GetOrRegisterMeter(prefix+"/missing", nil).Mark(int64(len(check.Missing)))
+	metrics.GetOrRegisterMeter(prefix+"/extra", nil).Mark(int64(len(check.Extra)))
+
+	return record
+}
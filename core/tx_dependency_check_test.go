@@ -0,0 +1,144 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTxDependency(t *testing.T) {
+	t.Parallel()
+
+	// 2 depends on 1 which depends on 0, and 3 depends on 0
+	detected := map[int][]int{1: {0}, 2: {1}, 3: {0}}
+
+	// No metadata
+	check := CheckTxDependency(nil, detected, 4)
+	require.False(t, check.Present)
+	require.False(t, check.Valid)
+	require.Equal(t, 3, check.Detected)
+
+	// Malformed metadata, i.e. a dependency on a later transaction
+	check = CheckTxDependency([][]uint64{{}, {2}, {}, {}}, detected, 4)
+	require.True(t, check.Present)
+	require.False(t, check.Valid)
+
+	// Metadata for a different number of transactions
+	check = CheckTxDependency([][]uint64{{}, {0}}, detected, 4)
+	require.False(t, check.Valid)
+
+	// Transitive dependencies are implied, e.g. 2 on 0
+	check = CheckTxDependency([][]uint64{{}, {0}, {1}, {0}}, detected, 4)
+	require.True(t, check.Valid)
+	require.Empty(t, check.Missing)
+	require.Empty(t, check.Extra)
+
+	check = CheckTxDependency([][]uint64{{}, {0}, {0}, {2}}, detected, 4)
+	require.True(t, check.Valid)
+	require.Equal(t, 3, check.Declared)
+	require.Equal(t, []TxDependencyEdge{{From: 1, To: 2}}, check.Missing)
+	require.Equal(t, []TxDependencyEdge{{From: 2, To: 3}}, check.Extra)
+}
+
+func TestTxDependencyRecordWritten(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _   = crypto.GenerateKey()
+		addr     = crypto.PubkeyToAddress(key.PublicKey)
+		coinbase = common.HexToAddress("0x00000000000000000000000000000000c0ffee00")
+		gspec    = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2, func(i int, gen *BlockGen) {
+		gen.SetCoinbase(coinbase)
+
+		for j := 0; j < 3; j++ {
+			tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr), common.Address{0xaa}, big.NewInt(1000), params.TxGas, gen.header.BaseFee, nil), signer, key)
+			gen.AddTx(tx)
+		}
+	})
+
+	db := rawdb.NewMemoryDatabase()
+
+	blockchain, err := NewParallelBlockChain(db, DefaultCacheConfigWithScheme(rawdb.HashScheme), gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil, 4, true)
+	require.NoError(t, err)
+
+	defer blockchain.Stop()
+
+	_, err = blockchain.InsertChain(blocks)
+	require.NoError(t, err)
+
+	// The outcome of the check is written in the background, without metadata to compare with
+	for _, block := range blocks {
+		var record *rawdb.TxDependencyRecord
+
+		require.Eventually(t, func() bool {
+			record = rawdb.ReadTxDependencyRecord(db, block.NumberU64(), block.Hash())
+			return record != nil
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, coinbase, record.Producer)
+		require.Equal(t, uint64(3), record.Transactions)
+		require.False(t, record.Present)
+	}
+}
+
+func TestTxDependencyCheckDroppedWhenBehind(t *testing.T) {
+	t.Parallel()
+
+	bc := &BlockChain{txDependencyCh: make(chan *txDependencyTask, 1)}
+
+	first := &txDependencyTask{header: &types.Header{Number: big.NewInt(1)}, n: 1}
+	second := &txDependencyTask{header: &types.Header{Number: big.NewInt(2)}, n: 2}
+
+	bc.queueTxDependencyCheck(first)
+	bc.queueTxDependencyCheck(second)
+
+	// The import never waits for the checks, the blocks imported while the queue is full are dropped
+	require.Len(t, bc.txDependencyCh, 1)
+	require.Equal(t, first, <-bc.txDependencyCh)
+}
+
+func TestTxDependencyCheckDeferredUntilWritten(t *testing.T) {
+	t.Parallel()
+
+	bc := &BlockChain{
+		txDependencyCh:      make(chan *txDependencyTask, txDependencyQueueSize),
+		txDependencyPending: lru.NewCache[common.Hash, *txDependencyTask](txDependencyQueueSize),
+	}
+
+	accepted := &txDependencyTask{header: &types.Header{Number: big.NewInt(1)}, n: 1}
+	rejected := &txDependencyTask{header: &types.Header{Number: big.NewInt(1), Extra: []byte{1}}, n: 1}
+
+	bc.deferTxDependencyCheck(accepted)
+	bc.deferTxDependencyCheck(rejected)
+
+	// Executed blocks are only checked once written
+	require.Empty(t, bc.txDependencyCh)
+
+	bc.acceptTxDependencyCheck(accepted.header.Hash())
+	require.Len(t, bc.txDependencyCh, 1)
+	require.Equal(t, accepted, <-bc.txDependencyCh)
+
+	// Blocks are checked once, and the ones not executed by the parallel processor aren't
+	bc.acceptTxDependencyCheck(accepted.header.Hash())
+	bc.acceptTxDependencyCheck(common.Hash{1})
+	require.Empty(t, bc.txDependencyCh)
+
+	// Blocks failing validation are never written, hence never checked
+	require.True(t, bc.txDependencyPending.Contains(rejected.header.Hash()))
+}
@@ -0,0 +1,107 @@
+package txpool
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// conditionalJournalLimit is the maximum number of conditional transaction outcomes
+// retained by the journal
+const conditionalJournalLimit = 4096
+
+// Outcomes of the conditional transactions (PIP-15) whose options weren't satisfied
+const (
+	ConditionalRejected = "rejected" // The known accounts mismatched
+	ConditionalExpired  = "expired"  // The block number or timestamp was out of range
+)
+
+// ConditionalCheck describes the check of the options of a conditional transaction which
+// failed.
+type ConditionalCheck struct {
+	Option   string          `json:"option"`             // knownAccounts, blockNumber or timestamp
+	Account  *common.Address `json:"account,omitempty"`  // Known account whose storage mismatched
+	Slot     *common.Hash    `json:"slot,omitempty"`     // Storage slot which mismatched, if not the storage root
+	Expected *common.Hash    `json:"expected,omitempty"` // Expected storage root or slot value
+	Actual   *common.Hash    `json:"actual,omitempty"`   // Actual storage root or slot value
+	Error    string          `json:"error"`
+}
+
+// newConditionalCheck describes the failed check of the given option from its error.
+func newConditionalCheck(option string, err error) *ConditionalCheck {
+	check := &ConditionalCheck{Option: option}
+	if err != nil {
+		check.Error = err.Error()
+	}
+
+	var known *types.KnownAccountError
+	if errors.As(err, &known) {
+		check.Account = &known.Address
+		check.Slot = known.Slot
+		check.Expected = &known.Expected
+		check.Actual = &known.Actual
+	}
+
+	return check
+}
+
+// ConditionalOutcome is the outcome of a conditional transaction whose options weren't
+// satisfied, either while building a block or by a new head.
+type ConditionalOutcome struct {
+	Status      string            `json:"status"`      // rejected or expired
+	BlockNumber uint64            `json:"blockNumber"` // Number of the block the options were checked against
+	Dropped     bool              `json:"dropped"`     // Whether the transaction was dropped from the pool, otherwise it was skipped while building the block
+	Check       *ConditionalCheck `json:"check"`
+}
+
+// ConditionalJournal is a bounded record of the outcomes of the conditional transactions
+// whose options weren't satisfied, the oldest outcomes being evicted first.
+type ConditionalJournal struct {
+	outcomes *lru.Cache[common.Hash, *ConditionalOutcome]
+}
+
+// NewConditionalJournal creates a journal retaining up to limit outcomes.
+func NewConditionalJournal(limit int) *ConditionalJournal {
+	return &ConditionalJournal{
+		outcomes: lru.NewCache[common.Hash, *ConditionalOutcome](limit),
+	}
+}
+
+// Reject records that the options of a conditional transaction weren't satisfied while
+// building the block with the given number.
+func (j *ConditionalJournal) Reject(hash common.Hash, number uint64, option string, err error) {
+	j.outcomes.Add(hash, &ConditionalOutcome{
+		Status:      conditionalStatus(option),
+		BlockNumber: number,
+		Check:       newConditionalCheck(option, err),
+	})
+}
+
+// Drop records that a conditional transaction was dropped from the pool.
+func (j *ConditionalJournal) Drop(ev core.DroppedConditionalTxEvent) {
+	j.outcomes.Add(ev.Tx.Hash(), &ConditionalOutcome{
+		Status:      conditionalStatus(ev.Reason),
+		BlockNumber: ev.Number,
+		Dropped:     true,
+		Check:       newConditionalCheck(ev.Reason, ev.Err),
+	})
+}
+
+// Outcome returns the latest recorded outcome of a conditional transaction, if any.
+func (j *ConditionalJournal) Outcome(hash common.Hash) *ConditionalOutcome {
+	outcome, _ := j.outcomes.Peek(hash)
+	return outcome
+}
+
+// conditionalStatus returns the outcome of a conditional transaction whose given option
+// isn't satisfied.
+func conditionalStatus(option string) string {
+	if option == core.ConditionalKnownAccounts {
+		return ConditionalRejected
+	}
+
+	return ConditionalExpired
+}
@@ -0,0 +1,62 @@
+package txpool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestConditionalJournal(t *testing.T) {
+	t.Parallel()
+
+	journal := NewConditionalJournal(2)
+
+	var (
+		rejected = types.NewTransaction(0, common.Address{}, common.Big0, 21000, common.Big1, nil)
+		expired  = types.NewTransaction(1, common.Address{}, common.Big0, 21000, common.Big1, nil)
+		evicted  = types.NewTransaction(2, common.Address{}, common.Big0, 21000, common.Big1, nil)
+		slot     = common.Hash{31: 1}
+	)
+
+	// A known account mismatch while building a block reports the mismatched slot
+	journal.Reject(rejected.Hash(), 10, core.ConditionalKnownAccounts, &types.KnownAccountError{
+		Address:  common.Address{19: 1},
+		Slot:     &slot,
+		Expected: common.Hash{31: 2},
+		Actual:   common.Hash{31: 3},
+	})
+
+	outcome := journal.Outcome(rejected.Hash())
+	require.NotNil(t, outcome)
+	require.Equal(t, ConditionalRejected, outcome.Status)
+	require.Equal(t, uint64(10), outcome.BlockNumber)
+	require.False(t, outcome.Dropped)
+	require.Equal(t, core.ConditionalKnownAccounts, outcome.Check.Option)
+	require.Equal(t, common.Address{19: 1}, *outcome.Check.Account)
+	require.Equal(t, slot, *outcome.Check.Slot)
+	require.Equal(t, common.Hash{31: 2}, *outcome.Check.Expected)
+	require.Equal(t, common.Hash{31: 3}, *outcome.Check.Actual)
+
+	// An out of range block number on a new head expires the transaction
+	journal.Drop(core.DroppedConditionalTxEvent{Tx: expired, Number: 11, Reason: core.ConditionalBlockNumber, Err: errors.New("out of range")})
+
+	outcome = journal.Outcome(expired.Hash())
+	require.NotNil(t, outcome)
+	require.Equal(t, ConditionalExpired, outcome.Status)
+	require.Equal(t, uint64(11), outcome.BlockNumber)
+	require.True(t, outcome.Dropped)
+	require.Nil(t, outcome.Check.Account)
+	require.Equal(t, "out of range", outcome.Check.Error)
+
+	// The journal is bounded, the oldest outcome is evicted first
+	journal.Drop(core.DroppedConditionalTxEvent{Tx: evicted, Number: 12, Reason: core.ConditionalTimestamp})
+
+	require.Nil(t, journal.Outcome(rejected.Hash()))
+	require.NotNil(t, journal.Outcome(expired.Hash()))
+	require.NotNil(t, journal.Outcome(evicted.Hash()))
+}
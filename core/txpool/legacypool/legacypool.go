@@ -96,6 +96,11 @@ var (
 	queuedNofundsMeter   = metrics.NewRegisteredMeter("txpool/queued/nofunds", nil)   // Dropped due to out-of-funds
 	queuedEvictionMeter  = metrics.NewRegisteredMeter("txpool/queued/eviction", nil)  // Dropped due to lifetime
 
+	// Metrics for the conditional transactions (PIP-15) dropped as their options aren't satisfied anymore
+	conditionalKnownAccountsMeter = metrics.NewRegisteredMeter("txpool/conditional/knownaccounts", nil)
+	conditionalBlockNumberMeter   = metrics.NewRegisteredMeter("txpool/conditional/blocknumber", nil)
+	conditionalTimestampMeter     = metrics.NewRegisteredMeter("txpool/conditional/timestamp", nil)
+
 	// General tx metrics
 	knownTxMeter       = metrics.NewRegisteredMeter("txpool/known", nil)
 	validTxMeter       = metrics.NewRegisteredMeter("txpool/valid", nil)
@@ -248,6 +253,9 @@ type LegacyPool struct {
 	signer      types.Signer
 	mu          sync.RWMutex
 
+	conditionalFeed  event.Feed                       // Feed of the dropped conditional transactions
+	conditionalDrops []core.DroppedConditionalTxEvent // Conditional transactions dropped during the running reorg
+
 	currentHead   atomic.Pointer[types.Header] // Current head of the blockchain
 	currentState  *state.StateDB               // Current state in the blockchain head
 	pendingNonces *noncer                      // Pending state tracking virtual nonces
@@ -433,6 +441,39 @@ func (pool *LegacyPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs
 	return pool.txFeed.Subscribe(ch)
 }
 
+// SubscribeDroppedConditionalTxs registers a subscription for the conditional transactions
+// (PIP-15) dropped from the pool because their options aren't satisfied anymore.
+func (pool *LegacyPool) SubscribeDroppedConditionalTxs(ch chan<- core.DroppedConditionalTxEvent) event.Subscription {
+	return pool.conditionalFeed.Subscribe(ch)
+}
+
+// dropConditional removes the given conditional transactions, whose options aren't
+// satisfied anymore, from the lookup set, and schedules their drop events.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *LegacyPool) dropConditional(txs types.Transactions, reasons map[common.Hash]conditionalDrop) {
+	number := pool.currentHead.Load().Number.Uint64()
+
+	for _, tx := range txs {
+		hash := tx.Hash()
+		pool.all.Remove(hash)
+
+		drop := reasons[hash]
+		log.Trace("Removed invalid conditional transaction", "hash", hash, "reason", drop.reason, "err", drop.err)
+
+		switch drop.reason {
+		case core.ConditionalKnownAccounts:
+			conditionalKnownAccountsMeter.Mark(1)
+		case core.ConditionalBlockNumber:
+			conditionalBlockNumberMeter.Mark(1)
+		case core.ConditionalTimestamp:
+			conditionalTimestampMeter.Mark(1)
+		}
+
+		pool.conditionalDrops = append(pool.conditionalDrops, core.DroppedConditionalTxEvent{Tx: tx, Number: number, Reason: drop.reason, Err: drop.err})
+	}
+}
+
 // SetGasTip updates the minimum gas tip required by the transaction pool for a
 // new transaction, and drops all transactions below this threshold.
 func (pool *LegacyPool) SetGasTip(tip *big.Int) {
@@ -1394,9 +1435,17 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	dropBetweenReorgHistogram.Update(int64(pool.changesSinceReorg))
 	pool.changesSinceReorg = 0 // Reset change counter
 
+	conditionalDrops := pool.conditionalDrops
+	pool.conditionalDrops = nil
+
 	pool.mu.Unlock()
 	reorgLockDurationTimer.Update(time.Since(lockTime))
 
+	// Notify subsystems for dropped conditional transactions
+	for _, drop := range conditionalDrops {
+		pool.conditionalFeed.Send(drop)
+	}
+
 	// Reheap if needed
 	if reset != nil {
 		if reset.newHead != nil {
@@ -1555,6 +1604,10 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		log.Trace("Removed unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
 
+		// bor: Drop all transactions that no longer have valid TxOptions
+		conditionals, reasons := list.filterTxConditional(pool.currentState, pool.currentHead.Load())
+		pool.dropConditional(conditionals, reasons)
+
 		// Gather all executable transactions and promote them
 		readies := list.Ready(pool.pendingNonces.get(addr))
 		for _, tx := range readies {
@@ -1575,8 +1628,8 @@ func (pool *LegacyPool) promoteExecutables(accounts []common.Address) []*types.T
 		}
 		queuedRateLimitMeter.Mark(int64(len(caps)))
 		// Mark all the items dropped as removed
-		pool.priced.Removed(len(forwards) + len(drops) + len(caps))
-		queuedGauge.Dec(int64(len(forwards) + len(drops) + len(caps)))
+		pool.priced.Removed(len(forwards) + len(drops) + len(conditionals) + len(caps))
+		queuedGauge.Dec(int64(len(forwards) + len(drops) + len(conditionals) + len(caps)))
 
 		// Delete the entire queue entry if it became empty.
 		if list.Empty() {
@@ -1754,13 +1807,8 @@ func (pool *LegacyPool) demoteUnexecutables() {
 			pool.enqueueTx(hash, tx, false)
 		}
 		// bor: Drop all transactions that no longer have valid TxOptions
-		txConditionalsRemoved := list.FilterTxConditional(pool.currentState, currentHeader)
-
-		for _, tx := range txConditionalsRemoved {
-			hash := tx.Hash()
-			pool.all.Remove(hash)
-			log.Trace("Removed invalid conditional transaction", "hash", hash)
-		}
+		txConditionalsRemoved, reasons := list.filterTxConditional(pool.currentState, currentHeader)
+		pool.dropConditional(txConditionalsRemoved, reasons)
 
 		pendingGauge.Dec(int64(len(olds) + len(drops) + len(invalids) + len(txConditionalsRemoved)))
 		// If there's a gap in front, alert (should never happen) and postpone all transactions
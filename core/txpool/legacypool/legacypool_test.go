@@ -540,6 +540,116 @@ func TestQueue2(t *testing.T) {
 	}
 }
 
+// Tests that pending and queued conditional transactions (PIP-15) are dropped on a new head
+// once their options aren't satisfied anymore, and that drop events are sent.
+func TestConditionalTransactionDrop(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000))
+
+	drops := make(chan core.DroppedConditionalTxEvent, 2)
+	sub := pool.SubscribeDroppedConditionalTxs(drops)
+	defer sub.Unsubscribe()
+
+	// Both transactions are conditional on the value of a storage slot
+	known := common.Address{19: 1}
+
+	pool.mu.Lock()
+	pool.currentState.SetState(known, common.Hash{}, common.Hash{31: 1})
+	pool.mu.Unlock()
+
+	options := &types.OptionsPIP15{
+		KnownAccounts: types.KnownAccounts{
+			known: &types.Value{Storage: map[common.Hash]common.Hash{{}: {31: 1}}},
+		},
+	}
+
+	pending := transaction(0, 100000, key)
+	pending.PutOptions(options)
+
+	queued := transaction(2, 100000, key)
+	queued.PutOptions(options)
+
+	for _, err := range pool.addRemotesSync([]*types.Transaction{pending, queued}) {
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 1, pool.pending[from].Len())
+	require.Equal(t, 1, pool.queue[from].Len())
+
+	// The options are still satisfied, nothing is dropped
+	<-pool.requestReset(nil, nil)
+
+	require.Equal(t, 2, pool.all.Count())
+	require.Len(t, drops, 0)
+
+	// Change the storage slot, both transactions are dropped
+	pool.mu.Lock()
+	pool.currentState.SetState(known, common.Hash{}, common.Hash{31: 2})
+	pool.mu.Unlock()
+
+	<-pool.requestReset(nil, nil)
+
+	require.Equal(t, 0, pool.all.Count())
+	require.NoError(t, validatePoolInternals(pool))
+
+	dropped := make(map[common.Hash]bool)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case drop := <-drops:
+			require.Equal(t, core.ConditionalKnownAccounts, drop.Reason)
+			require.Error(t, drop.Err)
+
+			dropped[drop.Tx.Hash()] = true
+		case <-time.After(time.Second):
+			t.Fatalf("drop event %d not fired", i)
+		}
+	}
+
+	require.True(t, dropped[pending.Hash()])
+	require.True(t, dropped[queued.Hash()])
+}
+
+// Tests that conditional transactions (PIP-15) whose block number or timestamp range
+// didn't start yet are kept in the pool on a new head.
+func TestConditionalTransactionNotYetValid(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, big.NewInt(1000000))
+
+	drops := make(chan core.DroppedConditionalTxEvent, 2)
+	sub := pool.SubscribeDroppedConditionalTxs(drops)
+	defer sub.Unsubscribe()
+
+	head := pool.currentHead.Load()
+	minTimestamp := head.Time + 100
+
+	pending := transaction(0, 100000, key)
+	pending.PutOptions(&types.OptionsPIP15{BlockNumberMin: new(big.Int).Add(head.Number, big.NewInt(10))})
+
+	queued := transaction(2, 100000, key)
+	queued.PutOptions(&types.OptionsPIP15{TimestampMin: &minTimestamp})
+
+	for _, err := range pool.addRemotesSync([]*types.Transaction{pending, queued}) {
+		require.NoError(t, err)
+	}
+
+	<-pool.requestReset(nil, nil)
+
+	require.Equal(t, 1, pool.pending[from].Len())
+	require.Equal(t, 1, pool.queue[from].Len())
+	require.Len(t, drops, 0)
+}
+
 func TestNegativeValue(t *testing.T) {
 	t.Parallel()
 
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -427,26 +428,49 @@ func (l *list) Filter(costLimit *uint256.Int, gasLimit uint64) (types.Transactio
 	return removed, invalids
 }
 
-// FilterTxConditional returns the conditional transactions with invalid PIP15 options
+// conditionalDrop is the reason a conditional transaction is dropped for
+type conditionalDrop struct {
+	reason string // Option which isn't satisfied anymore
+	err    error
+}
+
+// FilterTxConditional returns the conditional transactions with invalid PIP15 options, i.e.
+// whose known accounts changed or whose block number or timestamp range already ended.
 func (l *list) FilterTxConditional(state *state.StateDB, header *types.Header) types.Transactions {
+	removed, _ := l.filterTxConditional(state, header)
+	return removed
+}
+
+// filterTxConditional removes the conditional transactions with invalid PIP15 options,
+// returning them along with the reason each of them was removed for. Transactions whose
+// block number or timestamp range didn't start yet are kept, as they may become valid.
+func (l *list) filterTxConditional(state *state.StateDB, header *types.Header) (types.Transactions, map[common.Hash]conditionalDrop) {
 	if state == nil || header == nil {
-		return nil
+		return nil, nil
 	}
 
+	reasons := make(map[common.Hash]conditionalDrop)
+
 	removed := l.txs.filter(func(tx *types.Transaction) bool {
 		if options := tx.GetOptions(); options != nil {
 			if err := state.ValidateKnownAccounts(options.KnownAccounts); err != nil {
 				log.Debug("Error while Filtering Tx Conditional's known accounts", "err", err)
+				reasons[tx.Hash()] = conditionalDrop{core.ConditionalKnownAccounts, err}
+
 				return true
 			}
 
-			if err := header.ValidateBlockNumberOptionsPIP15(options.BlockNumberMin, options.BlockNumberMax); err != nil {
+			if err := header.ValidateBlockNumberOptionsPIP15(nil, options.BlockNumberMax); err != nil {
 				log.Debug("Error while Filtering Tx Conditional's block number options", "err", err)
+				reasons[tx.Hash()] = conditionalDrop{core.ConditionalBlockNumber, err}
+
 				return true
 			}
 
-			if err := header.ValidateTimestampOptionsPIP15(options.TimestampMin, options.TimestampMax); err != nil {
+			if err := header.ValidateTimestampOptionsPIP15(nil, options.TimestampMax); err != nil {
 				log.Debug("Error while Filtering Tx Conditional's timestamp options", "err", err)
+				reasons[tx.Hash()] = conditionalDrop{core.ConditionalTimestamp, err}
+
 				return true
 			}
 
@@ -457,12 +481,12 @@ func (l *list) FilterTxConditional(state *state.StateDB, header *types.Header) t
 	})
 
 	if len(removed) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	l.txs.reheap()
 
-	return removed
+	return removed, reasons
 }
 
 // Cap places a hard limit on the number of items, returning all transactions
@@ -227,6 +227,14 @@ func TestFilterTxConditionalBlockNumber(t *testing.T) {
 	count = len(drops)
 	require.Equal(t, 0, count, "got %d filtered by TxOptions when there should not be any", count)
 
+	// tx2 is kept until its block number range starts
+	header.Number = big.NewInt(80)
+
+	drops = list.FilterTxConditional(state, header)
+
+	count = len(drops)
+	require.Equal(t, 0, count, "got %d filtered by TxOptions before the range starts", count)
+
 	// Set block number that conflicts with tx2's policy
 	header.Number = big.NewInt(120)
 
@@ -291,6 +299,14 @@ func TestFilterTxConditionalTimestamp(t *testing.T) {
 	count = len(drops)
 	require.Equal(t, 0, count, "got %d filtered by TxOptions when there should not be any", count)
 
+	// tx2 is kept until its timestamp range starts
+	header.Time = 80
+
+	drops = list.FilterTxConditional(state, header)
+
+	count = len(drops)
+	require.Equal(t, 0, count, "got %d filtered by TxOptions before the range starts", count)
+
 	// Set timestamp that conflicts with tx2's policy
 	header.Time = 120
 
@@ -77,6 +77,8 @@ type TxPool struct {
 	term chan struct{}           // Termination channel to detect a closed pool
 
 	sync chan chan error // Testing / simulator channel to block until internal reset is done
+
+	conditionals *ConditionalJournal // Outcomes of the conditional transactions whose options weren't satisfied
 }
 
 // New creates a new transaction pool to gather, sort and filter inbound
@@ -105,6 +107,8 @@ func New(gasTip uint64, chain BlockChain, subpools []SubPool) (*TxPool, error) {
 		quit:     make(chan chan error),
 		term:     make(chan struct{}),
 		sync:     make(chan chan error),
+
+		conditionals: NewConditionalJournal(conditionalJournalLimit),
 	}
 	reserver := NewReservationTracker()
 	for i, subpool := range subpools {
@@ -158,6 +162,13 @@ func (p *TxPool) loop(head *types.Header) {
 	)
 	defer newHeadSub.Unsubscribe()
 
+	// Subscribe to dropped conditional transactions to journal their outcome
+	var (
+		droppedCh  = make(chan core.DroppedConditionalTxEvent, 16)
+		droppedSub = p.subscribeDroppedConditionalTxs(droppedCh)
+	)
+	defer droppedSub.Unsubscribe()
+
 	// Track the previous and current head to feed to an idle reset
 	var (
 		oldHead = head
@@ -240,6 +251,9 @@ func (p *TxPool) loop(head *types.Header) {
 				resetWaiter = nil
 			}
 
+		case drop := <-droppedCh:
+			p.conditionals.Drop(drop)
+
 		case errc = <-p.quit:
 			// Termination requested, break out on the next loop round
 
@@ -258,6 +272,12 @@ func (p *TxPool) loop(head *types.Header) {
 	errc <- nil
 }
 
+// ConditionalJournal returns the journal of the outcomes of the conditional transactions
+// (PIP-15) whose options weren't satisfied.
+func (p *TxPool) ConditionalJournal() *ConditionalJournal {
+	return p.conditionals
+}
+
 // SetGasTip updates the minimum gas tip required by the transaction pool for a
 // new transaction, and drops all transactions below this threshold.
 func (p *TxPool) SetGasTip(tip *big.Int) {
@@ -400,6 +420,30 @@ func (p *TxPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool)
 	return p.subs.Track(event.JoinSubscriptions(subs...))
 }
 
+// SubscribeDroppedConditionalTxs registers a subscription for the conditional transactions
+// (PIP-15) dropped by the subpools supporting them because their options aren't satisfied
+// anymore.
+func (p *TxPool) SubscribeDroppedConditionalTxs(ch chan<- core.DroppedConditionalTxEvent) event.Subscription {
+	return p.subs.Track(p.subscribeDroppedConditionalTxs(ch))
+}
+
+// subscribeDroppedConditionalTxs subscribes to the dropped conditional transactions of
+// the subpools without tracking the subscription, which the pool's own loop relies on
+// even if the pool is closed before it starts.
+func (p *TxPool) subscribeDroppedConditionalTxs(ch chan<- core.DroppedConditionalTxEvent) event.Subscription {
+	var subs []event.Subscription
+
+	for _, subpool := range p.subpools {
+		if pool, ok := subpool.(interface {
+			SubscribeDroppedConditionalTxs(ch chan<- core.DroppedConditionalTxEvent) event.Subscription
+		}); ok {
+			subs = append(subs, pool.SubscribeDroppedConditionalTxs(ch))
+		}
+	}
+
+	return event.JoinSubscriptions(subs...)
+}
+
 // PoolNonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (p *TxPool) PoolNonce(addr common.Address) uint64 {
@@ -122,6 +122,23 @@ type OptionsPIP15 struct {
 
 var ErrKnownAccounts = errors.New("an incorrect list of knownAccounts")
 
+// KnownAccountError is returned when the storage of a known account of a conditional
+// transaction doesn't match the expected storage root or slot value.
+type KnownAccountError struct {
+	Address  common.Address
+	Slot     *common.Hash // Mismatched storage slot, nil if the storage root mismatched
+	Expected common.Hash
+	Actual   common.Hash
+}
+
+func (e *KnownAccountError) Error() string {
+	if e.Slot == nil {
+		return fmt.Sprintf("invalid root hash for: %v root hash: %v actual root hash: %v", e.Address, e.Expected, e.Actual)
+	}
+
+	return fmt.Sprintf("invalid slot value at address: %v slot: %v value: %v actual value: %v", e.Address, *e.Slot, e.Expected, e.Actual)
+}
+
 func (ka KnownAccounts) ValidateLength() error {
 	if ka == nil {
 		return nil
@@ -141,3 +141,9 @@ func (api *AdminAPI) ImportChain(file string) (bool, error) {
 	}
 	return true, nil
 }
+
+// PeerReputation returns the peers which recently served chains conflicting with the
+// whitelisted milestones and checkpoints, along with whether they're banned.
+func (api *AdminAPI) PeerReputation() []*PeerReputation {
+	return api.eth.handler.reputation.list()
+}
@@ -0,0 +1,148 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// dependencyGraphReexec is the number of blocks re-executed to regenerate the state
+// of the parent of the profiled block when it isn't available anymore
+const dependencyGraphReexec = 128
+
+// TransactionAbort describes why an incarnation of a transaction was re-executed
+type TransactionAbort struct {
+	Incarnation int    `json:"incarnation"`
+	Dependency  *int   `json:"dependency,omitempty"` // Transaction whose pending writes were read, if known
+	Validation  bool   `json:"validation"`           // Whether the reads were invalidated after the execution
+	Reason      string `json:"reason"`
+}
+
+// TransactionDependencies is the outcome of the parallel execution of a transaction
+type TransactionDependencies struct {
+	Index         int                 `json:"index"`
+	Hash          common.Hash         `json:"hash"`
+	Dependencies  []int               `json:"dependencies"`
+	Incarnations  int                 `json:"incarnations"`
+	ExecutionTime uint64              `json:"executionTime"` // Nanoseconds taken by the last incarnation
+	Aborts        []*TransactionAbort `json:"aborts"`
+}
+
+// BlockDependencyGraph is the dependency graph of the transactions of a block along with
+// the statistics of their parallel execution
+type BlockDependencyGraph struct {
+	Number           uint64                     `json:"number"`
+	Hash             common.Hash                `json:"hash"`
+	Transactions     []*TransactionDependencies `json:"transactions"`
+	Edges            []core.TxDependencyEdge    `json:"edges"`
+	CriticalPath     []int                      `json:"criticalPath"`
+	CriticalPathTime uint64                     `json:"criticalPathTime"` // Nanoseconds
+	SerialTime       uint64                     `json:"serialTime"`       // Nanoseconds
+	Speedup          float64                    `json:"speedup"`
+	Metadata         *core.TxDependencyCheck    `json:"metadata"`
+}
+
+// GetBlockDependencyGraph re-executes the given block with the parallel state processor
+// and profiling enabled, and returns the dependencies observed between its transactions,
+// the number of incarnations of each transaction and why they were aborted, the critical
+// path of the dependency graph and the speed-up of an ideal parallel execution over a
+// serial one. The observed dependencies are checked against the dependency metadata
+// embedded in the block.
+func (api *DebugAPI) GetBlockDependencyGraph(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*BlockDependencyGraph, error) {
+	block, err := api.eth.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if block == nil {
+		return nil, errors.New("block not found")
+	}
+
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+
+	parent := api.eth.blockchain.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+
+	statedb, release, err := api.eth.stateAtBlock(ctx, parent, dependencyGraphReexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	processor := core.NewParallelStateProcessor(api.eth.blockchain.Config(), api.eth.blockchain, api.eth.engine)
+
+	_, result, err := processor.ProcessWithProfile(block, statedb, *api.eth.blockchain.GetVMConfig(), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute block %d: %w", block.NumberU64(), err)
+	}
+
+	graph := &BlockDependencyGraph{
+		Number:       block.NumberU64(),
+		Hash:         block.Hash(),
+		Transactions: make([]*TransactionDependencies, 0, len(block.Transactions())),
+		Edges:        []core.TxDependencyEdge{},
+		CriticalPath: []int{},
+	}
+
+	detected := core.DetectedDeps(result.AllDeps)
+
+	for i, tx := range block.Transactions() {
+		txDeps := &TransactionDependencies{
+			Index:        i,
+			Hash:         tx.Hash(),
+			Dependencies: append([]int{}, detected[i]...),
+			Aborts:       []*TransactionAbort{},
+		}
+
+		for _, dep := range detected[i] {
+			graph.Edges = append(graph.Edges, core.TxDependencyEdge{From: dep, To: i})
+		}
+
+		if i < len(result.Incarnations) {
+			txDeps.Incarnations = result.Incarnations[i]
+		}
+
+		if result.Stats != nil {
+			stat := (*result.Stats)[i]
+			txDeps.ExecutionTime = stat.End - stat.Start
+			graph.SerialTime += txDeps.ExecutionTime
+		}
+
+		for _, abort := range result.Aborts[i] {
+			txAbort := &TransactionAbort{
+				Incarnation: abort.Incarnation,
+				Validation:  abort.Validation,
+				Reason:      abort.Reason,
+			}
+
+			if abort.Dependency >= 0 {
+				dependency := abort.Dependency
+				txAbort.Dependency = &dependency
+			}
+
+			txDeps.Aborts = append(txDeps.Aborts, txAbort)
+		}
+
+		graph.Transactions = append(graph.Transactions, txDeps)
+	}
+
+	if result.Deps != nil && result.Stats != nil && len(block.Transactions()) > 0 {
+		graph.CriticalPath, graph.CriticalPathTime = result.Deps.LongestPath(*result.Stats)
+	}
+
+	if graph.CriticalPathTime > 0 {
+		graph.Speedup = float64(graph.SerialTime) / float64(graph.CriticalPathTime)
+	}
+
+	graph.Metadata = core.CheckTxDependency(block.GetTxDependency(), detected, len(block.Transactions()))
+
+	return graph, nil
+}
@@ -25,6 +25,7 @@ import (
 	"math/big"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts"
@@ -33,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
@@ -113,6 +115,9 @@ type Ethereum struct {
 
 	lock sync.RWMutex // Protects the variadic fields (e.g. gas price and etherbase)
 
+	incidentLock      sync.Mutex                           // Serializes the id allocation of the finality incidents
+	pendingCheckpoint atomic.Pointer[rawdb.FinalityRecord] // Record of the checkpoint being processed, not in the history yet
+
 	closeCh chan struct{} // Channel to signal the background processes to exit
 
 	shutdownTracker *shutdowncheck.ShutdownTracker // Tracks if and when the node has shutdown ungracefully
@@ -272,6 +277,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	// if enabled, use parallel state processor
 	if config.ParallelEVM.Enable {
 		eth.blockchain, err = core.NewParallelBlockChain(chainDb, cacheConfig, config.Genesis, &overrides, eth.engine, vmConfig, eth.shouldPreserve, &config.TransactionHistory, checker, config.ParallelEVM.SpeculativeProcesses, config.ParallelEVM.Enforce)
+		if err == nil && config.ParallelEVM.Adaptive {
+			eth.blockchain.EnableAdaptiveParallelism(config.ParallelEVM.MinParallelism)
+		}
 	} else {
 		eth.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, config.Genesis, &overrides, eth.engine, vmConfig, eth.shouldPreserve, &config.TransactionHistory, checker)
 	}
@@ -279,6 +287,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	// Set blockchain reference for fork detection in whitelist service
 	if err == nil {
 		checker.SetBlockchain(eth.blockchain)
+		checker.SetFinalityNotifier(notifyFinalized(eth.blockchain, chainDb, &eth.pendingCheckpoint))
 	}
 
 	// 1.14.8: NewOracle function definition was changed to accept (startPrice *big.Int) param.
@@ -358,6 +367,8 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		checker:             checker,
 		enableBlockTracking: eth.config.EnableBlockTracking,
 		txAnnouncementOnly:  eth.p2pServer.TxAnnouncementOnly,
+		PeerBanThreshold:    config.PeerBanThreshold,
+		PeerFailureExpiry:   config.PeerFailureExpiry,
 	}); err != nil {
 		return nil, err
 	}
@@ -664,6 +675,12 @@ func (s *Ethereum) Start() error {
 
 	go s.startCheckpointWhitelistService()
 	go s.startMilestoneWhitelistService()
+	s.startHeimdallSubscriptions()
+
+	if engine, ok := s.engine.(*bor.Bor); ok {
+		go s.recordProducers(engine)
+		go s.indexRootHashes(engine)
+	}
 
 	// start log indexer
 	s.filterMaps.Start()
@@ -690,36 +707,155 @@ func (s *Ethereum) startCheckpointWhitelistService() {
 		fnName         = "whitelist checkpoint"
 	)
 
+	// If heimdall ws is available, fetch the checkpoint as soon as heimdall announces it
+	if _, borEngine, err := s.getHandler(); err == nil && borEngine.HeimdallWSClient != nil {
+		subscribeHeimdallHandler(s, borEngine.HeimdallWSClient.SubscribeCheckpointEvents, func(ctx context.Context, ethHandler *ethHandler, bor *bor.Bor, _ uint64) error {
+			return s.fetchAndHandleWhitelistCheckpoint(ctx, ethHandler, bor)
+		}, s.fetchAndHandleWhitelistCheckpoint, tickerDuration, whitelistTimeout)
+
+		return
+	}
+
 	s.retryHeimdallHandler(s.fetchAndHandleWhitelistCheckpoint, tickerDuration, whitelistTimeout)
 }
 
 // startMilestoneWhitelistService starts the goroutine to fetch milestiones and update the
 // milestone whitelist map.
 func (s *Ethereum) startMilestoneWhitelistService() {
-	ethHandler, bor, _ := s.getHandler()
+	_, borEngine, _ := s.getHandler()
 
 	const (
 		tickerDuration = 2 * time.Second
+
+		// Polling is only a fallback for a broken subscription, hence it's less frequent
+		wsTickerDuration = 10 * time.Second
 	)
 
 	// If heimdall ws is available use WS subscription to new milestone events instead of polling
-	if bor != nil && bor.HeimdallWSClient != nil {
-		for {
-			if err := s.subscribeAndHandleMilestone(context.Background(), ethHandler, bor); err != nil {
-				log.Error("Error subscribing to milestone events", "err", err)
-			}
+	if borEngine != nil && borEngine.HeimdallWSClient != nil {
+		subscribeHeimdallHandler(s, borEngine.HeimdallWSClient.SubscribeMilestoneEvents, func(ctx context.Context, ethHandler *ethHandler, _ *bor.Bor, m *milestone.Milestone) error {
+			return ethHandler.handleMilestone(ctx, s, m, newBorVerifier())
+		}, s.fetchAndHandleMilestone, wsTickerDuration, whitelistTimeout)
+
+		return
+	}
+
+	s.retryHeimdallHandler(s.fetchAndHandleMilestone, tickerDuration, whitelistTimeout)
+}
+
+// startHeimdallSubscriptions starts the goroutines which prefetch the spans and state-sync
+// events announced by heimdall so that they needn't be fetched while importing blocks.
+// Without heimdall ws, these are fetched on demand by bor consensus.
+func (s *Ethereum) startHeimdallSubscriptions() {
+	_, borEngine, err := s.getHandler()
+	if err != nil || borEngine.HeimdallWSClient == nil {
+		return
+	}
+
+	const (
+		// The subscriptions only prefetch data, hence polling is only a rare fallback
+		tickerDuration = 60 * time.Second
+	)
+
+	go subscribeHeimdallHandler(s, borEngine.HeimdallWSClient.SubscribeSpanEvents, func(ctx context.Context, _ *ethHandler, bor *bor.Bor, id uint64) error {
+		return bor.ProcessSpanEvent(ctx, id)
+	}, s.fetchLatestSpan, tickerDuration, whitelistTimeout)
+
+	go subscribeHeimdallHandler(s, borEngine.HeimdallWSClient.SubscribeStateSyncEvents, func(ctx context.Context, _ *ethHandler, bor *bor.Bor, id uint64) error {
+		return bor.ProcessStateSyncEvent(ctx, id)
+	}, s.pollStateSyncEvents, tickerDuration, whitelistTimeout)
+}
+
+// pollStateSyncEvents fetches the state-sync events following the buffered ones from heimdall
+func (s *Ethereum) pollStateSyncEvents(ctx context.Context, _ *ethHandler, bor *bor.Bor) error {
+	return bor.PollStateSyncEvents(ctx)
+}
+
+// fetchLatestSpan fetches the latest span from heimdall into the span store
+func (s *Ethereum) fetchLatestSpan(ctx context.Context, _ *ethHandler, bor *bor.Bor) error {
+	span, err := bor.HeimdallClient.GetLatestSpan(ctx)
+	if err != nil {
+		return err
+	}
+
+	return bor.ProcessSpanEvent(ctx, span.Id)
+}
+
+// subscribeHeimdallHandler calls onEvent for every event of the heimdall ws subscription.
+// While the subscription is being established or no event arrived for tickerDuration, the
+// poll handler is called instead so that a broken subscription falls back to polling. The
+// subscription is re-established when it's closed and the poll handler is called once it's
+// back to catch up with the events missed in between.
+func subscribeHeimdallHandler[T any](s *Ethereum, subscribe func(context.Context) <-chan T, onEvent func(context.Context, *ethHandler, *bor.Bor, T) error, poll heimdallHandler, tickerDuration time.Duration, timeout time.Duration) {
+	ethHandler, bor, err := s.getHandler()
+	if err != nil {
+		log.Error("error while getting the ethHandler", "err", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	run := func(fn func(context.Context) error) {
+		fnCtx, fnCancel := context.WithTimeout(ctx, timeout)
+		defer fnCancel()
+
+		// Skip any error reporting here as it's handled in respective functions
+		_ = fn(fnCtx)
+	}
+
+	runPoll := func() {
+		if poll != nil {
+			run(func(ctx context.Context) error { return poll(ctx, ethHandler, bor) })
+		}
+	}
 
-			// If we fail to subscribe, retry after a delay
+	subscribed := make(chan (<-chan T), 1)
+	resubscribe := func(delay time.Duration) {
+		go func() {
 			select {
-			case <-s.closeCh:
+			case <-time.After(delay):
+			case <-ctx.Done():
 				return
-			case <-time.After(tickerDuration):
-				// Continue to retry subscribing to milestone event
 			}
-		}
+
+			subscribed <- subscribe(ctx)
+		}()
 	}
 
-	s.retryHeimdallHandler(s.fetchAndHandleMilestone, tickerDuration, whitelistTimeout)
+	resubscribe(0)
+
+	ticker := time.NewTicker(tickerDuration)
+	defer ticker.Stop()
+
+	var events <-chan T
+
+	for {
+		select {
+		case events = <-subscribed:
+			runPoll()
+
+		case event, ok := <-events:
+			if !ok {
+				log.Warn("Heimdall ws subscription closed, falling back to polling until resubscribed")
+
+				events = nil
+
+				resubscribe(tickerDuration)
+
+				continue
+			}
+
+			run(func(ctx context.Context) error { return onEvent(ctx, ethHandler, bor, event) })
+			ticker.Reset(tickerDuration)
+
+		case <-ticker.C:
+			runPoll()
+
+		case <-s.closeCh:
+			return
+		}
+	}
 }
 
 func (s *Ethereum) retryHeimdallHandler(fn heimdallHandler, tickerDuration time.Duration, timeout time.Duration) {
@@ -777,8 +913,24 @@ func (s *Ethereum) fetchAndHandleWhitelistCheckpoint(ctx context.Context, ethHan
 		return err
 	}
 
-	_, err = ethHandler.handleWhitelistCheckpoint(ctx, checkpoint, s, verifier, true)
-	return err
+	hash, err := ethHandler.handleWhitelistCheckpoint(ctx, checkpoint, s, verifier, false)
+	if err != nil {
+		return err
+	}
+
+	// The checkpoint is only recorded in the history once processed, its record being handed
+	// over to the finality notifier meanwhile
+	record := newCheckpointRecord(ctx, s.ChainDb(), bor.HeimdallClient, checkpoint)
+
+	s.pendingCheckpoint.Store(record)
+	ethHandler.downloader.ProcessCheckpoint(checkpoint.EndBlock, hash)
+	s.pendingCheckpoint.Store(nil)
+
+	if record != nil {
+		writeCheckpointHistory(s.ChainDb(), record)
+	}
+
+	return nil
 }
 
 type heimdallHandler func(ctx context.Context, ethHandler *ethHandler, bor *bor.Bor) error
@@ -795,27 +947,6 @@ func (s *Ethereum) fetchAndHandleMilestone(ctx context.Context, ethHandler *ethH
 	return ethHandler.handleMilestone(ctx, s, milestone, verifier)
 }
 
-func (s *Ethereum) subscribeAndHandleMilestone(ctx context.Context, ethHandler *ethHandler, bor *bor.Bor) error {
-	milestoneEvents := bor.HeimdallWSClient.SubscribeMilestoneEvents(ctx)
-
-	for {
-		select {
-		case m, ok := <-milestoneEvents:
-			if !ok {
-				return nil
-			}
-
-			err := ethHandler.handleMilestone(ctx, s, m, newBorVerifier())
-			if err != nil {
-				log.Error("error handling milestone ws event", "err", err)
-			}
-
-		case <-ctx.Done():
-			return nil
-		}
-	}
-}
-
 func (s *Ethereum) newChainView(head *types.Header) *filtermaps.ChainView {
 	if head == nil {
 		return nil
@@ -968,7 +1099,8 @@ func (s *Ethereum) SetBlockchain(blockchain *core.BlockChain) {
 // SyncMode retrieves the current sync mode, either explicitly set, or derived
 // from the chain status.
 func (s *Ethereum) SyncMode() downloader.SyncMode {
-	// If we're in snap sync mode, return that directly
+	// If we're in snap sync mode, return that directly. Milestone sync downloads the
+	// state like snap sync does, so it's reported as snap sync as well.
 	if s.handler.snapSync.Load() {
 		return downloader.SnapSync
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
@@ -127,6 +128,21 @@ func (b *EthAPIBackend) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) e
 	return b.eth.BlockChain().SubscribeStateSyncEvent(ch)
 }
 
+// SubscribeFinalizedHeadEvent subscribes to milestone and checkpoint finality event
+func (b *EthAPIBackend) SubscribeFinalizedHeadEvent(ch chan<- core.FinalizedHeadEvent) event.Subscription {
+	return b.eth.BlockChain().SubscribeFinalizedHeadEvent(ch)
+}
+
+// SubscribeDroppedConditionalTxsEvent subscribes to dropped conditional transaction event
+func (b *EthAPIBackend) SubscribeDroppedConditionalTxsEvent(ch chan<- core.DroppedConditionalTxEvent) event.Subscription {
+	return b.eth.txPool.SubscribeDroppedConditionalTxs(ch)
+}
+
+// GetConditionalTransactionOutcome returns the latest journaled outcome of a conditional transaction
+func (b *EthAPIBackend) GetConditionalTransactionOutcome(txHash common.Hash) *txpool.ConditionalOutcome {
+	return b.eth.txPool.ConditionalJournal().Outcome(txHash)
+}
+
 // SubscribeChain2HeadEvent subscribes to reorg/head/fork event
 func (b *EthAPIBackend) SubscribeChain2HeadEvent(ch chan<- core.Chain2HeadEvent) event.Subscription {
 	return b.eth.BlockChain().SubscribeChain2HeadEvent(ch)
@@ -0,0 +1,50 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+)
+
+// blockOriginsLimit is the number of recent blocks whose origin is tracked
+const blockOriginsLimit = 4096
+
+// blockOrigins tracks the peers which delivered the recent blocks, for the peers behind a
+// branch rewound by a milestone or checkpoint mismatch to be identified.
+type blockOrigins struct {
+	cache *lru.Cache[common.Hash, string]
+}
+
+func newBlockOrigins() *blockOrigins {
+	return &blockOrigins{
+		cache: lru.NewCache[common.Hash, string](blockOriginsLimit),
+	}
+}
+
+// add records the peer which delivered a block or its header, unless the block was
+// already delivered.
+func (o *blockOrigins) add(hash common.Hash, peer string) {
+	if peer != "" && !o.cache.Contains(hash) {
+		o.cache.Add(hash, peer)
+	}
+}
+
+// peer returns the peer which delivered a block, empty if unknown.
+func (o *blockOrigins) peer(hash common.Hash) string {
+	peer, _ := o.cache.Peek(hash)
+	return peer
+}
+
+// blockOrigin returns the peer a block was received from, i.e. the one which broadcast
+// it or delivered it to the block fetcher, empty if unknown.
+func blockOrigin(block *types.Block) string {
+	switch from := block.ReceivedFrom.(type) {
+	case *eth.Peer:
+		return from.ID()
+	case string:
+		return from
+	default:
+		return ""
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -158,6 +159,8 @@ func borVerify(ctx context.Context, eth *Ethereum, handler *ethHandler, start ui
 			canonicalChain = nil
 		}
 
+		recordFinalityIncident(eth, str, start, end, hash, localHash, head, rewindTo, canonicalChain)
+
 		reorgToFinalized(eth, head, rewindTo, canonicalChain)
 	}
 
@@ -197,6 +200,13 @@ func rewind(eth *Ethereum, head uint64, rewindTo uint64) {
 		log.Error("Error while rewinding the chain", "to", rewindTo, "err", err)
 	} else {
 		rewindLengthMeter.Mark(int64(head - rewindTo))
+
+		eth.blockchain.PostFinalizedHeadEvent(core.FinalizedHeadEvent{
+			Type:       core.FinalityRollback,
+			StartBlock: rewindTo + 1,
+			EndBlock:   head,
+			Header:     eth.blockchain.CurrentHeader(),
+		})
 	}
 }
 
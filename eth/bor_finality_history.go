@@ -0,0 +1,149 @@
+package eth
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// maxCheckpointNumberLookups is the maximum number of checkpoints fetched from heimdall
+// to find out the number of a checkpoint which doesn't follow the last known one
+const maxCheckpointNumberLookups = 4
+
+// newCheckpointRecord returns the record of the checkpoint in the checkpoint history, nil if
+// it's already recorded. Heimdall serves the latest checkpoint without its number, which is
+// hence derived from the previous checkpoint in the history or looked up on heimdall if the
+// checkpoints aren't contiguous.
+func newCheckpointRecord(ctx context.Context, db ethdb.Database, client bor.IHeimdallClient, checkpoint *checkpoint.Checkpoint) *rawdb.FinalityRecord {
+	if rawdb.ReadCheckpointRecordByEndBlock(db, checkpoint.EndBlock) != nil {
+		return nil
+	}
+
+	record := &rawdb.FinalityRecord{
+		StartBlock: checkpoint.StartBlock,
+		EndBlock:   checkpoint.EndBlock,
+		Hash:       checkpoint.RootHash,
+		Proposer:   checkpoint.Proposer,
+		Timestamp:  checkpoint.Timestamp,
+		ReceivedAt: uint64(time.Now().Unix()),
+	}
+
+	if last := rawdb.ReadLastCheckpointRecord(db); last != nil && last.Number != 0 && last.EndBlock+1 == checkpoint.StartBlock {
+		record.Number = last.Number + 1
+	} else {
+		record.Number = lookupCheckpointNumber(ctx, client, checkpoint)
+	}
+
+	return record
+}
+
+// writeCheckpointHistory adds the record of a processed checkpoint to the checkpoint history
+func writeCheckpointHistory(db ethdb.Database, record *rawdb.FinalityRecord) {
+	rawdb.WriteCheckpointRecord(db, record)
+
+	log.Debug("Added checkpoint to history", "number", record.Number, "start", record.StartBlock, "end", record.EndBlock)
+}
+
+// lookupCheckpointNumber finds the number of the checkpoint among the latest checkpoints on
+// heimdall. It returns 0 if the checkpoint isn't found.
+func lookupCheckpointNumber(ctx context.Context, client bor.IHeimdallClient, checkpoint *checkpoint.Checkpoint) uint64 {
+	if client == nil {
+		return 0
+	}
+
+	count, err := client.FetchCheckpointCount(ctx)
+	if err != nil {
+		log.Debug("Failed to fetch checkpoint count", "err", err)
+		return 0
+	}
+
+	for number := count; number > 0 && number > count-maxCheckpointNumberLookups; number-- {
+		candidate, err := client.FetchCheckpoint(ctx, number)
+		if err != nil {
+			log.Debug("Failed to fetch checkpoint", "number", number, "err", err)
+			return 0
+		}
+
+		if candidate.StartBlock == checkpoint.StartBlock && candidate.EndBlock == checkpoint.EndBlock {
+			return uint64(number)
+		}
+	}
+
+	return 0
+}
+
+// writeMilestoneHistory adds the processed milestone to the milestone history along with
+// the outcome of its verification. The outcome of a milestone already in the history is
+// updated, as a deferred milestone is processed again until the chain catches up.
+func writeMilestoneHistory(db ethdb.Database, milestone *milestone.Milestone, outcome string) {
+	record := rawdb.ReadMilestoneRecordByEndBlock(db, milestone.EndBlock)
+	if record != nil && record.Outcome == outcome {
+		return
+	}
+
+	if record == nil {
+		record = &rawdb.FinalityRecord{
+			ID:         milestone.MilestoneID,
+			StartBlock: milestone.StartBlock,
+			EndBlock:   milestone.EndBlock,
+			Hash:       milestone.Hash,
+			Proposer:   milestone.Proposer,
+			Timestamp:  milestone.Timestamp,
+			ReceivedAt: uint64(time.Now().Unix()),
+		}
+	}
+
+	record.Outcome = outcome
+
+	rawdb.WriteMilestoneRecord(db, record)
+
+	log.Debug("Added milestone to history", "id", milestone.MilestoneID, "start", milestone.StartBlock, "end", milestone.EndBlock, "outcome", outcome)
+}
+
+// notifyFinalized returns the callback posting the finality of the blocks of processed
+// milestones and checkpoints on the chain, along with the block range and the id of the
+// milestone or the number of the checkpoint recorded in the history. The checkpoint being
+// processed is only recorded once processed, hence its record is taken from pending.
+func notifyFinalized(chain *core.BlockChain, db ethdb.Database, pending *atomic.Pointer[rawdb.FinalityRecord]) whitelist.FinalityNotifier {
+	return func(isMilestone bool, number uint64, hash common.Hash) {
+		ev := core.FinalizedHeadEvent{
+			Type:     core.FinalizedByCheckpoint,
+			EndBlock: number,
+		}
+
+		if isMilestone {
+			ev.Type = core.FinalizedByMilestone
+
+			if record := rawdb.ReadMilestoneRecordByEndBlock(db, number); record != nil {
+				ev.MilestoneID = record.ID
+				ev.StartBlock = record.StartBlock
+			}
+		} else {
+			record := pending.Load()
+			if record == nil || record.EndBlock != number {
+				record = rawdb.ReadCheckpointRecordByEndBlock(db, number)
+			}
+
+			if record != nil {
+				ev.CheckpointNumber = record.Number
+				ev.StartBlock = record.StartBlock
+			}
+		}
+
+		if header := chain.GetHeaderByHash(hash); header != nil && header.Number.Uint64() == number {
+			ev.Header = header
+		}
+
+		chain.PostFinalizedHeadEvent(ev)
+	}
+}
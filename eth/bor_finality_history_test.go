@@ -0,0 +1,82 @@
+package eth
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+func TestMilestoneHistoryOutcome(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+	m := &milestone.Milestone{
+		MilestoneID: "milestone-1",
+		StartBlock:  1,
+		EndBlock:    16,
+		Hash:        common.HexToHash("0x01"),
+	}
+
+	// A milestone ahead of the chain is recorded before it can be whitelisted
+	writeMilestoneHistory(db, m, rawdb.MilestoneDeferred)
+
+	record := rawdb.ReadMilestoneRecord(db, m.MilestoneID)
+	require.NotNil(t, record, "deferred milestone not recorded")
+	require.Equal(t, rawdb.MilestoneDeferred, record.Outcome)
+
+	receivedAt := record.ReceivedAt
+
+	// Its outcome is updated once the chain caught up, keeping the time it was received
+	writeMilestoneHistory(db, m, rawdb.MilestoneWhitelisted)
+
+	record = rawdb.FindMilestoneRecord(db, 10)
+	require.NotNil(t, record, "whitelisted milestone not found")
+	require.Equal(t, rawdb.MilestoneWhitelisted, record.Outcome)
+	require.Equal(t, receivedAt, record.ReceivedAt)
+
+	// Mismatching milestones are recorded too
+	m = &milestone.Milestone{MilestoneID: "milestone-2", StartBlock: 17, EndBlock: 32}
+	writeMilestoneHistory(db, m, rawdb.MilestoneMismatch)
+
+	record = rawdb.ReadMilestoneRecordByEndBlock(db, 32)
+	require.NotNil(t, record, "mismatching milestone not recorded")
+	require.Equal(t, rawdb.MilestoneMismatch, record.Outcome)
+}
+
+func TestNotifyFinalizedPendingCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandlerWithBlocks(16)
+	defer handler.close()
+
+	var (
+		chain   = handler.chain
+		db      = handler.handler.database
+		pending atomic.Pointer[rawdb.FinalityRecord]
+		events  = make(chan core.FinalizedHeadEvent, 1)
+	)
+
+	sub := chain.SubscribeFinalizedHeadEvent(events)
+	defer sub.Unsubscribe()
+
+	notify := notifyFinalized(chain, db, &pending)
+	header := chain.GetHeaderByNumber(16)
+
+	// The checkpoint being processed is notified from its pending record, which isn't
+	// in the history until the checkpoint is processed
+	pending.Store(&rawdb.FinalityRecord{Number: 5, StartBlock: 1, EndBlock: 16})
+	notify(false, 16, header.Hash())
+
+	ev := <-events
+	require.Equal(t, core.FinalizedByCheckpoint, ev.Type)
+	require.Equal(t, uint64(5), ev.CheckpointNumber)
+	require.Equal(t, uint64(1), ev.StartBlock)
+	require.Equal(t, header.Hash(), ev.Header.Hash())
+	require.Nil(t, rawdb.ReadCheckpointRecordByEndBlock(db, 16))
+}
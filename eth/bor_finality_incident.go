@@ -0,0 +1,97 @@
+package eth
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// maxIncidentHeaders is the maximum number of headers of each branch recorded in a
+// finality incident, the ones closest to the end of the milestone or checkpoint
+const maxIncidentHeaders = 64
+
+// recordFinalityIncident persists the evidence of a mismatch between the local chain and
+// a milestone or checkpoint before the local branch is rewound: the local headers up to
+// the end block along with their signers and the peers which served them, and the
+// finalized headers if the finalized branch is known locally.
+func recordFinalityIncident(eth *Ethereum, kind string, start uint64, end uint64, expected string, local string,
+	head uint64, rewindTo uint64, finalized []*types.Block) {
+	incident := &rawdb.FinalityIncident{
+		Time:       uint64(time.Now().Unix()),
+		Kind:       kind,
+		StartBlock: start,
+		EndBlock:   end,
+		Expected:   common.HexToHash(expected),
+		Local:      common.HexToHash(local),
+		Head:       head,
+		RewindTo:   rewindTo,
+	}
+
+	last := end
+	if head < last {
+		last = head
+	}
+
+	first := rewindTo + 1
+	if last >= first+maxIncidentHeaders {
+		first = last - maxIncidentHeaders + 1
+	}
+
+	peers := make(map[string]struct{})
+
+	for number := first; number <= last; number++ {
+		header := eth.blockchain.GetHeaderByNumber(number)
+		if header == nil {
+			break
+		}
+
+		entry := newIncidentHeader(eth, header)
+		if entry.Peer != "" {
+			peers[entry.Peer] = struct{}{}
+		}
+
+		incident.LocalHeaders = append(incident.LocalHeaders, entry)
+	}
+
+	if len(finalized) > maxIncidentHeaders {
+		finalized = finalized[len(finalized)-maxIncidentHeaders:]
+	}
+
+	for _, block := range finalized {
+		incident.FinalizedHeaders = append(incident.FinalizedHeaders, newIncidentHeader(eth, block.Header()))
+	}
+
+	incident.Peers = make([]string, 0, len(peers))
+	for peer := range peers {
+		incident.Peers = append(incident.Peers, peer)
+	}
+
+	sort.Strings(incident.Peers)
+
+	// The milestone and checkpoint verifiers record incidents concurrently, while ids are
+	// allocated from the latest one in the database
+	eth.incidentLock.Lock()
+	rawdb.WriteFinalityIncident(eth.ChainDb(), incident)
+	eth.incidentLock.Unlock()
+
+	log.Warn("Recorded finality incident", "id", incident.ID, "kind", kind, "end", end, "rewindTo", rewindTo, "peers", len(incident.Peers))
+}
+
+func newIncidentHeader(eth *Ethereum, header *types.Header) *rawdb.FinalityIncidentHeader {
+	entry := &rawdb.FinalityIncidentHeader{
+		Number:     header.Number.Uint64(),
+		Hash:       header.Hash(),
+		ParentHash: header.ParentHash,
+		Peer:       eth.handler.origins.peer(header.Hash()),
+	}
+
+	if signer, err := eth.engine.Author(header); err == nil {
+		entry.Signer = signer
+	}
+
+	return entry
+}
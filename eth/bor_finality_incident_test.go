@@ -0,0 +1,47 @@
+package eth
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+func TestFinalityIncidentConcurrentIDs(t *testing.T) {
+	t.Parallel()
+
+	eth := &Ethereum{chainDb: rawdb.NewMemoryDatabase()}
+
+	// The milestone and checkpoint verifiers record incidents concurrently
+	const incidents = 64
+
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+	)
+
+	for i := 0; i < incidents; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			<-start
+			recordFinalityIncident(eth, "milestone", uint64(i)*16+1, uint64(i+1)*16, "0x01", "0x02", 0, 0, nil)
+		}(i)
+	}
+
+	close(start)
+	wg.Wait()
+
+	require.Equal(t, uint64(incidents), rawdb.ReadLastFinalityIncidentID(eth.ChainDb()))
+
+	ends := make(map[uint64]struct{})
+	for _, incident := range rawdb.ReadFinalityIncidents(eth.ChainDb(), incidents) {
+		ends[incident.EndBlock] = struct{}{}
+	}
+
+	require.Len(t, ends, incidents, "incidents overwritten by concurrent writes")
+}
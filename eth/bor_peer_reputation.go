@@ -0,0 +1,242 @@
+package eth
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// peerReputationPruneInterval is the interval at which the expired failures are pruned
+	peerReputationPruneInterval = time.Hour
+
+	// latestMilestoneMaxAge is the age after which the latest milestone fetched by the
+	// whitelist service no longer confirms the local milestone is current
+	latestMilestoneMaxAge = time.Minute
+)
+
+var (
+	peerPenalizedMeter = metrics.NewRegisteredMeter("eth/reputation/penalized", nil)
+	peerBannedMeter    = metrics.NewRegisteredMeter("eth/reputation/banned", nil)
+)
+
+// isWhitelistFailure returns whether a sync cycle failed because the peer served a chain
+// conflicting with the whitelisted milestones and checkpoints, the locked sprint or the
+// future milestones. Chains which only reach too far past the last milestone aren't
+// counted, as honest peers ahead of a lagging heimdall serve them too.
+func isWhitelistFailure(err error) bool {
+	return errors.Is(err, whitelist.ErrMismatch) ||
+		errors.Is(err, whitelist.ErrLockedSprintMismatch) ||
+		errors.Is(err, whitelist.ErrFutureMilestoneMismatch)
+}
+
+// penalizeSyncPeer records a chain conflicting with the whitelist served by the sync peer,
+// removing the peer once banned. Trusted and static peers are never penalized, and the
+// others only once the local milestone is confirmed current, as honest peers conflict
+// with a stale whitelist too.
+func (h *handler) penalizeSyncPeer(peer *eth.Peer, reason error) {
+	if peer.IsTrusted() || peer.IsStatic() {
+		log.Debug("Not penalizing trusted peer serving a conflicting chain", "peer", peer.ID(), "err", reason)
+		return
+	}
+
+	if !h.whitelistCurrent() {
+		log.Debug("Not penalizing peer serving a conflicting chain, whitelist not current", "peer", peer.ID(), "err", reason)
+		return
+	}
+
+	if h.reputation.penalize(peer.ID(), reason) {
+		h.removePeer(peer.ID())
+	}
+}
+
+// fetchedMilestone is the latest milestone fetched from heimdall by the whitelist service
+type fetchedMilestone struct {
+	milestone *milestone.Milestone
+	time      time.Time
+}
+
+// whitelistCurrent returns whether the local whitelisted milestone is the latest milestone
+// fetched from heimdall by the whitelist service, as long as it was fetched recently. The
+// sync loop isn't held up by a request to heimdall.
+func (h *handler) whitelistCurrent() bool {
+	if h.downloader.ChainValidator == nil {
+		return false
+	}
+
+	latest := h.latestMilestone.Load()
+	if latest == nil || time.Since(latest.time) > latestMilestoneMaxAge {
+		return false
+	}
+
+	exists, number, hash := h.downloader.GetWhitelistedMilestone()
+
+	return exists && number == latest.milestone.EndBlock && hash == latest.milestone.Hash
+}
+
+// PeerReputation is the reputation of a peer as reported by admin_peerReputation.
+type PeerReputation struct {
+	ID          string    `json:"id"`
+	Failures    uint64    `json:"failures"`
+	LastFailure time.Time `json:"lastFailure"`
+	LastReason  string    `json:"lastReason"`
+	Banned      bool      `json:"banned"`
+	Expiry      time.Time `json:"expiry"` // Time the failures are forgiven at
+}
+
+// peerReputation tracks the peers serving chains which conflict with the whitelisted
+// milestones and checkpoints. The failures are persisted, for the peers to be known
+// across restarts, and forgiven once the peer behaves for long enough.
+type peerReputation struct {
+	db        ethdb.KeyValueStore
+	threshold uint64        // Number of conflicting chains after which a peer is banned
+	expiry    time.Duration // Time after the last conflicting chain after which the failures are forgiven
+	peers     map[string]*rawdb.PeerReputation
+	lock      sync.RWMutex
+}
+
+func newPeerReputation(db ethdb.KeyValueStore, threshold uint64, expiry time.Duration) *peerReputation {
+	r := &peerReputation{
+		db:        db,
+		threshold: threshold,
+		expiry:    expiry,
+		peers:     make(map[string]*rawdb.PeerReputation),
+	}
+
+	for _, reputation := range rawdb.ReadPeerReputations(db) {
+		r.peers[reputation.ID] = reputation
+	}
+
+	r.prune()
+
+	return r
+}
+
+// expired returns whether the failures of a peer are forgiven.
+func (r *peerReputation) expired(reputation *rawdb.PeerReputation) bool {
+	return time.Since(reputation.LastFailure) >= r.expiry
+}
+
+// prune drops the peers whose failures are forgiven.
+func (r *peerReputation) prune() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for id, reputation := range r.peers {
+		if r.expired(reputation) {
+			rawdb.DeletePeerReputation(r.db, id)
+			delete(r.peers, id)
+		}
+	}
+}
+
+// pruneLoop periodically drops the peers whose failures are forgiven until quit is closed.
+func (r *peerReputation) pruneLoop(quit chan struct{}) {
+	ticker := time.NewTicker(peerReputationPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.prune()
+		case <-quit:
+			return
+		}
+	}
+}
+
+// penalize records a conflicting chain served by a peer and returns whether the peer is
+// banned as a result.
+func (r *peerReputation) penalize(id string, reason error) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	reputation, ok := r.peers[id]
+	if !ok || r.expired(reputation) {
+		reputation = &rawdb.PeerReputation{ID: id}
+		r.peers[id] = reputation
+	}
+
+	reputation.Failures++
+	reputation.LastFailure = time.Now()
+	reputation.LastReason = reason.Error()
+
+	rawdb.WritePeerReputation(r.db, reputation)
+	peerPenalizedMeter.Mark(1)
+
+	banned := reputation.Failures >= r.threshold
+	if banned {
+		peerBannedMeter.Mark(1)
+		log.Warn("Banning peer serving conflicting chains", "peer", id, "failures", reputation.Failures, "err", reason)
+	} else {
+		log.Info("Penalized peer serving a conflicting chain", "peer", id, "failures", reputation.Failures, "err", reason)
+	}
+
+	return banned
+}
+
+// failures returns the number of conflicting chains served by a peer which aren't
+// forgiven yet.
+func (r *peerReputation) failures(id string) uint64 {
+	if r == nil {
+		return 0
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	reputation, ok := r.peers[id]
+	if !ok || r.expired(reputation) {
+		return 0
+	}
+
+	return reputation.Failures
+}
+
+// banned returns whether a peer served too many conflicting chains to be connected to.
+func (r *peerReputation) banned(id string) bool {
+	if r == nil {
+		return false
+	}
+
+	return r.failures(id) >= r.threshold
+}
+
+// list returns the reputation of the peers whose failures aren't forgiven yet, the most
+// recently penalized first.
+func (r *peerReputation) list() []*PeerReputation {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	list := make([]*PeerReputation, 0, len(r.peers))
+
+	for _, reputation := range r.peers {
+		if r.expired(reputation) {
+			continue
+		}
+
+		list = append(list, &PeerReputation{
+			ID:          reputation.ID,
+			Failures:    reputation.Failures,
+			LastFailure: reputation.LastFailure,
+			LastReason:  reputation.LastReason,
+			Banned:      reputation.Failures >= r.threshold,
+			Expiry:      reputation.LastFailure.Add(r.expiry),
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].LastFailure.After(list[j].LastFailure)
+	})
+
+	return list
+}
@@ -0,0 +1,173 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestPeerReputation(t *testing.T) {
+	t.Parallel()
+
+	const (
+		threshold = 3
+		expiry    = time.Hour
+	)
+
+	db := rawdb.NewMemoryDatabase()
+	reputation := newPeerReputation(db, threshold, expiry)
+
+	require.False(t, isWhitelistFailure(fmt.Errorf("timeout")))
+	require.False(t, isWhitelistFailure(fmt.Errorf("invalid chain: %w", whitelist.ErrLongFutureChain)))
+	require.True(t, isWhitelistFailure(fmt.Errorf("invalid chain: %w", whitelist.ErrMismatch)))
+	require.True(t, isWhitelistFailure(fmt.Errorf("invalid chain: %w", whitelist.ErrLockedSprintMismatch)))
+	require.True(t, isWhitelistFailure(fmt.Errorf("invalid chain: %w", whitelist.ErrFutureMilestoneMismatch)))
+
+	for i := 1; i < threshold; i++ {
+		require.False(t, reputation.penalize("a", whitelist.ErrMismatch))
+		require.Equal(t, uint64(i), reputation.failures("a"))
+	}
+
+	require.False(t, reputation.banned("a"))
+	require.True(t, reputation.penalize("a", whitelist.ErrMismatch))
+	require.True(t, reputation.banned("a"))
+
+	// The failures are kept across restarts
+	reputation = newPeerReputation(db, threshold, expiry)
+	require.True(t, reputation.banned("a"))
+
+	list := reputation.list()
+	require.Len(t, list, 1)
+	require.Equal(t, uint64(threshold), list[0].Failures)
+	require.Equal(t, whitelist.ErrMismatch.Error(), list[0].LastReason)
+	require.True(t, list[0].Banned)
+	require.Equal(t, list[0].LastFailure.Add(expiry), list[0].Expiry)
+
+	// The ban follows the configured threshold
+	require.False(t, newPeerReputation(db, threshold+1, expiry).banned("a"))
+
+	// The failures are forgiven once expired, lifting the ban
+	stale := rawdb.ReadPeerReputation(db, "a")
+	stale.LastFailure = time.Now().Add(-expiry)
+	rawdb.WritePeerReputation(db, stale)
+
+	reputation = newPeerReputation(db, threshold, expiry)
+	require.False(t, reputation.banned("a"))
+	require.Empty(t, reputation.list())
+	require.Nil(t, rawdb.ReadPeerReputation(db, "a"))
+
+	// The expired failures are pruned while running as well
+	require.False(t, reputation.penalize("b", whitelist.ErrMismatch))
+	require.NotNil(t, rawdb.ReadPeerReputation(db, "b"))
+
+	reputation.lock.Lock()
+	reputation.peers["b"].LastFailure = time.Now().Add(-expiry)
+	reputation.lock.Unlock()
+
+	reputation.prune()
+	require.Empty(t, reputation.peers)
+	require.Nil(t, rawdb.ReadPeerReputation(db, "b"))
+}
+
+func TestPenalizeSyncPeerStaleWhitelist(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler()
+	defer handler.close()
+
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+	defer net.Close()
+
+	peer := eth.NewPeer(eth.ETH68, p2p.NewPeer(enode.ID{1}, "", nil), app, nil)
+	defer peer.Close()
+
+	// Without heimdall to confirm the local milestone is current, peers aren't penalized
+	handler.handler.penalizeSyncPeer(peer, whitelist.ErrMismatch)
+	require.Zero(t, handler.handler.reputation.failures(peer.ID()))
+}
+
+func TestPenalizeSyncPeerFetchedMilestone(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db      = rawdb.NewMemoryDatabase()
+		gspec   = &core.Genesis{Config: params.TestChainConfig}
+		checker = whitelist.NewService(db)
+	)
+
+	chain, err := core.NewBlockChain(db, nil, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, checker)
+	require.NoError(t, err)
+	defer chain.Stop()
+
+	h, err := newHandler(&handlerConfig{
+		Database: db,
+		Chain:    chain,
+		TxPool:   newTestTxPool(),
+		Network:  1,
+		checker:  checker,
+	})
+	require.NoError(t, err)
+
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+	defer net.Close()
+
+	peer := eth.NewPeer(eth.ETH68, p2p.NewPeer(enode.ID{1}, "", nil), app, nil)
+	defer peer.Close()
+
+	hash := common.HexToHash("0x01")
+	checker.ProcessMilestone(100, hash)
+
+	// Until the whitelist service fetched a milestone, peers aren't penalized
+	h.penalizeSyncPeer(peer, whitelist.ErrMismatch)
+	require.Zero(t, h.reputation.failures(peer.ID()))
+
+	// Nor while the local milestone is behind the latest fetched one
+	h.latestMilestone.Store(&fetchedMilestone{milestone: &milestone.Milestone{EndBlock: 200, Hash: hash}, time: time.Now()})
+	h.penalizeSyncPeer(peer, whitelist.ErrMismatch)
+	require.Zero(t, h.reputation.failures(peer.ID()))
+
+	// Nor once the latest fetched milestone is too old to tell
+	h.latestMilestone.Store(&fetchedMilestone{milestone: &milestone.Milestone{EndBlock: 100, Hash: hash}, time: time.Now().Add(-latestMilestoneMaxAge - time.Second)})
+	h.penalizeSyncPeer(peer, whitelist.ErrMismatch)
+	require.Zero(t, h.reputation.failures(peer.ID()))
+
+	// The peer is penalized once the local milestone is the latest one fetched
+	h.latestMilestone.Store(&fetchedMilestone{milestone: &milestone.Milestone{EndBlock: 100, Hash: hash}, time: time.Now()})
+	h.penalizeSyncPeer(peer, whitelist.ErrMismatch)
+	require.Equal(t, uint64(1), h.reputation.failures(peer.ID()))
+}
+
+func TestBetterSyncPeer(t *testing.T) {
+	t.Parallel()
+
+	local := big.NewInt(100)
+
+	// Among the peers ahead, the fewest failures win over the highest total difficulty
+	require.True(t, betterSyncPeer(local, big.NewInt(110), 0, big.NewInt(200), 1))
+	require.False(t, betterSyncPeer(local, big.NewInt(200), 1, big.NewInt(110), 0))
+	require.True(t, betterSyncPeer(local, big.NewInt(200), 0, big.NewInt(110), 0))
+
+	// A peer ahead always wins over a peer which isn't, whatever its failures
+	require.True(t, betterSyncPeer(local, big.NewInt(110), 2, big.NewInt(90), 0))
+	require.False(t, betterSyncPeer(local, big.NewInt(90), 0, big.NewInt(110), 2))
+
+	// The failures of the peers which aren't ahead don't matter
+	require.True(t, betterSyncPeer(local, big.NewInt(95), 2, big.NewInt(90), 0))
+}
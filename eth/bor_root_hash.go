@@ -0,0 +1,26 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core"
+)
+
+// indexRootHashes persists the root hash leaves and subtrees of the blocks as they become
+// canonical, one batch per new chain head, until the node is stopped.
+func (s *Ethereum) indexRootHashes(engine *bor.Bor) {
+	headCh := make(chan core.ChainHeadEvent, 10)
+	sub := s.blockchain.SubscribeChainHeadEvent(headCh)
+
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case ev := <-headCh:
+			engine.IndexRootHashes(s.blockchain, ev.Header)
+		case <-sub.Err():
+			return
+		case <-s.closeCh:
+			return
+		}
+	}
+}
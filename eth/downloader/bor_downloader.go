@@ -80,6 +80,8 @@ var (
 	errTooOld                  = errors.New("peer's protocol version too old")
 	errNoAncestorFound         = errors.New("no common ancestor found")
 	errNoPivotHeader           = errors.New("pivot header is not found")
+	errNoFinalityAnchor        = errors.New("no milestone or checkpoint to anchor the sync at")
+	errNoSpanVerifier          = errors.New("milestone sync requires a span signer verifier")
 	ErrMergeTransition         = errors.New("legacy sync reached the merge")
 )
 
@@ -95,6 +97,9 @@ type badBlockFn func(invalid *types.Header, origin *types.Header)
 type headerTask struct {
 	headers []*types.Header
 	hashes  []common.Hash
+	peers   []string // Peers which delivered the headers, nil if unknown
+
+	verified bool // Whether the headers were verified against the milestone sync anchor
 }
 
 type Downloader struct {
@@ -118,6 +123,8 @@ type Downloader struct {
 	dropPeer peerDropFn // Drops a peer for misbehaving
 	badBlock badBlockFn // Reports a block as rejected by the chain
 
+	recordOrigin func(hash common.Hash, peer string) // Records the peer which delivered an accepted header
+
 	// Status
 	synchroniseMock func(id string, hash common.Hash) error // Replacement for synchronise during testing
 	synchronising   atomic.Bool
@@ -135,6 +142,10 @@ type Downloader struct {
 	pivotHeader *types.Header // Pivot block header to dynamically push the syncing state root
 	pivotLock   sync.RWMutex  // Lock protecting pivot header reads from updates
 
+	// Milestone sync
+	anchor       *finalityAnchor    // Finalized block the pivot is anchored at (per sync cycle), nil if not anchored
+	spanVerifier SpanSignerVerifier // Verifier of the headers up to the anchor against the span signers
+
 	SnapSyncer     *snap.Syncer // TODO(karalabe): make private! hack for now
 	stateSyncStart chan *stateSync
 
@@ -180,6 +191,11 @@ type LightChain interface {
 	// InsertHeaderChain inserts a batch of headers into the local chain.
 	InsertHeaderChain([]*types.Header) (int, error)
 
+	// InsertVerifiedHeaderChain inserts a batch of headers linked to a finalized block
+	// into the local chain, verifying them with the given function instead of the
+	// consensus engine.
+	InsertVerifiedHeaderChain([]*types.Header, func(*types.Header) error) (int, error)
+
 	// SetHead rewinds the local chain to a new head.
 	SetHead(uint64) error
 }
@@ -253,6 +269,12 @@ func New(stateDb ethdb.Database, mux *event.TypeMux, chain BlockChain, lightchai
 	return dl
 }
 
+// SetOriginRecorder sets the callback invoked with the peer which delivered each header
+// accepted for import, for the synced blocks to be traced back to the peers serving them.
+func (d *Downloader) SetOriginRecorder(record func(hash common.Hash, peer string)) {
+	d.recordOrigin = record
+}
+
 // Progress retrieves the synchronisation boundaries, specifically the origin
 // block where synchronisation started at (may have failed/suspended); the block
 // or header sync is currently at; and the latest known block which the sync targets.
@@ -375,6 +397,11 @@ func (d *Downloader) LegacySync(id string, head common.Hash, td, ttd *big.Int, m
 		return err // This is an expected fault, don't keep printing it in a spin-loop
 	}
 
+	if errors.Is(err, errNoFinalityAnchor) {
+		log.Debug("Milestone sync waiting for a milestone or checkpoint", "peer", id)
+		return err
+	}
+
 	// Warn in case of any error thrown by whitelisting module
 	if errors.Is(err, whitelist.ErrNoRemote) || errors.Is(err, whitelist.ErrMismatch) {
 		log.Warn("Synchronisation failed due to whitelist validation", "peer", id, "err", err)
@@ -418,6 +445,24 @@ func (d *Downloader) synchronise(id string, hash common.Hash, td, ttd *big.Int,
 	if d.notified.CompareAndSwap(false, true) {
 		log.Info("Block synchronisation started")
 	}
+	// Milestone sync is a snap sync whose pivot is anchored at the latest block finalized
+	// by a milestone or a checkpoint, instead of an arbitrary block which may be reorged.
+	// Fall back to full sync if the finalized blocks are already part of the local chain.
+	d.anchor = nil
+
+	if mode == MilestoneSync {
+		anchor, err := d.finalityAnchor()
+		if err != nil {
+			return err
+		}
+
+		if d.anchor = anchor; anchor != nil {
+			mode = SnapSync
+		} else {
+			mode = FullSync
+		}
+	}
+
 	if mode == SnapSync {
 		// Snap sync will directly modify the persistent state, making the entire
 		// trie database unusable until the state is fully synced. To prevent any
@@ -519,6 +564,12 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td, ttd *
 		if err != nil {
 			return err
 		}
+
+		if d.anchor != nil {
+			if pivot, err = d.fetchAnchor(p, latest); err != nil {
+				return err
+			}
+		}
 	} else {
 		// In beacon mode, use the skeleton chain to retrieve the headers from
 		latest, _, final, err = d.skeleton.Bounds()
@@ -658,6 +709,13 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td, ttd *
 			}
 		}
 	}
+	// Milestone sync downloads the headers up to the anchor backwards from it, which verifies
+	// them by hash, instead of verifying them against the consensus engine
+	if d.anchor != nil {
+		if err := d.fetchAnchoredHeaders(p, pivot, origin); err != nil {
+			return err
+		}
+	}
 	// Initiate the sync using a concurrent header and content retrieval algorithm
 	d.queue.Prepare(origin+1, mode)
 
@@ -1104,6 +1162,15 @@ func (d *Downloader) fetchHeaders(p *peerConnection, from uint64, head uint64) e
 		pivoting = false // Whether the next request is pivot verification
 		ancestor = from
 	)
+	// The headers up to the milestone sync anchor were already downloaded backwards from
+	// it, schedule them for import and pull the ones above it only
+	if d.anchor != nil && from <= d.anchor.number {
+		if err := d.feedAnchoredHeaders(p, from); err != nil {
+			return err
+		}
+
+		from = d.anchor.number + 1
+	}
 
 	for {
 		// Pull the next batch of headers, it either:
@@ -1113,6 +1180,7 @@ func (d *Downloader) fetchHeaders(p *peerConnection, from uint64, head uint64) e
 		var (
 			headers []*types.Header
 			hashes  []common.Hash
+			peers   []string
 			err     error
 		)
 
@@ -1238,7 +1306,7 @@ func (d *Downloader) fetchHeaders(p *peerConnection, from uint64, head uint64) e
 		var progressed bool
 
 		if skeleton {
-			filled, hashset, proced, err := d.fillHeaderSkeleton(from, headers)
+			filled, hashset, peerset, proced, err := d.fillHeaderSkeleton(from, headers)
 			if err != nil {
 				p.log.Debug("Skeleton chain invalid", "err", err)
 				return fmt.Errorf("%w: %v", errInvalidChain, err)
@@ -1246,6 +1314,7 @@ func (d *Downloader) fetchHeaders(p *peerConnection, from uint64, head uint64) e
 
 			headers = filled[proced:]
 			hashes = hashset[proced:]
+			peers = peerset[proced:]
 
 			progressed = proced > 0
 			from += uint64(proced)
@@ -1283,6 +1352,11 @@ func (d *Downloader) fetchHeaders(p *peerConnection, from uint64, head uint64) e
 					hashes = hashes[:n-delay]
 				}
 			}
+			// Headers fetched directly were delivered by the origin
+			peers = make([]string, len(headers))
+			for i := range peers {
+				peers[i] = p.id
+			}
 		}
 		// If no headers have been delivered, or all of them have been delayed,
 		// sleep a bit and retry. Take care with headers already consumed during
@@ -1303,6 +1377,7 @@ func (d *Downloader) fetchHeaders(p *peerConnection, from uint64, head uint64) e
 			case d.headerProcCh <- &headerTask{
 				headers: headers,
 				hashes:  hashes,
+				peers:   peers,
 			}:
 			case <-d.cancelCh:
 				return errCanceled
@@ -1311,8 +1386,9 @@ func (d *Downloader) fetchHeaders(p *peerConnection, from uint64, head uint64) e
 			from += uint64(len(headers))
 		}
 		// If we're still skeleton filling snap sync, check pivot staleness
-		// before continuing to the next skeleton filling
-		if skeleton && pivot > 0 {
+		// before continuing to the next skeleton filling. The pivot of milestone
+		// sync is anchored at a finalized block, never moved.
+		if skeleton && pivot > 0 && d.anchor == nil {
 			pivoting = true
 		}
 	}
@@ -1327,7 +1403,7 @@ func (d *Downloader) fetchHeaders(p *peerConnection, from uint64, head uint64) e
 //
 // The method returns the entire filled skeleton and also the number of headers
 // already forwarded for processing.
-func (d *Downloader) fillHeaderSkeleton(from uint64, skeleton []*types.Header) ([]*types.Header, []common.Hash, int, error) {
+func (d *Downloader) fillHeaderSkeleton(from uint64, skeleton []*types.Header) ([]*types.Header, []common.Hash, []string, int, error) {
 	log.Debug("Filling up skeleton", "from", from)
 	d.queue.ScheduleSkeleton(from, skeleton)
 
@@ -1336,12 +1412,12 @@ func (d *Downloader) fillHeaderSkeleton(from uint64, skeleton []*types.Header) (
 		log.Debug("Skeleton fill failed", "err", err)
 	}
 
-	filled, hashes, proced := d.queue.RetrieveHeaders()
+	filled, hashes, peers, proced := d.queue.RetrieveHeaders()
 	if err == nil {
 		log.Debug("Skeleton fill succeeded", "filled", len(filled), "processed", proced)
 	}
 
-	return filled, hashes, proced, err
+	return filled, hashes, peers, proced, err
 }
 
 // fetchBodies iteratively downloads the scheduled block bodies, taking any
@@ -1419,7 +1495,7 @@ func (d *Downloader) processHeaders(origin uint64, td, ttd *big.Int, beaconMode
 				return nil
 			}
 			// Otherwise split the chunk of headers into batches and process them
-			headers, hashes := task.headers, task.hashes
+			headers, hashes, peers := task.headers, task.hashes, task.peers
 
 			gotHeaders = true
 
@@ -1439,6 +1515,11 @@ func (d *Downloader) processHeaders(origin uint64, td, ttd *big.Int, beaconMode
 				chunkHeaders := headers[:limit]
 				chunkHashes := hashes[:limit]
 
+				var chunkPeers []string
+				if peers != nil {
+					chunkPeers = peers[:limit]
+				}
+
 				// In case of header only syncing, validate the chunk immediately
 				if mode == SnapSync {
 					// Although the received headers might be all valid, a legacy
@@ -1477,7 +1558,18 @@ func (d *Downloader) processHeaders(origin uint64, td, ttd *big.Int, beaconMode
 					}
 
 					if len(chunkHeaders) > 0 {
-						if n, err := d.lightchain.InsertHeaderChain(chunkHeaders); err != nil {
+						if err := d.checkAnchor(chunkHeaders, chunkHashes); err != nil {
+							return err
+						}
+
+						insert := d.lightchain.InsertHeaderChain
+						if task.verified {
+							insert = func(headers []*types.Header) (int, error) {
+								return d.lightchain.InsertVerifiedHeaderChain(headers, d.spanVerifier.VerifySpanSigner)
+							}
+						}
+
+						if n, err := insert(chunkHeaders); err != nil {
 							log.Warn("Invalid header encountered", "number", chunkHeaders[n].Number, "hash", chunkHashes[n], "parent", chunkHeaders[n].ParentHash, "err", err)
 
 							return fmt.Errorf("%w: %v", errInvalidChain, err)
@@ -1506,9 +1598,19 @@ func (d *Downloader) processHeaders(origin uint64, td, ttd *big.Int, beaconMode
 						return fmt.Errorf("%w: stale headers", errBadPeer)
 					}
 				}
+				// Track the peers which delivered the accepted headers
+				if d.recordOrigin != nil && chunkPeers != nil {
+					for i := range chunkHeaders {
+						d.recordOrigin(chunkHashes[i], chunkPeers[i])
+					}
+				}
 
 				headers = headers[limit:]
 				hashes = hashes[limit:]
+
+				if peers != nil {
+					peers = peers[limit:]
+				}
 				origin += uint64(limit)
 			}
 			// Update the highest block number we know if a higher one is found.
@@ -1641,7 +1743,7 @@ func (d *Downloader) importBlockResults(results []*fetchResult) error {
 			return fmt.Errorf("%v: %w", errInvalidChain, err)
 		}
 
-		return fmt.Errorf("%w: %v", errInvalidChain, err)
+		return fmt.Errorf("%w: %w", errInvalidChain, err)
 	}
 
 	return nil
@@ -1733,8 +1835,9 @@ func (d *Downloader) processSnapSyncContent() error {
 		} else { // results already piled up, consume before handling pivot move
 			results = append(append([]*fetchResult{oldPivot}, oldTail...), results...)
 		}
-		// Split around the pivot block and process the two sides via snap/full sync
-		if !d.committed.Load() {
+		// Split around the pivot block and process the two sides via snap/full sync.
+		// The pivot of milestone sync is anchored at a finalized block, never moved.
+		if !d.committed.Load() && d.anchor == nil {
 			latest := results[len(results)-1].Header
 			// If the height is above the pivot block by 2 sets, it means the pivot
 			// become stale in the network, and it was garbage collected, move to a
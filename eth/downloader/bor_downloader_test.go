@@ -17,6 +17,7 @@
 package downloader
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -1643,3 +1644,151 @@ func TestFakedSyncProgress67BypassWhitelistValidation(t *testing.T) {
 	err := tester.sync("light", nil, mode)
 	assert.NoError(t, err, "failed synchronisation")
 }
+
+// TestMilestoneSync tests that milestone sync anchors the snap sync pivot at the latest
+// whitelisted milestone, and refuses the peers whose chain doesn't lead to it.
+func TestMilestoneSync(t *testing.T) {
+	chain := testChainBase.shorten(blockCacheMaxItems - 15)
+
+	tester := newTester(t)
+	defer tester.terminate()
+
+	tester.newPeer("peer", eth.ETH68, chain.blocks[1:])
+
+	// Milestone sync doesn't run without verifying the headers against the span signers
+	_, err := tester.downloader.finalityAnchor()
+	assert.ErrorIs(t, err, errNoSpanVerifier)
+
+	tester.downloader.SetSpanSignerVerifier(&spanSignerTester{verified: make(map[uint64]bool)})
+
+	// Milestone sync waits for a milestone or a checkpoint to anchor at
+	_, err = tester.downloader.finalityAnchor()
+	assert.ErrorIs(t, err, errNoFinalityAnchor)
+
+	// A milestone conflicting with the peer's chain fails the sync
+	// The milestone is set below the default pivot, within the states kept by the peer
+	anchor := chain.blocks[len(chain.blocks)-100]
+	tester.downloader.ChainValidator.ProcessMilestone(anchor.NumberU64(), common.Hash{0x01})
+
+	err = tester.sync("peer", nil, MilestoneSync)
+	assert.True(t, errors.Is(err, whitelist.ErrMismatch), "unexpected error: %v", err)
+
+	// The state is downloaded at the milestone once it matches the peer's chain
+	tester.downloader.ChainValidator.ProcessMilestone(anchor.NumberU64(), anchor.Hash())
+
+	err = tester.sync("peer", nil, MilestoneSync)
+	assert.NoError(t, err, "failed synchronisation")
+
+	assertOwnChain(t, tester, len(chain.blocks))
+
+	pivot := rawdb.ReadLastPivotNumber(tester.downloader.stateDB)
+	if assert.NotNil(t, pivot) {
+		assert.Equal(t, anchor.NumberU64(), *pivot)
+	}
+}
+
+// Tests that the peer which delivered each header accepted for import is recorded,
+// whether it's the peer synced against or one filling up the skeleton.
+func TestHeaderOrigins(t *testing.T) {
+	tester := newTester(t)
+	defer tester.terminate()
+
+	chain := testChainBase.shorten(800)
+
+	lengths := make(map[string]int)
+
+	for i := 0; i < 4; i++ {
+		id := fmt.Sprintf("peer #%d", i)
+		blocks := chain.shorten(len(chain.blocks) / (i + 1)).blocks[1:]
+
+		tester.newPeer(id, eth.ETH68, blocks)
+		lengths[id] = len(blocks)
+	}
+
+	var (
+		lock    sync.Mutex
+		origins = make(map[common.Hash]string)
+	)
+
+	tester.downloader.SetOriginRecorder(func(hash common.Hash, peer string) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		origins[hash] = peer
+	})
+
+	if err := tester.sync("peer #0", nil, FullSync); err != nil {
+		t.Fatalf("failed to synchronise blocks: %v", err)
+	}
+
+	assertOwnChain(t, tester, len(chain.blocks))
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	for _, block := range chain.blocks[1:] {
+		peer, ok := origins[block.Hash()]
+		if !ok {
+			t.Fatalf("block %d: origin not recorded", block.NumberU64())
+		}
+		// The recorded peer must have been able to deliver the header
+		if block.NumberU64() > uint64(lengths[peer]) {
+			t.Fatalf("block %d: recorded origin %q only has %d blocks", block.NumberU64(), peer, lengths[peer])
+		}
+	}
+}
+
+// spanSignerTester is a span signer verifier tracking the verified headers, and rejecting
+// the header with the given number.
+type spanSignerTester struct {
+	reject   uint64
+	verified map[uint64]bool
+}
+
+func (v *spanSignerTester) VerifySpanSigner(header *types.Header) error {
+	if header.Number.Uint64() == v.reject {
+		return errors.New("unauthorized signer")
+	}
+
+	v.verified[header.Number.Uint64()] = true
+
+	return nil
+}
+
+// TestMilestoneSyncSpanSigners tests that milestone sync verifies the headers below the
+// anchor backwards from it against the span signers.
+func TestMilestoneSyncSpanSigners(t *testing.T) {
+	chain := testChainBase.shorten(blockCacheMaxItems - 15)
+	anchor := chain.blocks[len(chain.blocks)-100]
+
+	// A header below the anchor which isn't signed by a span producer fails the sync
+	tester := newTester(t)
+	defer tester.terminate()
+
+	tester.newPeer("peer", eth.ETH68, chain.blocks[1:])
+	tester.downloader.SetSpanSignerVerifier(&spanSignerTester{reject: 100, verified: make(map[uint64]bool)})
+	tester.downloader.ChainValidator.ProcessMilestone(anchor.NumberU64(), anchor.Hash())
+
+	err := tester.sync("peer", nil, MilestoneSync)
+	assert.True(t, errors.Is(err, errInvalidChain), "unexpected error: %v", err)
+
+	// Every header up to the anchor is verified otherwise
+	tester = newTester(t)
+	defer tester.terminate()
+
+	verifier := &spanSignerTester{verified: make(map[uint64]bool)}
+
+	tester.newPeer("peer", eth.ETH68, chain.blocks[1:])
+	tester.downloader.SetSpanSignerVerifier(verifier)
+	tester.downloader.ChainValidator.ProcessMilestone(anchor.NumberU64(), anchor.Hash())
+
+	err = tester.sync("peer", nil, MilestoneSync)
+	assert.NoError(t, err, "failed synchronisation")
+
+	assertOwnChain(t, tester, len(chain.blocks))
+
+	for number := uint64(1); number <= anchor.NumberU64(); number++ {
+		assert.True(t, verifier.verified[number], "header %d not verified", number)
+		assert.Nil(t, rawdb.ReadSkeletonHeader(tester.downloader.stateDB, number), "header %d not cleaned up", number)
+	}
+}
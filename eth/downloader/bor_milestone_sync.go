@@ -0,0 +1,223 @@
+package downloader
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// SpanSignerVerifier verifies a header without its ancestors, by checking that its
+// signer belongs to the validator set of the span the header is part of.
+type SpanSignerVerifier interface {
+	VerifySpanSigner(header *types.Header) error
+}
+
+// finalityAnchor is the block finalized by the latest milestone or checkpoint, which
+// milestone sync downloads the state at.
+type finalityAnchor struct {
+	number uint64
+	hash   common.Hash
+}
+
+// SetSpanSignerVerifier sets the verifier of the headers milestone sync downloads up to
+// the block it's anchored at. Milestone sync doesn't run without one.
+func (d *Downloader) SetSpanSignerVerifier(verifier SpanSignerVerifier) {
+	d.spanVerifier = verifier
+}
+
+// finalityAnchor returns the latest block finalized by a milestone or a checkpoint, or
+// nil if it isn't ahead of the local chain. An error is returned if no milestone or
+// checkpoint was received yet, for the sync to be retried once there's one.
+func (d *Downloader) finalityAnchor() (*finalityAnchor, error) {
+	// The headers up to the anchor skip the consensus verification, which needs the
+	// state of their ancestors, hence they must be verified against the span signers
+	if d.spanVerifier == nil {
+		return nil, errNoSpanVerifier
+	}
+
+	if d.ChainValidator == nil {
+		return nil, errNoFinalityAnchor
+	}
+
+	var anchor *finalityAnchor
+
+	if exists, number, hash := d.GetWhitelistedMilestone(); exists {
+		anchor = &finalityAnchor{number: number, hash: hash}
+	}
+
+	if exists, number, hash := d.GetWhitelistedCheckpoint(); exists && (anchor == nil || number > anchor.number) {
+		anchor = &finalityAnchor{number: number, hash: hash}
+	}
+
+	if anchor == nil {
+		return nil, errNoFinalityAnchor
+	}
+
+	if anchor.number <= d.blockchain.CurrentBlock().Number.Uint64() {
+		return nil, nil
+	}
+
+	return anchor, nil
+}
+
+// fetchAnchor retrieves the header milestone sync is anchored at from the given peer,
+// whose head is the given one, and verifies it against the span signers.
+func (d *Downloader) fetchAnchor(p *peerConnection, head *types.Header) (*types.Header, error) {
+	anchor := d.anchor
+
+	if head.Number.Uint64() < anchor.number {
+		return nil, fmt.Errorf("%w: head %d is behind the finalized block %d", whitelist.ErrNoRemote, head.Number.Uint64(), anchor.number)
+	}
+
+	headers, hashes, err := d.fetchHeadersByNumber(p, anchor.number, 1, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(headers) == 0 {
+		return nil, fmt.Errorf("%w: finalized block %d not served", whitelist.ErrNoRemote, anchor.number)
+	}
+
+	if headers[0].Number.Uint64() != anchor.number || hashes[0] != anchor.hash {
+		return nil, fmt.Errorf("%w: %w", errInvalidChain, whitelist.ErrMismatch)
+	}
+
+	if err := d.spanVerifier.VerifySpanSigner(headers[0]); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidChain, err)
+	}
+
+	p.log.Debug("Anchored sync at the finalized block", "number", anchor.number, "hash", anchor.hash)
+
+	return headers[0], nil
+}
+
+// fetchAnchoredHeaders downloads the headers between the common ancestor and the anchor
+// header from the given peer, backwards from the anchor. Each header is verified to be the
+// parent of the previous one, which links all of them by hash to the finalized block, and
+// to be signed by a producer of its span. The headers are stored until they're imported.
+func (d *Downloader) fetchAnchoredHeaders(p *peerConnection, anchor *types.Header, origin uint64) error {
+	p.log.Debug("Downloading headers backwards from the finalized block", "number", anchor.Number, "origin", origin)
+
+	rawdb.WriteAnchoredHeader(d.stateDB, anchor)
+
+	var (
+		parent = anchor.ParentHash
+		number = anchor.Number.Uint64()
+	)
+
+	for number > origin+1 {
+		count := min(uint64(MaxHeaderFetch), number-origin-1)
+
+		headers, hashes, err := d.fetchHeadersByHash(p, parent, int(count), 0, true)
+		if err != nil {
+			return err
+		}
+
+		if len(headers) == 0 {
+			return fmt.Errorf("%w: headers below %d not served", errBadPeer, number)
+		}
+
+		batch := d.stateDB.NewBatch()
+
+		for i, header := range headers {
+			if header.Number.Uint64() != number-1 || hashes[i] != parent {
+				p.log.Warn("Header not linked to the finalized block", "number", header.Number, "hash", hashes[i], "want", number-1, "parent", parent)
+				return fmt.Errorf("%w: header %d not linked to the finalized block", errInvalidChain, header.Number)
+			}
+
+			if err := d.spanVerifier.VerifySpanSigner(header); err != nil {
+				p.log.Warn("Header not signed by a span producer", "number", header.Number, "hash", hashes[i], "err", err)
+				return fmt.Errorf("%w: %v", errInvalidChain, err)
+			}
+
+			rawdb.WriteAnchoredHeader(batch, header)
+
+			parent, number = header.ParentHash, number-1
+		}
+
+		if err := batch.Write(); err != nil {
+			return err
+		}
+	}
+	// The headers must be linked to the local chain as well
+	if !d.lightchain.HasHeader(parent, origin) {
+		p.log.Warn("Finalized chain not linked to the common ancestor", "number", origin, "parent", parent)
+		return fmt.Errorf("%w: finalized chain not linked to the common ancestor %d", errInvalidChain, origin)
+	}
+
+	return nil
+}
+
+// feedAnchoredHeaders schedules the headers from the given block up to the anchor, which
+// were downloaded and verified backwards from it by fetchAnchoredHeaders, for import.
+func (d *Downloader) feedAnchoredHeaders(p *peerConnection, from uint64) error {
+	anchor := d.anchor
+
+	for from <= anchor.number {
+		count := min(uint64(MaxHeaderFetch), anchor.number-from+1)
+
+		var (
+			headers = make([]*types.Header, 0, count)
+			hashes  = make([]common.Hash, 0, count)
+			peers   = make([]string, 0, count)
+			batch   = d.stateDB.NewBatch()
+		)
+
+		for number := from; number < from+count; number++ {
+			header := rawdb.ReadAnchoredHeader(d.stateDB, number)
+			if header == nil {
+				return fmt.Errorf("verified header %d missing", number)
+			}
+
+			headers = append(headers, header)
+			hashes = append(hashes, header.Hash())
+			peers = append(peers, p.id)
+
+			rawdb.DeleteAnchoredHeader(batch, number)
+		}
+
+		select {
+		case d.headerProcCh <- &headerTask{
+			headers:  headers,
+			hashes:   hashes,
+			peers:    peers,
+			verified: true,
+		}:
+		case <-d.cancelCh:
+			return errCanceled
+		}
+
+		if err := batch.Write(); err != nil {
+			return err
+		}
+
+		from += count
+	}
+
+	return nil
+}
+
+// checkAnchor ensures the given headers lead to the block milestone sync is anchored at,
+// which verifies the whole header chain below it by hash.
+func (d *Downloader) checkAnchor(headers []*types.Header, hashes []common.Hash) error {
+	anchor := d.anchor
+	if anchor == nil {
+		return nil
+	}
+
+	first, last := headers[0].Number.Uint64(), headers[len(headers)-1].Number.Uint64()
+	if anchor.number < first || anchor.number > last {
+		return nil
+	}
+
+	if hash := hashes[anchor.number-first]; hash != anchor.hash {
+		log.Warn("Header chain doesn't lead to the finalized block", "number", anchor.number, "have", hash, "want", anchor.hash)
+		return fmt.Errorf("%w: %w", errInvalidChain, whitelist.ErrMismatch)
+	}
+
+	return nil
+}
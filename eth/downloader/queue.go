@@ -135,6 +135,7 @@ type queue struct {
 	headerPendPool  map[string]*fetchRequest       // Currently pending header retrieval operations
 	headerResults   []*types.Header                // Result cache accumulating the completed headers
 	headerHashes    []common.Hash                  // Result cache accumulating the completed header hashes
+	headerPeers     []string                       // Result cache accumulating the peers which delivered the completed headers
 	headerProced    int                            // Number of headers already processed from the results
 	headerOffset    uint64                         // Number of the first header in the result cache
 	headerContCh    chan bool                      // Channel to notify when header download finishes
@@ -278,6 +279,7 @@ func (q *queue) ScheduleSkeleton(from uint64, skeleton []*types.Header) {
 	q.headerPeerMiss = make(map[string]map[uint64]struct{}) // Reset availability to correct invalid chains
 	q.headerResults = make([]*types.Header, len(skeleton)*MaxHeaderFetch)
 	q.headerHashes = make([]common.Hash, len(skeleton)*MaxHeaderFetch)
+	q.headerPeers = make([]string, len(skeleton)*MaxHeaderFetch)
 	q.headerProced = 0
 	q.headerOffset = from
 	q.headerContCh = make(chan bool, 1)
@@ -292,14 +294,14 @@ func (q *queue) ScheduleSkeleton(from uint64, skeleton []*types.Header) {
 
 // RetrieveHeaders retrieves the header chain assemble based on the scheduled
 // skeleton.
-func (q *queue) RetrieveHeaders() ([]*types.Header, []common.Hash, int) {
+func (q *queue) RetrieveHeaders() ([]*types.Header, []common.Hash, []string, int) {
 	q.lock.Lock()
 	defer q.lock.Unlock()
 
-	headers, hashes, proced := q.headerResults, q.headerHashes, q.headerProced
-	q.headerResults, q.headerHashes, q.headerProced = nil, nil, 0
+	headers, hashes, peers, proced := q.headerResults, q.headerHashes, q.headerPeers, q.headerProced
+	q.headerResults, q.headerHashes, q.headerPeers, q.headerProced = nil, nil, nil, 0
 
-	return headers, hashes, proced
+	return headers, hashes, peers, proced
 }
 
 // Schedule adds a set of headers for the download queue for scheduling, returning
@@ -796,6 +798,10 @@ func (q *queue) DeliverHeaders(id string, headers []*types.Header, hashes []comm
 	copy(q.headerResults[request.From-q.headerOffset:], headers)
 	copy(q.headerHashes[request.From-q.headerOffset:], hashes)
 
+	for i := range headers {
+		q.headerPeers[request.From-q.headerOffset+uint64(i)] = id
+	}
+
 	delete(q.headerTaskPool, request.From)
 
 	ready := 0
@@ -811,10 +817,14 @@ func (q *queue) DeliverHeaders(id string, headers []*types.Header, hashes []comm
 		processHashes := make([]common.Hash, ready)
 		copy(processHashes, q.headerHashes[q.headerProced:q.headerProced+ready])
 
+		processPeers := make([]string, ready)
+		copy(processPeers, q.headerPeers[q.headerProced:q.headerProced+ready])
+
 		select {
 		case headerProcCh <- &headerTask{
 			headers: processHeaders,
 			hashes:  processHashes,
+			peers:   processPeers,
 		}:
 			logger.Trace("Pre-scheduled new headers", "count", len(processHeaders), "from", processHeaders[0].Number)
 			q.headerProced += len(processHeaders)
@@ -102,6 +102,31 @@ func (m *milestone) IsValidChain(currentHeader *types.Header, chain []*types.Hea
 	return isValid, nil
 }
 
+// ChainConflict returns which of the local milestone entries the chain conflicts with,
+// nil if it doesn't conflict with any.
+func (m *milestone) ChainConflict(currentHeader *types.Header, chain []*types.Header) error {
+	if !flags.Milestone {
+		return nil
+	}
+
+	m.finality.RLock()
+	defer m.finality.RUnlock()
+
+	if valid, _ := m.finality.IsValidChain(currentHeader, chain); !valid {
+		return ErrMismatch
+	}
+
+	if m.Locked && !m.IsReorgAllowed(chain, m.LockedMilestoneNumber, m.LockedMilestoneHash) {
+		return ErrLockedSprintMismatch
+	}
+
+	if !m.IsFutureMilestoneCompatible(chain) {
+		return ErrFutureMilestoneMismatch
+	}
+
+	return nil
+}
+
 // IsValidPeer checks if the chain we're about to receive from a peer is valid or not
 // in terms of reorgs. We won't reorg beyond the last bor finality submitted to mainchain.
 func (m *milestone) IsValidPeer(fetchHeadersByNumber func(number uint64, amount int, skip int, reverse bool) ([]*types.Header, []common.Hash, error)) (bool, error) {
@@ -3,6 +3,7 @@ package whitelist
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -17,11 +18,24 @@ var (
 	ErrCheckpointMismatch = errors.New("checkpoint mismatch")
 	ErrLongFutureChain    = errors.New("received future chain of unacceptable length")
 	ErrNoRemoteCheckpoint = errors.New("remote peer doesn't have a checkpoint")
+
+	// ErrLockedSprintMismatch and ErrFutureMilestoneMismatch are the mismatches of a chain
+	// reorging past the locked sprint and of a chain conflicting with a future milestone.
+	ErrLockedSprintMismatch    = fmt.Errorf("%w: locked sprint", ErrMismatch)
+	ErrFutureMilestoneMismatch = fmt.Errorf("%w: future milestone", ErrMismatch)
 )
 
+// FinalityNotifier is called whenever a milestone or a checkpoint advances the finalized
+// block, with the end block of the milestone or the checkpoint.
+type FinalityNotifier func(isMilestone bool, number uint64, hash common.Hash)
+
 type Service struct {
 	checkpointService
 	milestoneService
+
+	finalizedLock sync.Mutex
+	finalized     uint64           // Highest block finalized by a milestone or a checkpoint
+	notify        FinalityNotifier // Callback notified when the finalized block advances
 }
 
 func NewService(db ethdb.Database) *Service {
@@ -52,8 +66,17 @@ func NewService(db ethdb.Database) *Service {
 		list = make(map[uint64]common.Hash)
 	}
 
+	finalized := uint64(0)
+	if checkpointDoExist {
+		finalized = checkpointNumber
+	}
+
+	if milestoneDoExist && milestoneNumber > finalized {
+		finalized = milestoneNumber
+	}
+
 	return &Service{
-		&checkpoint{
+		checkpointService: &checkpoint{
 			finality[*rawdb.Checkpoint]{
 				doExist:  checkpointDoExist,
 				Number:   checkpointNumber,
@@ -64,7 +87,7 @@ func NewService(db ethdb.Database) *Service {
 			},
 		},
 
-		&milestone{
+		milestoneService: &milestone{
 			finality: finality[*rawdb.Milestone]{
 				doExist:  milestoneDoExist,
 				Number:   milestoneNumber,
@@ -83,6 +106,8 @@ func NewService(db ethdb.Database) *Service {
 			MaxCapacity:           10,
 			blockchain:            nil, // Will be set after blockchain creation
 		},
+
+		finalized: finalized,
 	}
 }
 
@@ -125,12 +150,48 @@ func (s *Service) GetWhitelistedMilestone() (bool, uint64, common.Hash) {
 	return s.milestoneService.Get()
 }
 
+// SetFinalityNotifier sets the callback notified whenever a milestone or a checkpoint
+// advances the finalized block.
+func (s *Service) SetFinalityNotifier(notify FinalityNotifier) {
+	s.finalizedLock.Lock()
+	defer s.finalizedLock.Unlock()
+
+	s.notify = notify
+}
+
 func (s *Service) ProcessMilestone(endBlockNum uint64, endBlockHash common.Hash) {
+	s.finalizedLock.Lock()
 	s.milestoneService.Process(endBlockNum, endBlockHash)
+	notify := s.advanceFinalized(endBlockNum)
+	s.finalizedLock.Unlock()
+
+	if notify != nil {
+		notify(true, endBlockNum, endBlockHash)
+	}
 }
 
 func (s *Service) ProcessCheckpoint(endBlockNum uint64, endBlockHash common.Hash) {
+	s.finalizedLock.Lock()
 	s.checkpointService.Process(endBlockNum, endBlockHash)
+	notify := s.advanceFinalized(endBlockNum)
+	s.finalizedLock.Unlock()
+
+	if notify != nil {
+		notify(false, endBlockNum, endBlockHash)
+	}
+}
+
+// advanceFinalized advances the finalized block to the end block of the processed milestone
+// or checkpoint, and returns the notifier to call once finalizedLock is released if it
+// finalizes blocks beyond the ones already finalized. The caller must hold finalizedLock.
+func (s *Service) advanceFinalized(number uint64) FinalityNotifier {
+	if number <= s.finalized {
+		return nil
+	}
+
+	s.finalized = number
+
+	return s.notify
 }
 
 func (s *Service) IsValidChain(currentHeader *types.Header, chain []*types.Header) (bool, error) {
@@ -147,6 +208,24 @@ func (s *Service) IsValidChain(currentHeader *types.Header, chain []*types.Heade
 	return true, nil
 }
 
+// ChainConflict returns why the chain isn't valid against the local whitelist entries,
+// ErrMismatch if the reason isn't known, and nil if the chain is valid.
+func (s *Service) ChainConflict(currentHeader *types.Header, chain []*types.Header) error {
+	if valid, _ := s.checkpointService.IsValidChain(currentHeader, chain); !valid {
+		return ErrMismatch
+	}
+
+	if milestone, ok := s.milestoneService.(*milestone); ok {
+		return milestone.ChainConflict(currentHeader, chain)
+	}
+
+	if valid, _ := s.milestoneService.IsValidChain(currentHeader, chain); !valid {
+		return ErrMismatch
+	}
+
+	return nil
+}
+
 func (s *Service) GetMilestoneIDsList() []string {
 	return s.milestoneService.GetMilestoneIDsList()
 }
@@ -51,8 +51,7 @@ func (m *MockChainReader) SetBlock(number uint64, block *types.Block) {
 // NewMockService creates a new mock whitelist service
 func NewMockService(db ethdb.Database) *Service {
 	return &Service{
-
-		&checkpoint{
+		checkpointService: &checkpoint{
 			finality[*rawdb.Checkpoint]{
 				doExist:  false,
 				interval: 256,
@@ -60,7 +59,7 @@ func NewMockService(db ethdb.Database) *Service {
 			},
 		},
 
-		&milestone{
+		milestoneService: &milestone{
 			finality: finality[*rawdb.Milestone]{
 				doExist:  false,
 				interval: 256,
@@ -700,6 +699,41 @@ func TestIsValidChain(t *testing.T) {
 
 }
 
+// TestChainConflict checks that the chains rejected by IsValidChain are reported
+// with the whitelist entry they conflict with
+func TestChainConflict(t *testing.T) {
+	t.Parallel()
+
+	s := NewMockService(rawdb.NewMemoryDatabase())
+	chain := createMockChain(1, 20)
+	current := chain[len(chain)-1]
+
+	require.NoError(t, s.ChainConflict(current, chain))
+
+	milestone := s.milestoneService.(*milestone)
+
+	// Chain reorging past the locked sprint
+	milestone.LockMutex(15)
+	milestone.UnlockMutex(true, "MilestoneID1", 15, common.Hash{1})
+
+	valid, err := s.IsValidChain(current, chain)
+	require.NoError(t, err)
+	require.False(t, valid)
+	require.ErrorIs(t, s.ChainConflict(current, chain), ErrLockedSprintMismatch)
+	require.ErrorIs(t, s.ChainConflict(current, chain), ErrMismatch)
+
+	milestone.UnlockSprint(15)
+	require.NoError(t, s.ChainConflict(current, chain))
+
+	// Chain conflicting with a future milestone
+	s.ProcessFutureMilestone(18, common.Hash{2})
+	require.ErrorIs(t, s.ChainConflict(chain[0], chain), ErrFutureMilestoneMismatch)
+
+	// Chain conflicting with the whitelisted milestone
+	s.ProcessMilestone(10, common.Hash{3})
+	require.Equal(t, ErrMismatch, s.ChainConflict(current, chain))
+}
+
 func TestPropertyBasedTestingMilestone(t *testing.T) {
 	rapid.Check(t, func(t *rapid.T) {
 
@@ -1281,3 +1315,45 @@ func TestMilestoneSetBlockchain(t *testing.T) {
 	require.NotNil(t, milestone.blockchain, "Blockchain should be set")
 	require.Equal(t, blockchain, milestone.blockchain, "Blockchain should match what was set")
 }
+
+// TestFinalityNotifier tests that the finality notifier is only called when a milestone
+// or a checkpoint finalizes blocks beyond the already finalized ones.
+func TestFinalityNotifier(t *testing.T) {
+	t.Parallel()
+
+	type notification struct {
+		isMilestone bool
+		number      uint64
+		hash        common.Hash
+	}
+
+	var (
+		db            = rawdb.NewMemoryDatabase()
+		notifications []notification
+	)
+
+	// The finality restored from the database isn't notified again
+	require.NoError(t, rawdb.WriteLastFinality[*rawdb.Milestone](db, 16, common.Hash{1}))
+
+	s := NewService(db)
+	s.SetFinalityNotifier(func(isMilestone bool, number uint64, hash common.Hash) {
+		// The notifier is called without holding the lock of the finalized block
+		require.True(t, s.finalizedLock.TryLock())
+		s.finalizedLock.Unlock()
+
+		notifications = append(notifications, notification{isMilestone, number, hash})
+	})
+
+	s.ProcessMilestone(16, common.Hash{1})
+	s.ProcessMilestone(32, common.Hash{2})
+	s.ProcessCheckpoint(24, common.Hash{3})
+	s.ProcessCheckpoint(48, common.Hash{4})
+	s.ProcessMilestone(40, common.Hash{5})
+	s.ProcessMilestone(64, common.Hash{6})
+
+	require.Equal(t, []notification{
+		{true, 32, common.Hash{2}},
+		{false, 48, common.Hash{4}},
+		{true, 64, common.Hash{6}},
+	}, notifications)
+}
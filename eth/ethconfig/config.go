@@ -18,7 +18,9 @@
 package ethconfig
 
 import (
+	"fmt"
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -28,6 +30,8 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/bor/contract"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall" //nolint:typecheck
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallapp"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallarchive"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdallgrpc"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdallws"
 	"github.com/ethereum/go-ethereum/consensus/clique"
@@ -77,6 +81,8 @@ var Defaults = Config{
 	RPCEVMTimeout:      5 * time.Second,
 	GPO:                FullNodeGPO,
 	RPCTxFeeCap:        1, // 1 ether
+	PeerBanThreshold:   3,
+	PeerFailureExpiry:  24 * time.Hour,
 }
 
 //go:generate go run github.com/fjl/gencodec -type Config -formats toml -out gen_config.go
@@ -202,6 +208,24 @@ type Config struct {
 	// Use child heimdall process to fetch data, Only works when RunHeimdall is true
 	UseHeimdallApp bool
 
+	// Path of a local heimdall archive to be used instead of a live heimdall
+	HeimdallArchivePath string
+
+	// Address of the trusted signer of the heimdall archive
+	HeimdallArchiveSigner string
+
+	// Additional heimdall endpoints to fail over to
+	HeimdallEndpoints []string
+
+	// Time after which a request to a heimdall endpoint is failed over to the next one
+	HeimdallFailoverTimeout time.Duration
+
+	// Time after which a slow heimdall request is also sent to the next endpoint
+	HeimdallHedgeDelay time.Duration
+
+	// Number of heimdall endpoints which must agree on spans and milestones
+	HeimdallQuorum int
+
 	// Bor logs flag
 	BorLogs bool
 
@@ -216,6 +240,14 @@ type Config struct {
 
 	// EnableBlockTracking allows logging of information collected while tracking block lifecycle
 	EnableBlockTracking bool
+
+	// Number of chains conflicting with the whitelisted milestones and checkpoints
+	// after which a peer is banned
+	PeerBanThreshold uint64
+
+	// Time after the last conflicting chain served by a peer after which its
+	// failures are forgiven, lifting its ban
+	PeerFailureExpiry time.Duration
 }
 
 // CreateConsensusEngine creates a consensus engine for the given chain configuration.
@@ -238,18 +270,56 @@ func CreateConsensusEngine(chainConfig *params.ChainConfig, ethConfig *Config, d
 			}
 
 			var heimdallClient bor.IHeimdallClient
-			if ethConfig.RunHeimdall && ethConfig.UseHeimdallApp {
-				// TODO: Running heimdall from bor is not tested yet.
-				// heimdallClient = heimdallapp.NewHeimdallAppClient()
-				panic("Running heimdall from bor is not implemented yet. Please use heimdall gRPC or HTTP client instead.")
+			if ethConfig.HeimdallArchivePath != "" {
+				var trustedSigner *common.Address
+				if ethConfig.HeimdallArchiveSigner != "" {
+					if !common.IsHexAddress(ethConfig.HeimdallArchiveSigner) {
+						return nil, fmt.Errorf("invalid heimdall archive signer: %s", ethConfig.HeimdallArchiveSigner)
+					}
+
+					signer := common.HexToAddress(ethConfig.HeimdallArchiveSigner)
+					trustedSigner = &signer
+				} else {
+					log.Warn("------------------------------------------------------------------")
+					log.Warn("No heimdall archive signer configured, the archive is NOT verified")
+					log.Warn("Anyone able to modify the archive can change the validator sets")
+					log.Warn("Set --bor.heimdallarchivesigner to only accept signed archives")
+					log.Warn("------------------------------------------------------------------")
+				}
+
+				archiveClient, err := heimdallarchive.NewHeimdallArchiveClientFromFile(ethConfig.HeimdallArchivePath, chainConfig.ChainID.String(), trustedSigner)
+				if err != nil {
+					return nil, err
+				}
+
+				log.Warn("Using local heimdall archive instead of a live heimdall", "path", ethConfig.HeimdallArchivePath)
+
+				// Subscriptions are meaningless for a static archive, hence the ws client is skipped
+				return bor.New(chainConfig, db, blockchainAPI, spanner, archiveClient, nil, genesisContractsClient, false), nil
+			} else if ethConfig.RunHeimdall && ethConfig.UseHeimdallApp {
+				// TODO: Running heimdall from bor is not tested yet. The app client reports itself
+				// unhealthy until it's given the running app, hence it's only used as the preferred
+				// backend of the multi-endpoint client, failing over to the other endpoints.
+				if len(ethConfig.HeimdallEndpoints) == 0 {
+					panic("Running heimdall from bor requires additional heimdall endpoints. Please use heimdall gRPC or HTTP client instead.")
+				}
+
+				heimdallClient = heimdallapp.NewHeimdallAppClient()
 			} else if ethConfig.HeimdallgRPCAddress != "" {
 				heimdallClient = heimdallgrpc.NewHeimdallGRPCClient(ethConfig.HeimdallgRPCAddress)
 			} else {
 				heimdallClient = heimdall.NewHeimdallClient(ethConfig.HeimdallURL, ethConfig.HeimdallTimeout)
 			}
 
-			var heimdallWSClient bor.IHeimdallWSClient
 			var err error
+			if len(ethConfig.HeimdallEndpoints) > 0 {
+				heimdallClient, err = newMultiHeimdallClient(heimdallClient, ethConfig)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			var heimdallWSClient bor.IHeimdallWSClient
 			if ethConfig.HeimdallWSAddress != "" {
 				heimdallWSClient, err = heimdallws.NewHeimdallWSClient(ethConfig.HeimdallWSAddress)
 				if err != nil {
@@ -262,3 +332,32 @@ func CreateConsensusEngine(chainConfig *params.ChainConfig, ethConfig *Config, d
 	}
 	return beacon.New(ethash.NewFaker()), nil
 }
+
+// newMultiHeimdallClient wraps the primary heimdall client and the additional
+// heimdall endpoints into a single client failing over between them.
+func newMultiHeimdallClient(primary bor.IHeimdallClient, ethConfig *Config) (bor.IHeimdallClient, error) {
+	backends := []heimdall.Backend{{Name: "primary", Client: primary}}
+
+	for i, endpoint := range ethConfig.HeimdallEndpoints {
+		var client heimdall.Client
+
+		switch {
+		case strings.HasPrefix(endpoint, "grpc://"):
+			client = heimdallgrpc.NewHeimdallGRPCClient(strings.TrimPrefix(endpoint, "grpc://"))
+		case strings.HasPrefix(endpoint, "http://"), strings.HasPrefix(endpoint, "https://"):
+			client = heimdall.NewHeimdallClient(endpoint, ethConfig.HeimdallTimeout)
+		default:
+			return nil, fmt.Errorf("invalid heimdall endpoint %q, expected http(s):// or grpc:// scheme", endpoint)
+		}
+
+		backends = append(backends, heimdall.Backend{Name: fmt.Sprintf("endpoint%d", i), Client: client})
+	}
+
+	log.Info("Using multiple heimdall endpoints", "endpoints", len(backends), "hedgeDelay", ethConfig.HeimdallHedgeDelay, "quorum", ethConfig.HeimdallQuorum)
+
+	return heimdall.NewMultiHeimdallClient(backends, heimdall.MultiClientConfig{
+		RequestTimeout: ethConfig.HeimdallFailoverTimeout,
+		HedgeDelay:     ethConfig.HeimdallHedgeDelay,
+		Quorum:         ethConfig.HeimdallQuorum,
+	})
+}
@@ -71,11 +71,19 @@ func (c Config) MarshalJSON() ([]byte, error) {
 		RunHeimdall                          bool
 		RunHeimdallArgs                      string
 		UseHeimdallApp                       bool
+		HeimdallArchivePath                  string
+		HeimdallArchiveSigner                string
+		HeimdallEndpoints                    []string
+		HeimdallFailoverTimeout              time.Duration
+		HeimdallHedgeDelay                   time.Duration
+		HeimdallQuorum                       int
 		BorLogs                              bool
 		ParallelEVM                          core.ParallelEVMConfig `toml:",omitempty"`
 		DevFakeAuthor                        bool                   `hcl:"devfakeauthor,optional" toml:"devfakeauthor,optional"`
 		OverrideVerkle                       *big.Int               `toml:",omitempty"`
 		EnableBlockTracking                  bool
+		PeerBanThreshold                     uint64
+		PeerFailureExpiry                    time.Duration
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -129,11 +137,19 @@ func (c Config) MarshalJSON() ([]byte, error) {
 	enc.RunHeimdall = c.RunHeimdall
 	enc.RunHeimdallArgs = c.RunHeimdallArgs
 	enc.UseHeimdallApp = c.UseHeimdallApp
+	enc.HeimdallArchivePath = c.HeimdallArchivePath
+	enc.HeimdallArchiveSigner = c.HeimdallArchiveSigner
+	enc.HeimdallEndpoints = c.HeimdallEndpoints
+	enc.HeimdallFailoverTimeout = c.HeimdallFailoverTimeout
+	enc.HeimdallHedgeDelay = c.HeimdallHedgeDelay
+	enc.HeimdallQuorum = c.HeimdallQuorum
 	enc.BorLogs = c.BorLogs
 	enc.ParallelEVM = c.ParallelEVM
 	enc.DevFakeAuthor = c.DevFakeAuthor
 	enc.OverrideVerkle = c.OverrideVerkle
 	enc.EnableBlockTracking = c.EnableBlockTracking
+	enc.PeerBanThreshold = c.PeerBanThreshold
+	enc.PeerFailureExpiry = c.PeerFailureExpiry
 	return json.Marshal(&enc)
 }
 
@@ -191,11 +207,19 @@ func (c *Config) UnmarshalJSON(input []byte) error {
 		RunHeimdall                          *bool
 		RunHeimdallArgs                      *string
 		UseHeimdallApp                       *bool
+		HeimdallArchivePath                  *string
+		HeimdallArchiveSigner                *string
+		HeimdallEndpoints                    []string
+		HeimdallFailoverTimeout              *time.Duration
+		HeimdallHedgeDelay                   *time.Duration
+		HeimdallQuorum                       *int
 		BorLogs                              *bool
 		ParallelEVM                          *core.ParallelEVMConfig `toml:",omitempty"`
 		DevFakeAuthor                        *bool                   `hcl:"devfakeauthor,optional" toml:"devfakeauthor,optional"`
 		OverrideVerkle                       *big.Int                `toml:",omitempty"`
 		EnableBlockTracking                  *bool
+		PeerBanThreshold                     *uint64
+		PeerFailureExpiry                    *time.Duration
 	}
 	var dec Config
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -354,6 +378,24 @@ func (c *Config) UnmarshalJSON(input []byte) error {
 	if dec.UseHeimdallApp != nil {
 		c.UseHeimdallApp = *dec.UseHeimdallApp
 	}
+	if dec.HeimdallArchivePath != nil {
+		c.HeimdallArchivePath = *dec.HeimdallArchivePath
+	}
+	if dec.HeimdallArchiveSigner != nil {
+		c.HeimdallArchiveSigner = *dec.HeimdallArchiveSigner
+	}
+	if dec.HeimdallEndpoints != nil {
+		c.HeimdallEndpoints = dec.HeimdallEndpoints
+	}
+	if dec.HeimdallFailoverTimeout != nil {
+		c.HeimdallFailoverTimeout = *dec.HeimdallFailoverTimeout
+	}
+	if dec.HeimdallHedgeDelay != nil {
+		c.HeimdallHedgeDelay = *dec.HeimdallHedgeDelay
+	}
+	if dec.HeimdallQuorum != nil {
+		c.HeimdallQuorum = *dec.HeimdallQuorum
+	}
 	if dec.BorLogs != nil {
 		c.BorLogs = *dec.BorLogs
 	}
@@ -369,5 +411,11 @@ func (c *Config) UnmarshalJSON(input []byte) error {
 	if dec.EnableBlockTracking != nil {
 		c.EnableBlockTracking = *dec.EnableBlockTracking
 	}
+	if dec.PeerBanThreshold != nil {
+		c.PeerBanThreshold = *dec.PeerBanThreshold
+	}
+	if dec.PeerFailureExpiry != nil {
+		c.PeerFailureExpiry = *dec.PeerFailureExpiry
+	}
 	return nil
 }
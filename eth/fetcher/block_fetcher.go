@@ -643,6 +643,7 @@ func (f *BlockFetcher) loop() {
 
 							block := types.NewBlockWithHeader(header)
 							block.ReceivedAt = task.time
+							block.ReceivedFrom = task.peer
 							block.AnnouncedAt = &task.announcedTime
 
 							complete = append(complete, block)
@@ -741,6 +742,7 @@ func (f *BlockFetcher) loop() {
 								Uncles:       task.uncles[i],
 							})
 							block.ReceivedAt = task.time
+							block.ReceivedFrom = task.peer
 							block.AnnouncedAt = &task.announcedTime
 							blocks = append(blocks, block)
 						} else {
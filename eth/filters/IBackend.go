@@ -245,6 +245,20 @@ func (mr *MockBackendMockRecorder) SubscribeChainEvent(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeChainEvent", reflect.TypeOf((*MockBackend)(nil).SubscribeChainEvent), arg0)
 }
 
+// SubscribeFinalizedHeadEvent mocks base method.
+func (m *MockBackend) SubscribeFinalizedHeadEvent(arg0 chan<- core.FinalizedHeadEvent) event.Subscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeFinalizedHeadEvent", arg0)
+	ret0, _ := ret[0].(event.Subscription)
+	return ret0
+}
+
+// SubscribeFinalizedHeadEvent indicates an expected call of SubscribeFinalizedHeadEvent.
+func (mr *MockBackendMockRecorder) SubscribeFinalizedHeadEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeFinalizedHeadEvent", reflect.TypeOf((*MockBackend)(nil).SubscribeFinalizedHeadEvent), arg0)
+}
+
 // SubscribeLogsEvent mocks base method.
 func (m *MockBackend) SubscribeLogsEvent(arg0 chan<- []*types.Log) event.Subscription {
 	m.ctrl.T.Helper()
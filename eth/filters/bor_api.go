@@ -6,6 +6,8 @@ import (
 
 	ethereum "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -85,3 +87,54 @@ func (api *FilterAPI) NewDeposits(ctx context.Context, crit ethereum.StateSyncFi
 
 	return rpcSub, nil
 }
+
+// FinalizedHead is the notification of the finalizedHeads subscription. Type is one of
+// "milestone", "checkpoint" or "rollback". For rollbacks, the block range is the one of
+// the rewound blocks and the header is the head the chain was rewound to.
+type FinalizedHead struct {
+	Type             string         `json:"type"`
+	MilestoneID      string         `json:"milestoneId,omitempty"`
+	CheckpointNumber hexutil.Uint64 `json:"checkpointNumber,omitempty"`
+	StartBlock       hexutil.Uint64 `json:"startBlock"`
+	EndBlock         hexutil.Uint64 `json:"endBlock"`
+	Header           *types.Header  `json:"header"`
+}
+
+func newFinalizedHead(ev *core.FinalizedHeadEvent) *FinalizedHead {
+	return &FinalizedHead{
+		Type:             ev.Type,
+		MilestoneID:      ev.MilestoneID,
+		CheckpointNumber: hexutil.Uint64(ev.CheckpointNumber),
+		StartBlock:       hexutil.Uint64(ev.StartBlock),
+		EndBlock:         hexutil.Uint64(ev.EndBlock),
+		Header:           ev.Header,
+	}
+}
+
+// FinalizedHeads send a notification each time a milestone or a checkpoint advances the
+// finalized block, and each time the chain is rewound to the finalized chain.
+func (api *FilterAPI) FinalizedHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		finalized := make(chan *core.FinalizedHeadEvent)
+		finalizedSub := api.events.SubscribeFinalizedHeads(finalized)
+		defer finalizedSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-finalized:
+				notifier.Notify(rpcSub.ID, newFinalizedHead(ev))
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
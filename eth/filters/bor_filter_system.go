@@ -14,6 +14,12 @@ func (es *EventSystem) handleStateSyncEvent(filters filterIndex, ev core.StateSy
 	}
 }
 
+func (es *EventSystem) handleFinalizedHeadEvent(filters filterIndex, ev core.FinalizedHeadEvent) {
+	for _, f := range filters[FinalizedHeadsSubscription] {
+		f.finalized <- &ev
+	}
+}
+
 // SubscribeNewDeposits creates a subscription that writes details about the new state sync events (from mainchain to Bor)
 func (es *EventSystem) SubscribeNewDeposits(data chan *types.StateSyncData) *Subscription {
 	sub := &subscription{
@@ -30,3 +36,21 @@ func (es *EventSystem) SubscribeNewDeposits(data chan *types.StateSyncData) *Sub
 
 	return es.subscribe(sub)
 }
+
+// SubscribeFinalizedHeads creates a subscription that writes the blocks finalized by
+// milestones and checkpoints, along with the finality rollbacks.
+func (es *EventSystem) SubscribeFinalizedHeads(finalized chan *core.FinalizedHeadEvent) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       FinalizedHeadsSubscription,
+		created:   time.Now(),
+		logs:      make(chan []*types.Log),
+		txs:       make(chan []*types.Transaction),
+		headers:   make(chan *types.Header),
+		finalized: finalized,
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+
+	return es.subscribe(sub)
+}
@@ -3,8 +3,11 @@ package filters
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	types "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
@@ -155,3 +158,59 @@ func TestBorFilters(t *testing.T) {
 		t.Error("expected 0 log, got", len(logs))
 	}
 }
+
+// TestFinalizedHeadsSubscription tests that the finalized heads subscriptions receive the
+// posted milestone, checkpoint and rollback events, and stop receiving them once uninstalled.
+func TestFinalizedHeadsSubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		backend, sys = newTestFilterSystem(rawdb.NewMemoryDatabase(), Config{})
+		api          = NewFilterAPI(sys, true)
+		events       = []core.FinalizedHeadEvent{
+			{Type: core.FinalizedByMilestone, MilestoneID: "m1", StartBlock: 1, EndBlock: 16, Header: newTestHeader(16)},
+			{Type: core.FinalizedByCheckpoint, CheckpointNumber: 1, StartBlock: 1, EndBlock: 24, Header: newTestHeader(24)},
+			{Type: core.FinalityRollback, StartBlock: 25, EndBlock: 30, Header: newTestHeader(24)},
+		}
+	)
+
+	chan0 := make(chan *core.FinalizedHeadEvent)
+	sub0 := api.events.SubscribeFinalizedHeads(chan0)
+	chan1 := make(chan *core.FinalizedHeadEvent)
+	sub1 := api.events.SubscribeFinalizedHeads(chan1)
+
+	go func() { // simulate client
+		i0, i1 := 0, 0
+		for i0 != len(events) || i1 != len(events) {
+			select {
+			case ev := <-chan0:
+				if ev.Type != events[i0].Type || ev.EndBlock != events[i0].EndBlock || ev.Header.Hash() != events[i0].Header.Hash() {
+					t.Errorf("sub0 received invalid event on index %d, want %+v, got %+v", i0, events[i0], ev)
+				}
+
+				i0++
+			case ev := <-chan1:
+				if ev.Type != events[i1].Type || ev.EndBlock != events[i1].EndBlock || ev.Header.Hash() != events[i1].Header.Hash() {
+					t.Errorf("sub1 received invalid event on index %d, want %+v, got %+v", i1, events[i1], ev)
+				}
+
+				i1++
+			}
+		}
+
+		sub0.Unsubscribe()
+		sub1.Unsubscribe()
+	}()
+
+	time.Sleep(1 * time.Second)
+
+	for _, ev := range events {
+		backend.finalizedFeed.Send(ev)
+	}
+
+	<-sub0.Err()
+	<-sub1.Err()
+
+	// Events posted once the subscriptions are uninstalled must not block the event loop
+	backend.finalizedFeed.Send(events[0])
+}
@@ -77,6 +77,7 @@ type Backend interface {
 	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
 	SubscribePendingLogsEvent(ch chan<- []*types.Log) event.Subscription
 	SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) event.Subscription
+	SubscribeFinalizedHeadEvent(ch chan<- core.FinalizedHeadEvent) event.Subscription
 
 	CurrentView() *filtermaps.ChainView
 	NewMatcherBackend() filtermaps.MatcherBackend
@@ -177,6 +178,8 @@ const (
 	BlocksSubscription
 	// StateSyncSubscription to listen main chain state
 	StateSyncSubscription
+	// FinalizedHeadsSubscription queries for blocks finalized by milestones and checkpoints
+	FinalizedHeadsSubscription
 	// LastIndexSubscription keeps track of the last index
 	LastIndexSubscription
 )
@@ -195,6 +198,8 @@ const (
 	chainEvChanSize = 10
 	// stateEvChanSize is the size of channel listening to StateSyncEvent.
 	stateEvChanSize = 10
+	// finalizedEvChanSize is the size of channel listening to FinalizedHeadEvent.
+	finalizedEvChanSize = 10
 )
 
 type subscription struct {
@@ -209,6 +214,7 @@ type subscription struct {
 	err       chan error    // closed when the filter is uninstalled
 
 	stateSyncData chan *types.StateSyncData
+	finalized     chan *core.FinalizedHeadEvent
 }
 
 // EventSystem creates subscriptions, processes events and broadcasts them to the
@@ -234,8 +240,10 @@ type EventSystem struct {
 	chainCh       chan core.ChainEvent       // Channel to receive new chain event
 
 	// Bor related subscription and channels
-	stateSyncSub event.Subscription       // Subscription for new state event
-	stateSyncCh  chan core.StateSyncEvent // Channel to receive deposit state change event
+	stateSyncSub event.Subscription           // Subscription for new state event
+	stateSyncCh  chan core.StateSyncEvent     // Channel to receive deposit state change event
+	finalizedSub event.Subscription           // Subscription for finalized head event
+	finalizedCh  chan core.FinalizedHeadEvent // Channel to receive milestone and checkpoint finality event
 }
 
 // NewEventSystem creates a new manager that listens for event on the given mux,
@@ -256,6 +264,7 @@ func NewEventSystem(sys *FilterSystem) *EventSystem {
 		pendingLogsCh: make(chan []*types.Log, logsChanSize),
 		chainCh:       make(chan core.ChainEvent, chainEvChanSize),
 		stateSyncCh:   make(chan core.StateSyncEvent, stateEvChanSize),
+		finalizedCh:   make(chan core.FinalizedHeadEvent, finalizedEvChanSize),
 	}
 
 	// Subscribe events
@@ -265,6 +274,7 @@ func NewEventSystem(sys *FilterSystem) *EventSystem {
 	m.chainSub = m.backend.SubscribeChainEvent(m.chainCh)
 	m.pendingLogsSub = m.backend.SubscribePendingLogsEvent(m.pendingLogsCh)
 	m.stateSyncSub = m.backend.SubscribeStateSyncEvent(m.stateSyncCh)
+	m.finalizedSub = m.backend.SubscribeFinalizedHeadEvent(m.finalizedCh)
 
 	// Make sure none of the subscriptions are empty
 	if m.txsSub == nil || m.logsSub == nil || m.rmLogsSub == nil || m.chainSub == nil || m.pendingLogsSub == nil {
@@ -304,6 +314,7 @@ func (sub *Subscription) Unsubscribe() {
 			case <-sub.f.logs:
 			case <-sub.f.txs:
 			case <-sub.f.headers:
+			case <-sub.f.finalized:
 			}
 		}
 
@@ -472,6 +483,7 @@ func (es *EventSystem) eventLoop() {
 		es.rmLogsSub.Unsubscribe()
 		es.chainSub.Unsubscribe()
 		es.stateSyncSub.Unsubscribe()
+		es.finalizedSub.Unsubscribe()
 	}()
 
 	index := make(filterIndex)
@@ -493,6 +505,8 @@ func (es *EventSystem) eventLoop() {
 			es.handleChainEvent(index, ev)
 		case ev := <-es.stateSyncCh:
 			es.handleStateSyncEvent(index, ev)
+		case ev := <-es.finalizedCh:
+			es.handleFinalizedHeadEvent(index, ev)
 
 		case f := <-es.install:
 			index[f.typ][f.id] = f
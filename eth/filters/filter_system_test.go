@@ -50,12 +50,17 @@ type testBackend struct {
 	pendingReceipts types.Receipts
 
 	stateSyncFeed event.Feed
+	finalizedFeed event.Feed
 }
 
 func (b *testBackend) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) event.Subscription {
 	return b.stateSyncFeed.Subscribe(ch)
 }
 
+func (b *testBackend) SubscribeFinalizedHeadEvent(ch chan<- core.FinalizedHeadEvent) event.Subscription {
+	return b.finalizedFeed.Subscribe(ch)
+}
+
 func (b *testBackend) ChainConfig() *params.ChainConfig {
 	return params.TestChainConfig
 }
@@ -26,6 +26,7 @@ type TestBackend struct {
 	chainFeed       event.Feed
 
 	stateSyncFeed event.Feed
+	finalizedFeed event.Feed
 }
 
 // bloombits logic was removed - https://github.com/ethereum/go-ethereum/pull/31081
@@ -183,6 +184,10 @@ func (b *TestBackend) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) eve
 	return b.stateSyncFeed.Subscribe(ch)
 }
 
+func (b *TestBackend) SubscribeFinalizedHeadEvent(ch chan<- core.FinalizedHeadEvent) event.Subscription {
+	return b.finalizedFeed.Subscribe(ch)
+}
+
 func (b *TestBackend) ChainConfig() *params.ChainConfig { panic("not implemented") }
 
 func (b *TestBackend) CurrentHeader() *types.Header { panic("not implemented") }
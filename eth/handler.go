@@ -33,6 +33,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/fetcher"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
@@ -106,6 +107,8 @@ type handlerConfig struct {
 	EthAPI              *ethapi.BlockChainAPI  // EthAPI to interact
 	enableBlockTracking bool                   // Whether to log information collected while tracking block lifecycle
 	txAnnouncementOnly  bool                   // Whether to only announce txs to peers
+	PeerBanThreshold    uint64                 // Number of conflicting chains after which a peer is banned (0 = default)
+	PeerFailureExpiry   time.Duration          // Time after which the conflicting chains of a peer are forgiven (0 = default)
 }
 
 type handler struct {
@@ -114,7 +117,9 @@ type handler struct {
 	forkFilter forkid.Filter // Fork ID filter, constant across the lifetime of the node
 
 	snapSync atomic.Bool // Flag whether snap sync is enabled (gets disabled if we already have blocks)
-	synced   atomic.Bool // Flag whether we're considered synchronised (enables transaction processing)
+
+	milestoneSync bool        // Flag whether snap sync is run as milestone sync, anchored at the finalized block
+	synced        atomic.Bool // Flag whether we're considered synchronised (enables transaction processing)
 
 	database ethdb.Database
 	txpool   txPool
@@ -134,6 +139,10 @@ type handler struct {
 	minedBlockSub *event.TypeMuxSubscription
 
 	requiredBlocks map[uint64]common.Hash
+	origins        *blockOrigins   // Peers which served the recent blocks
+	reputation     *peerReputation // Peers which served chains conflicting with the whitelist
+
+	latestMilestone atomic.Pointer[fetchedMilestone] // Latest milestone fetched from heimdall, nil if none yet
 
 	enableBlockTracking bool
 	txAnnouncementOnly  bool
@@ -155,6 +164,17 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		config.EventMux = new(event.TypeMux) // Nicety initialization for tests
 	}
 
+	banThreshold, failureExpiry := config.PeerBanThreshold, config.PeerFailureExpiry
+	if banThreshold == 0 {
+		banThreshold = ethconfig.Defaults.PeerBanThreshold
+	}
+
+	if failureExpiry == 0 {
+		failureExpiry = ethconfig.Defaults.PeerFailureExpiry
+	}
+
+	reputation := newPeerReputation(config.Database, banThreshold, failureExpiry)
+
 	h := &handler{
 		nodeID:              config.NodeID,
 		networkID:           config.Network,
@@ -163,9 +183,12 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		database:            config.Database,
 		txpool:              config.TxPool,
 		chain:               config.Chain,
-		peers:               newPeerSet(),
+		peers:               newPeerSet(reputation),
 		ethAPI:              config.EthAPI,
 		requiredBlocks:      config.RequiredBlocks,
+		origins:             newBlockOrigins(),
+		reputation:          reputation,
+		milestoneSync:       config.Sync == downloader.MilestoneSync,
 		enableBlockTracking: config.enableBlockTracking,
 		txAnnouncementOnly:  config.txAnnouncementOnly,
 		quitSync:            make(chan struct{}),
@@ -209,6 +232,13 @@ func newHandler(config *handlerConfig) (*handler, error) {
 	}
 	// Construct the downloader (long sync)
 	h.downloader = downloader.New(config.Database, h.eventMux, h.chain, nil, h.removePeer, h.enableSyncedFeatures, config.checker)
+	h.downloader.SetOriginRecorder(h.origins.add)
+
+	if verifier, ok := h.chain.Engine().(downloader.SpanSignerVerifier); ok {
+		h.downloader.SetSpanSignerVerifier(verifier)
+	} else if h.milestoneSync {
+		return nil, errors.New("milestone sync not supported without span signers to verify the headers against")
+	}
 
 	// Construct the fetcher (short sync)
 	validator := func(header *types.Header) error {
@@ -237,7 +267,14 @@ func newHandler(config *handlerConfig) (*handler, error) {
 			return 0, nil
 		}
 
-		return h.chain.InsertChain(blocks)
+		n, err := h.chain.InsertChain(blocks)
+		if err == nil {
+			for _, block := range blocks {
+				h.origins.add(block.Hash(), blockOrigin(block))
+			}
+		}
+
+		return n, err
 	}
 
 	// If snap sync is requested but snapshots are disabled, fail loudly
@@ -340,8 +377,13 @@ func (h *handler) runEthPeer(peer *eth.Peer, handler eth.Handler) error {
 			}
 		}
 	}
-	// Ignore maxPeers if this is a trusted peer
+	// Ignore maxPeers and the bans if this is a trusted peer, and the bans if it's a static one
 	if !peer.Peer.Info().Network.Trusted {
+		if !peer.IsStatic() && h.reputation.banned(peer.ID()) {
+			peer.Log().Debug("Rejected banned peer", "failures", h.reputation.failures(peer.ID()))
+			return p2p.DiscUselessPeer
+		}
+
 		if reject || h.peers.len() >= h.maxPeers {
 			return p2p.DiscTooManyPeers
 		}
@@ -512,6 +554,14 @@ func (h *handler) Start(maxPeers int) {
 	// start peer handler tracker
 	h.wg.Add(1)
 	go h.protoTracker()
+
+	// forgive the peers which stopped serving conflicting chains
+	h.wg.Add(1)
+
+	go func() {
+		defer h.wg.Done()
+		h.reputation.pruneLoop(h.quitSync)
+	}()
 }
 
 func (h *handler) Stop() {
@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -85,9 +86,28 @@ func (h *ethHandler) fetchWhitelistMilestone(ctx context.Context, bor *bor.Bor)
 
 // handleMilestone verify and process the fetched milestone
 func (h *ethHandler) handleMilestone(ctx context.Context, eth *Ethereum, milestone *milestone.Milestone, verifier *borVerifier) error {
+	// Record the milestone for the sync loop to tell whether the whitelist is current
+	h.latestMilestone.Store(&fetchedMilestone{milestone: milestone, time: time.Now()})
+
 	// Verify if the milestone fetched can be added to the local whitelist entry or not. If verified,
 	// the hash of the end block of the milestone is returned else appropriate error is returned.
 	_, err := verifier.verify(ctx, eth, h, milestone.StartBlock, milestone.EndBlock, milestone.Hash.String()[2:], false)
+
+	// Record the milestone along with the outcome of its verification, whether it could
+	// be whitelisted or not
+	outcome := rawdb.MilestoneWhitelisted
+
+	switch {
+	case errors.Is(err, errChainOutOfSync):
+		outcome = rawdb.MilestoneDeferred
+	case errors.Is(err, errHashMismatch):
+		outcome = rawdb.MilestoneMismatch
+	case err != nil:
+		outcome = rawdb.MilestoneFailed
+	}
+
+	writeMilestoneHistory(eth.ChainDb(), milestone, outcome)
+
 	if err != nil {
 		if errors.Is(err, errChainOutOfSync) {
 			log.Info("Whitelisting milestone deferred", "err", err)
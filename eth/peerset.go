@@ -55,18 +55,21 @@ type peerSet struct {
 	snapWait map[string]chan *snap.Peer // Peers connected on `eth` waiting for their snap extension
 	snapPend map[string]*snap.Peer      // Peers connected on the `snap` protocol, but not yet on `eth`
 
+	reputation *peerReputation // Peers which served chains conflicting with the whitelist
+
 	lock   sync.RWMutex
 	closed bool
 	quitCh chan struct{} // Quit channel to signal termination
 }
 
 // newPeerSet creates a new peer set to track the active participants.
-func newPeerSet() *peerSet {
+func newPeerSet(reputation *peerReputation) *peerSet {
 	return &peerSet{
-		peers:    make(map[string]*ethPeer),
-		snapWait: make(map[string]chan *snap.Peer),
-		snapPend: make(map[string]*snap.Peer),
-		quitCh:   make(chan struct{}),
+		peers:      make(map[string]*ethPeer),
+		snapWait:   make(map[string]chan *snap.Peer),
+		snapPend:   make(map[string]*snap.Peer),
+		reputation: reputation,
+		quitCh:     make(chan struct{}),
 	}
 }
 
@@ -258,25 +261,46 @@ func (ps *peerSet) snapLen() int {
 }
 
 // peerWithHighestTD retrieves the known peer with the currently highest total
-// difficulty, but below the given PoS switchover threshold.
-func (ps *peerSet) peerWithHighestTD() *eth.Peer {
+// difficulty. Among the peers ahead of the given local total difficulty, the ones
+// which served the fewest chains conflicting with the whitelist are preferred.
+func (ps *peerSet) peerWithHighestTD(local *big.Int) *eth.Peer {
 	ps.lock.RLock()
 	defer ps.lock.RUnlock()
 
 	var (
-		bestPeer *eth.Peer
-		bestTd   *big.Int
+		bestPeer     *eth.Peer
+		bestTd       *big.Int
+		bestFailures uint64
 	)
 
 	for _, p := range ps.peers {
-		if _, td := p.Head(); bestPeer == nil || td.Cmp(bestTd) > 0 {
-			bestPeer, bestTd = p.Peer, td
+		_, td := p.Head()
+		failures := ps.reputation.failures(p.ID())
+
+		if bestPeer == nil || betterSyncPeer(local, td, failures, bestTd, bestFailures) {
+			bestPeer, bestTd, bestFailures = p.Peer, td, failures
 		}
 	}
 
 	return bestPeer
 }
 
+// betterSyncPeer returns whether a peer is a better sync target than the best one so far,
+// given their total difficulties and failures. The failures only matter among the peers
+// ahead of the local chain, the others not being synced with anyway.
+func betterSyncPeer(local *big.Int, td *big.Int, failures uint64, bestTd *big.Int, bestFailures uint64) bool {
+	ahead, bestAhead := td.Cmp(local) > 0, bestTd.Cmp(local) > 0
+	if ahead != bestAhead {
+		return ahead
+	}
+
+	if ahead && failures != bestFailures {
+		return failures < bestFailures
+	}
+
+	return td.Cmp(bestTd) > 0
+}
+
 // close disconnects all peers.
 func (ps *peerSet) close() {
 	ps.lock.Lock()
@@ -160,12 +160,25 @@ func (cs *chainSyncer) nextSyncOp() *chainSyncOp {
 	// We have enough peers, pick the one with the highest TD, but avoid going
 	// over the terminal total difficulty. Above that we expect the consensus
 	// clients to direct the chain head to sync to.
-	peer := cs.handler.peers.peerWithHighestTD()
+	mode, ourTD := cs.modeAndLocalHead()
+
+	// Milestone sync downloads the state over snap, which is disabled in bor otherwise,
+	// hence it waits for peers serving snap instead of falling back to full sync
+	if mode == downloader.MilestoneSync && !cs.handler.milestoneSyncReady() {
+		if time.Since(cs.warned) > 10*time.Second {
+			log.Warn("Milestone sync waiting for snap peers")
+
+			cs.warned = time.Now()
+		}
+
+		return nil
+	}
+
+	peer := cs.handler.peers.peerWithHighestTD(ourTD)
 	if peer == nil {
 		return nil
 	}
 
-	mode, ourTD := cs.modeAndLocalHead()
 	op := peerToSyncOp(mode, peer)
 
 	if op.td.Cmp(ourTD) <= 0 {
@@ -184,12 +197,25 @@ func (cs *chainSyncer) nextSyncOp() *chainSyncOp {
 	return op
 }
 
+// milestoneSyncReady returns whether milestone sync can download the state, i.e. whether
+// any peer serves snap.
+func (h *handler) milestoneSyncReady() bool {
+	return h.peers.snapLen() > 0
+}
+
 func peerToSyncOp(mode downloader.SyncMode, p *eth.Peer) *chainSyncOp {
 	peerHead, peerTD := p.Head()
 	return &chainSyncOp{mode: mode, peer: p, td: peerTD, head: peerHead}
 }
 
 func (cs *chainSyncer) modeAndLocalHead() (downloader.SyncMode, *big.Int) {
+	// Run milestone sync while catching up from an empty database, the state being
+	// downloaded at a finalized block only
+	if cs.handler.milestoneSync && cs.handler.snapSync.Load() {
+		block := cs.handler.chain.CurrentSnapBlock()
+		td := cs.handler.chain.GetTd(block.Hash(), block.Number.Uint64())
+		return downloader.MilestoneSync, td
+	}
 	// Enforce full sync as snap sync is disabled momentarily
 	head := cs.handler.chain.CurrentBlock()
 	td := cs.handler.chain.GetTd(head.Hash(), head.Number.Uint64())
@@ -239,7 +265,7 @@ func (cs *chainSyncer) startSync(op *chainSyncOp) {
 
 // doSync synchronizes the local blockchain with a remote peer.
 func (h *handler) doSync(op *chainSyncOp) error {
-	if op.mode == downloader.SnapSync {
+	if op.mode == downloader.SnapSync || op.mode == downloader.MilestoneSync {
 		// Before launch the snap sync, we have to ensure user uses the same
 		// txlookup limit.
 		// The main concern here is: during the snap sync Geth won't index the
@@ -260,6 +286,12 @@ func (h *handler) doSync(op *chainSyncOp) error {
 	// Run the sync cycle, and disable snap sync if we're past the pivot block
 	err := h.downloader.LegacySync(op.peer.ID(), op.head, op.td, h.chain.Config().TerminalTotalDifficulty, op.mode)
 	if err != nil {
+		// Penalize the peer if it served a chain conflicting with the whitelist, banning it
+		// if it keeps doing so, for the next cycles to be run against other peers
+		if isWhitelistFailure(err) {
+			h.penalizeSyncPeer(op.peer, err)
+		}
+
 		return err
 	}
 	h.enableSyncedFeatures()
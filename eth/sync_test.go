@@ -95,3 +95,25 @@ func testSnapSyncDisabling(t *testing.T, ethVer uint, snapVer uint) {
 		t.Fatalf("snap sync not disabled after successful synchronisation")
 	}
 }
+
+// Tests that the sync mode run by the chain syncer is full sync unless milestone sync
+// is enabled, as snap sync is disabled momentarily.
+func TestSyncModeSnapDisabled(t *testing.T) {
+	handler := newTestHandler()
+	defer handler.close()
+
+	if mode, _ := handler.handler.chainSync.modeAndLocalHead(); mode != downloader.FullSync {
+		t.Fatalf("sync mode mismatch: have %v, want %v", mode, downloader.FullSync)
+	}
+
+	handler.handler.milestoneSync = true
+
+	if mode, _ := handler.handler.chainSync.modeAndLocalHead(); mode != downloader.MilestoneSync {
+		t.Fatalf("sync mode mismatch: have %v, want %v", mode, downloader.MilestoneSync)
+	}
+
+	// Milestone sync waits for peers serving snap
+	if handler.handler.milestoneSyncReady() {
+		t.Fatalf("milestone sync ready without snap peers")
+	}
+}
@@ -208,6 +208,9 @@ type TraceConfig struct {
 	TracerConfig    json.RawMessage
 	BorTraceEnabled *bool
 	BorTx           *bool
+	// Parallel traces the transactions of a block concurrently, each on the state it was
+	// executed on by a first blockstm pass, rather than on states produced by a serial replay
+	Parallel *bool
 }
 
 // TraceCallConfig is the config for traceCall API. It holds one more
@@ -803,6 +806,15 @@ func (api *API) traceBlock(ctx context.Context, block *types.Block, config *Trac
 
 	defer release()
 
+	if config.Parallel != nil && *config.Parallel && !ioflag {
+		results, err := api.traceBlockParallel(ctx, block, statedb, config)
+		if err == nil || ctx.Err() != nil {
+			return results, err
+		}
+
+		log.Debug("Parallel block tracing failed, tracing serially", "number", block.NumberU64(), "err", err)
+	}
+
 	// create and add empty mvHashMap in statedb as StateAtBlock does not have mvHashmap in it.
 	if ioflag {
 		statedb.AddEmptyMVHashMap()
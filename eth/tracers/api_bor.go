@@ -3,10 +3,13 @@ package tracers
 import (
 	"context"
 	"fmt"
+	"runtime"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/bor/statefull"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
@@ -68,14 +71,46 @@ func (api *API) traceBorBlock(ctx context.Context, block *types.Block, config *T
 
 	// Execute all the transaction contained within the block concurrently
 	var (
-		signer                               = types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
 		txs, stateSyncPresent, stateSyncHash = api.getAllBlockTransactions(ctx, block)
 		deleteEmptyObjects                   = api.backend.ChainConfig().IsEIP158(block.Number())
 	)
 
-	blockCtx := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+	traceTxn := api.newBorTxTracer(ctx, block, config)
 
-	traceTxn := func(indx int, tx *types.Transaction, borTx bool, stateSyncHash common.Hash) *TxTraceResult {
+	if config != nil && config.Parallel != nil && *config.Parallel {
+		traces, err := api.traceBorBlockParallel(ctx, block, statedb, txs, stateSyncPresent, stateSyncHash, traceTxn)
+		if err == nil || ctx.Err() != nil {
+			res.Transactions = traces
+			return res, err
+		}
+
+		log.Debug("Parallel bor block tracing failed, tracing serially", "number", block.NumberU64(), "err", err)
+	}
+
+	intermediateRoot := func() common.Hash {
+		return statedb.IntermediateRoot(deleteEmptyObjects)
+	}
+
+	for indx, tx := range txs {
+		if stateSyncPresent && indx == len(txs)-1 {
+			res.Transactions = append(res.Transactions, traceTxn(statedb, indx, tx, true, stateSyncHash, intermediateRoot))
+		} else {
+			res.Transactions = append(res.Transactions, traceTxn(statedb, indx, tx, false, stateSyncHash, intermediateRoot))
+		}
+	}
+
+	return res, nil
+}
+
+// borTxTracer traces a transaction on the given state, taking the intermediate root after it
+// from the given function
+type borTxTracer func(statedb *state.StateDB, indx int, tx *types.Transaction, borTx bool, stateSyncHash common.Hash, intermediateRoot func() common.Hash) *TxTraceResult
+
+// newBorTxTracer returns the function tracing the transactions of the block with the struct logger
+func (api *API) newBorTxTracer(ctx context.Context, block *types.Block, config *TraceConfig) borTxTracer {
+	signer := types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
+
+	return func(statedb *state.StateDB, indx int, tx *types.Transaction, borTx bool, stateSyncHash common.Hash, intermediateRoot func() common.Hash) *TxTraceResult {
 		message, _ := core.TransactionToMessage(tx, signer, block.BaseFee())
 		txHash := tx.Hash()
 		if borTx {
@@ -84,14 +119,22 @@ func (api *API) traceBorBlock(ctx context.Context, block *types.Block, config *T
 
 		tracer := logger.NewStructLogger(config.Config)
 
-		// Run the transaction with tracing enabled.
+		// Run the transaction with tracing enabled. The block context is created for every
+		// transaction as its block hash lookup isn't safe for concurrent use.
+		blockCtx := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
 		vmenv := vm.NewEVM(blockCtx, statedb, api.backend.ChainConfig(), vm.Config{Tracer: tracer.Hooks(), NoBaseFee: true})
 
 		// Call Prepare to clear out the statedb access list
 		// Not sure if we need to do this
 		statedb.SetTxContext(txHash, indx)
 
-		var execRes *core.ExecutionResult
+		// The logger reads the state through the context it's given when a transaction starts
+		tracer.OnTxStart(vmenv.GetVMContext(), tx, message.From)
+
+		var (
+			execRes *core.ExecutionResult
+			err     error
+		)
 
 		if borTx {
 			callmsg := prepareCallMessage(*message)
@@ -114,21 +157,79 @@ func (api *API) traceBorBlock(ctx context.Context, block *types.Block, config *T
 		}
 		res := &TxTraceResult{
 			Result:           result,
-			IntermediateHash: statedb.IntermediateRoot(deleteEmptyObjects),
+			IntermediateHash: intermediateRoot(),
 		}
 
 		return res
 	}
+}
 
-	for indx, tx := range txs {
-		if stateSyncPresent && indx == len(txs)-1 {
-			res.Transactions = append(res.Transactions, traceTxn(indx, tx, true, stateSyncHash))
-		} else {
-			res.Transactions = append(res.Transactions, traceTxn(indx, tx, false, stateSyncHash))
+// traceBorBlockParallel traces the transactions of the block concurrently, each on the state
+// it was executed on by a first blockstm pass which computes the intermediate roots as well.
+// The state sync transaction is traced last, on the state after all the other transactions.
+func (api *API) traceBorBlockParallel(ctx context.Context, block *types.Block, statedb *state.StateDB, txs types.Transactions, stateSyncPresent bool, stateSyncHash common.Hash, traceTxn borTxTracer) ([]*TxTraceResult, error) {
+	prestates, err := core.ExecuteParallelPrestates(ctx, api.backend.ChainConfig(), api.chainContext(ctx), block, statedb, runtime.NumCPU(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		traces = make([]*TxTraceResult, len(txs))
+		n      = len(block.Transactions())
+		pend   sync.WaitGroup
+	)
+
+	threads := runtime.NumCPU()
+	if threads > n {
+		threads = n
+	}
+
+	jobs := make(chan *txTraceTask, threads)
+
+	for th := 0; th < threads; th++ {
+		pend.Add(1)
+
+		go func() {
+			defer pend.Done()
+
+			for task := range jobs {
+				index := task.index
+				traces[index] = traceTxn(task.statedb, index, txs[index], false, stateSyncHash, func() common.Hash {
+					return prestates.IntermediateRoot(index)
+				})
+			}
+		}()
+	}
+
+	var failed error
+
+txloop:
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			failed = ctx.Err()
+			break txloop
+		case jobs <- &txTraceTask{statedb: prestates.StateAt(i), index: i}:
 		}
 	}
 
-	return res, nil
+	close(jobs)
+	pend.Wait()
+
+	if failed != nil {
+		return nil, failed
+	}
+
+	if stateSyncPresent {
+		final := prestates.FinalState()
+		deleteEmptyObjects := api.backend.ChainConfig().IsEIP158(block.Number())
+
+		traces[len(txs)-1] = traceTxn(final, len(txs)-1, txs[len(txs)-1], true, stateSyncHash, func() common.Hash {
+			return final.IntermediateRoot(deleteEmptyObjects)
+		})
+	}
+
+	return traces, nil
 }
 
 type TraceBlockRequest struct {
@@ -0,0 +1,120 @@
+package tracers
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// traceBlockParallel traces the transactions of the block concurrently. The transactions are
+// first executed by blockstm, using the dependency metadata of the block if any, so that the
+// state each transaction was executed on is known without replaying the block serially. The
+// results are identical to the ones of the serial tracing. The given state isn't modified.
+func (api *API) traceBlockParallel(ctx context.Context, block *types.Block, statedb *state.StateDB, config *TraceConfig) ([]*txTraceResult, error) {
+	prestates, err := core.ExecuteParallelPrestates(ctx, api.backend.ChainConfig(), api.chainContext(ctx), block, statedb, runtime.NumCPU(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		txs, stateSyncPresent, stateSyncHash = api.getAllBlockTransactions(ctx, block)
+		blockHash                            = block.Hash()
+		signer                               = types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
+		results                              = make([]*txTraceResult, len(txs))
+		pend                                 sync.WaitGroup
+	)
+
+	// Set up front as tracing a transaction sets it otherwise
+	if config.BorTx == nil {
+		config.BorTx = newBoolPtr(false)
+	}
+
+	borConfig := *config
+	borConfig.BorTx = newBoolPtr(true)
+
+	threads := runtime.NumCPU()
+	if threads > len(txs) {
+		threads = len(txs)
+	}
+
+	jobs := make(chan *txTraceTask, threads)
+
+	for th := 0; th < threads; th++ {
+		pend.Add(1)
+
+		go func() {
+			defer pend.Done()
+
+			for task := range jobs {
+				msg, _ := core.TransactionToMessage(txs[task.index], signer, block.BaseFee())
+				txHash := txs[task.index].Hash()
+				txConfig := config
+
+				if stateSyncPresent && task.index == len(txs)-1 {
+					txHash = stateSyncHash
+					txConfig = &borConfig
+				}
+
+				txctx := &Context{
+					BlockHash:   blockHash,
+					BlockNumber: block.Number(),
+					TxIndex:     task.index,
+					TxHash:      txHash,
+				}
+
+				// The block hash lookup of a block context isn't safe for concurrent use
+				blockCtx := core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+
+				res, err := api.traceTx(ctx, txs[task.index], msg, txctx, blockCtx, task.statedb, txConfig, nil)
+				if err != nil {
+					results[task.index] = &txTraceResult{TxHash: txHash, Error: err.Error()}
+					continue
+				}
+
+				results[task.index] = &txTraceResult{TxHash: txHash, Result: res}
+			}
+		}()
+	}
+
+	var failed error
+
+txloop:
+	for i := range txs {
+		var task *txTraceTask
+
+		// State sync transactions are applied after all the transactions of the block
+		if stateSyncPresent && i == len(txs)-1 {
+			if !*config.BorTraceEnabled {
+				break
+			}
+
+			task = &txTraceTask{statedb: prestates.FinalState(), index: i}
+		} else {
+			task = &txTraceTask{statedb: prestates.StateAt(i), index: i}
+		}
+
+		select {
+		case <-ctx.Done():
+			failed = ctx.Err()
+			break txloop
+		case jobs <- task:
+		}
+	}
+
+	close(jobs)
+	pend.Wait()
+
+	if failed != nil {
+		return nil, failed
+	}
+
+	if !*config.BorTraceEnabled && stateSyncPresent {
+		return results[:len(results)-1], nil
+	}
+
+	return results, nil
+}
@@ -215,6 +215,11 @@ func (b *testBackend) GetBorBlockTransactionWithBlockHash(ctx context.Context, t
 	return tx, blockHash, blockNumber, index, nil
 }
 
+func init() {
+	// Registered once as the directory isn't safe for concurrent use by the parallel tests
+	DefaultDirectory.Register("stateTracer", newStateTracer, false)
+}
+
 type stateTracer struct {
 	Balance map[common.Address]*hexutil.Big
 	Nonce   map[common.Address]hexutil.Uint64
@@ -290,7 +295,6 @@ func TestStateHooks(t *testing.T) {
 		})
 	)
 	defer backend.teardown()
-	DefaultDirectory.Register("stateTracer", newStateTracer, false)
 	api := NewAPI(backend)
 	tracer := "stateTracer"
 	res, err := api.TraceCall(t.Context(), ethapi.TransactionArgs{From: &from, To: &to, Value: (*hexutil.Big)(big.NewInt(1000))}, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), &TraceCallConfig{TraceConfig: TraceConfig{Tracer: &tracer}})
@@ -1374,3 +1378,205 @@ func TestTraceBlockWithBasefee(t *testing.T) {
 		}
 	}
 }
+
+func TestTraceBlockParallel(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(3)
+		coinbase = common.HexToAddress("0x00000000000000000000000000000000c0ffee00")
+		counter  = common.HexToAddress("0x00000000000000000000000000000000c0ffee01")
+		watcher  = common.HexToAddress("0x00000000000000000000000000000000c0ffee02")
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+				accounts[2].addr: {Balance: big.NewInt(params.Ether)},
+				// Increments the counter in slot 0
+				counter: {Code: []byte{
+					byte(vm.PUSH1), 0x0, byte(vm.SLOAD), byte(vm.PUSH1), 0x1, byte(vm.ADD),
+					byte(vm.PUSH1), 0x0, byte(vm.SSTORE), byte(vm.STOP),
+				}},
+				// Stores the balance of the coinbase in slot 0
+				watcher: {Code: []byte{
+					byte(vm.COINBASE), byte(vm.BALANCE), byte(vm.PUSH1), 0x0, byte(vm.SSTORE), byte(vm.STOP),
+				}},
+			},
+		}
+		signer = types.HomesteadSigner{}
+		nonces = make([]uint64, len(accounts))
+	)
+
+	backend := newTestBackend(t, 2, genesis, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(coinbase)
+
+		send := func(from int, to common.Address, value int64) {
+			tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+				Nonce:    nonces[from],
+				To:       &to,
+				Value:    big.NewInt(value),
+				Gas:      100000,
+				GasPrice: new(big.Int).Add(b.BaseFee(), big.NewInt(params.GWei)),
+			}), signer, accounts[from].key)
+			b.AddTx(tx)
+			nonces[from]++
+		}
+
+		send(0, accounts[1].addr, 1000)
+		send(1, counter, 0)
+		send(2, counter, 0)
+		send(0, coinbase, 1000)
+		send(2, accounts[0].addr, 1000)
+
+		// Reading the balance of the coinbase prevents fees from being delayed
+		if i == 1 {
+			send(1, watcher, 0)
+		}
+	})
+	defer backend.teardown()
+
+	api := NewAPI(backend)
+	var (
+		stateTracer    = "stateTracer"
+		callTracer     = "callTracer"
+		prestateTracer = "prestateTracer"
+	)
+
+	// The parallel tracers are called directly as tracing falls back to serial on failure
+	stateAt := func(number rpc.BlockNumber) (*types.Block, *state.StateDB) {
+		block, err := api.blockByNumber(t.Context(), number)
+		if err != nil {
+			t.Fatalf("block %d: failed to retrieve block: %v", number, err)
+		}
+
+		parent, err := api.blockByNumber(t.Context(), number-1)
+		if err != nil {
+			t.Fatalf("block %d: failed to retrieve parent: %v", number, err)
+		}
+
+		statedb, release, err := backend.StateAtBlock(t.Context(), parent, defaultTraceReexec, nil, true, false)
+		if err != nil {
+			t.Fatalf("block %d: failed to retrieve parent state: %v", number, err)
+		}
+
+		t.Cleanup(release)
+
+		return block, statedb
+	}
+
+	for number := rpc.BlockNumber(1); number <= 2; number++ {
+		for _, tracer := range []*string{nil, &stateTracer, &callTracer, &prestateTracer} {
+			serial, err := api.TraceBlockByNumber(t.Context(), number, &TraceConfig{Tracer: tracer})
+			if err != nil {
+				t.Fatalf("block %d: failed to trace serially: %v", number, err)
+			}
+
+			block, statedb := stateAt(number)
+
+			parallel, err := api.traceBlockParallel(t.Context(), block, statedb, &TraceConfig{Tracer: tracer, Parallel: newBoolPtr(true)})
+			if err != nil {
+				t.Fatalf("block %d: failed to trace in parallel: %v", number, err)
+			}
+
+			have, _ := json.Marshal(parallel)
+			want, _ := json.Marshal(serial)
+
+			if string(have) != string(want) {
+				t.Fatalf("block %d: result mismatch\nhave: %s\nwant: %s", number, have, want)
+			}
+		}
+
+		serial, err := api.TraceBorBlock(&TraceBlockRequest{Number: int64(number), Config: &TraceConfig{}})
+		if err != nil {
+			t.Fatalf("block %d: failed to trace bor block serially: %v", number, err)
+		}
+
+		block, statedb := stateAt(number)
+		config := &TraceConfig{Parallel: newBoolPtr(true)}
+		txs, stateSyncPresent, stateSyncHash := api.getAllBlockTransactions(t.Context(), block)
+
+		parallel, err := api.traceBorBlockParallel(t.Context(), block, statedb, txs, stateSyncPresent, stateSyncHash, api.newBorTxTracer(t.Context(), block, config))
+		if err != nil {
+			t.Fatalf("block %d: failed to trace bor block in parallel: %v", number, err)
+		}
+
+		have, _ := json.Marshal(parallel)
+		want, _ := json.Marshal(serial.Transactions)
+
+		if string(have) != string(want) {
+			t.Fatalf("block %d: bor result mismatch\nhave: %s\nwant: %s", number, have, want)
+		}
+	}
+}
+
+func TestTraceBorBlock(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		counter  = common.HexToAddress("0x00000000000000000000000000000000c0ffee01")
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				// Increments the counter in slot 0
+				counter: {Code: []byte{
+					byte(vm.PUSH1), 0x0, byte(vm.SLOAD), byte(vm.PUSH1), 0x1, byte(vm.ADD),
+					byte(vm.PUSH1), 0x0, byte(vm.SSTORE), byte(vm.STOP),
+				}},
+			},
+		}
+		signer = types.HomesteadSigner{}
+	)
+
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		for nonce := uint64(0); nonce < 2; nonce++ {
+			tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+				Nonce:    nonce,
+				To:       &counter,
+				Gas:      100000,
+				GasPrice: b.BaseFee(),
+			}), signer, accounts[0].key)
+			b.AddTx(tx)
+		}
+	})
+	defer backend.teardown()
+
+	api := NewAPI(backend)
+
+	res, err := api.TraceBorBlock(&TraceBlockRequest{Number: 1, Config: &TraceConfig{}})
+	if err != nil {
+		t.Fatalf("failed to trace bor block: %v", err)
+	}
+
+	if len(res.Transactions) != 2 {
+		t.Fatalf("transaction count mismatch: have %d, want 2", len(res.Transactions))
+	}
+
+	// The logger of every transaction must see the storage written by the previous ones
+	for i, tx := range res.Transactions {
+		if tx.Error != "" {
+			t.Fatalf("transaction %d: failed to trace: %v", i, tx.Error)
+		}
+
+		result := tx.Result.(*logger.ExecutionResult)
+		if len(result.StructLogs) != 7 {
+			t.Fatalf("transaction %d: struct log count mismatch: have %d, want 7", i, len(result.StructLogs))
+		}
+
+		var sstore struct {
+			Op      string            `json:"op"`
+			Storage map[string]string `json:"storage"`
+		}
+
+		if err := json.Unmarshal(result.StructLogs[5], &sstore); err != nil {
+			t.Fatalf("transaction %d: failed to decode struct log: %v", i, err)
+		}
+
+		want := fmt.Sprintf("%064x", i+1)
+		if have := sstore.Storage[fmt.Sprintf("%064x", 0)]; sstore.Op != "SSTORE" || have != want {
+			t.Fatalf("transaction %d: storage mismatch: op %s, have %s, want %s", i, sstore.Op, have, want)
+		}
+	}
+}
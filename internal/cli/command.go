@@ -99,6 +99,16 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"debug finality": func() (MarkDownCommand, error) {
+			return &DebugFinalityCommand{
+				Meta2: meta2,
+			}, nil
+		},
+		"debug production": func() (MarkDownCommand, error) {
+			return &DebugProductionCommand{
+				Meta2: meta2,
+			}, nil
+		},
 		"chain": func() (MarkDownCommand, error) {
 			return &ChainCommand{
 				UI: ui,
@@ -114,6 +124,16 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"heimdall": func() (MarkDownCommand, error) {
+			return &HeimdallCommand{
+				UI: ui,
+			}, nil
+		},
+		"heimdall export": func() (MarkDownCommand, error) {
+			return &HeimdallExportCommand{
+				UI: ui,
+			}, nil
+		},
 		"account": func() (MarkDownCommand, error) {
 			return &Account{
 				UI: ui,
@@ -186,6 +206,26 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"span": func() (MarkDownCommand, error) {
+			return &SpanCommand{
+				UI: ui,
+			}, nil
+		},
+		"span simulate": func() (MarkDownCommand, error) {
+			return &SpanSimulateCommand{
+				UI: ui,
+			}, nil
+		},
+		"state-sync": func() (MarkDownCommand, error) {
+			return &StateSyncCommand{
+				UI: ui,
+			}, nil
+		},
+		"state-sync verify": func() (MarkDownCommand, error) {
+			return &StateSyncVerifyCommand{
+				UI: ui,
+			}, nil
+		},
 		"snapshot": func() (MarkDownCommand, error) {
 			return &SnapshotCommand{
 				UI: ui,
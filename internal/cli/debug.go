@@ -50,6 +50,8 @@ func (c *DebugCommand) MarkDown() string {
 		"The ```bor debug``` command takes a debug dump of the running client.",
 		"- [```bor debug pprof```](./debug_pprof.md): Dumps bor pprof traces.",
 		"- [```bor debug block <number>```](./debug_block.md): Dumps bor block traces.",
+		"- [```bor debug production <number>```](./debug_production.md): Prints the production timeline of a block.",
+		"- [```bor debug finality```](./debug_finality.md): Prints the latest finality incidents.",
 	}
 	items = append(items, examples...)
 
@@ -68,7 +70,15 @@ func (c *DebugCommand) Help() string {
 
 	Get the block traces:
 
-		$ bor debug block <number>`
+		$ bor debug block <number>
+
+	Get the production timeline of a block:
+
+		$ bor debug production <number>
+
+	Get the latest finality incidents:
+
+		$ bor debug finality`
 }
 
 // Synopsis implements the cli.Command interface
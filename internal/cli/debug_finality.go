@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+)
+
+// DebugFinalityCommand is the command to print the mismatches between the local chain and
+// the milestones and checkpoints
+type DebugFinalityCommand struct {
+	*Meta2
+
+	count uint64
+	id    uint64
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DebugFinalityCommand) MarkDown() string {
+	items := []string{
+		"# Debug finality",
+		"The ```bor debug finality``` command prints the latest finality incidents recorded by the node. An incident is recorded whenever the local chain doesn't match a " +
+			"milestone or a checkpoint and is rewound: it holds the expected and local hashes, the rewind target, the " +
+			"headers of the rewound branch and of the finalized branch along with their signers, and the peers which " +
+			"served the rewound branch. The details of a single incident are printed with the ```id``` flag.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DebugFinalityCommand) Help() string {
+	return `Usage: bor debug finality
+
+  This command prints the latest finality incidents` + c.Flags().Help()
+}
+
+func (c *DebugFinalityCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("debug finality")
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "count",
+		Usage:   "Number of latest incidents to print",
+		Value:   &c.count,
+		Default: 10,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "id",
+		Usage: "Id of the incident to print the details of",
+		Value: &c.id,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DebugFinalityCommand) Synopsis() string {
+	return "Print the latest finality incidents"
+}
+
+// Run implements the cli.Command interface
+func (c *DebugFinalityCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	borClt, err := c.BorConn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	resp, err := borClt.DebugFinality(context.Background(), &proto.DebugFinalityRequest{Count: c.count, Id: c.id})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.id != 0 && len(resp.Incidents) == 1 {
+		c.UI.Output(formatFinalityIncident(resp.Incidents[0]))
+	} else {
+		c.UI.Output(formatFinalityIncidents(resp.Incidents))
+	}
+
+	return 0
+}
+
+func formatFinalityIncidents(incidents []*proto.DebugFinalityResponse_Incident) string {
+	if len(incidents) == 0 {
+		return emptyPlaceHolder
+	}
+
+	rows := make([]string, len(incidents)+1)
+	rows[0] = "Id|Time|Kind|Start|End|Head|Rewind to|Peers"
+
+	for i, incident := range incidents {
+		rows[i+1] = fmt.Sprintf("%d|%s|%s|%d|%d|%d|%d|%d",
+			incident.Id,
+			time.Unix(int64(incident.Time), 0).UTC().Format(time.RFC3339),
+			incident.Kind,
+			incident.StartBlock,
+			incident.EndBlock,
+			incident.Head,
+			incident.RewindTo,
+			len(incident.Peers),
+		)
+	}
+
+	return formatList(rows)
+}
+
+func formatFinalityIncident(incident *proto.DebugFinalityResponse_Incident) string {
+	peers := "-"
+	if len(incident.Peers) > 0 {
+		peers = strings.Join(incident.Peers, ", ")
+	}
+
+	items := []string{
+		formatKV([]string{
+			fmt.Sprintf("Id|%d", incident.Id),
+			fmt.Sprintf("Time|%s", time.Unix(int64(incident.Time), 0).UTC().Format(time.RFC3339)),
+			fmt.Sprintf("Kind|%s", incident.Kind),
+			fmt.Sprintf("Blocks|%d - %d", incident.StartBlock, incident.EndBlock),
+			fmt.Sprintf("Expected hash|%s", incident.Expected),
+			fmt.Sprintf("Local hash|%s", incident.Local),
+			fmt.Sprintf("Head|%d", incident.Head),
+			fmt.Sprintf("Rewind to|%d", incident.RewindTo),
+			fmt.Sprintf("Peers|%s", peers),
+		}),
+		"",
+		"Rewound branch",
+		formatIncidentHeaders(incident.LocalHeaders),
+		"",
+		"Finalized branch",
+		formatIncidentHeaders(incident.FinalizedHeaders),
+	}
+
+	return strings.Join(items, "\n")
+}
+
+func formatIncidentHeaders(headers []*proto.DebugFinalityResponse_Header) string {
+	if len(headers) == 0 {
+		return emptyPlaceHolder
+	}
+
+	rows := make([]string, len(headers)+1)
+	rows[0] = "Number|Hash|Signer|Peer"
+
+	for i, header := range headers {
+		peer := "-"
+		if header.Peer != "" {
+			peer = header.Peer
+		}
+
+		rows[i+1] = fmt.Sprintf("%d|%s|%s|%s", header.Number, header.Hash, header.Signer, peer)
+	}
+
+	return formatList(rows)
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+)
+
+// DebugProductionCommand is the command to print the production timeline of a block
+type DebugProductionCommand struct {
+	*Meta2
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DebugProductionCommand) MarkDown() string {
+	items := []string{
+		"# Debug production",
+		"The ```bor debug production <number>``` command prints the timeline of the production of a block by the " +
+			"local validator: the delay computed when preparing the header, every start of the block building, the " +
+			"timeout interrupt, the time spent assembling the block and committing the state-sync events, the sealing " +
+			"and the broadcast, along with the transactions which were rejected. Only the recently produced blocks are " +
+			"available, see the ```miner.timelines``` flag of the server.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DebugProductionCommand) Help() string {
+	return `Usage: bor debug production <number>
+
+  This command prints the production timeline of a block` + c.Flags().Help()
+}
+
+func (c *DebugProductionCommand) Flags() *flagset.Flagset {
+	return c.NewFlagSet("debug production")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DebugProductionCommand) Synopsis() string {
+	return "Print the production timeline of a block"
+}
+
+// Run implements the cli.Command interface
+func (c *DebugProductionCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("The block number is required")
+		return 1
+	}
+
+	number, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Invalid block number: %v", err))
+		return 1
+	}
+
+	borClt, err := c.BorConn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	timeline, err := borClt.DebugProduction(context.Background(), &proto.DebugProductionRequest{Number: number})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	hash := "-"
+	if timeline.Hash != "" {
+		hash = timeline.Hash
+	}
+
+	c.UI.Output(formatKV([]string{
+		fmt.Sprintf("Block|%d", timeline.Number),
+		fmt.Sprintf("Hash|%s", hash),
+		fmt.Sprintf("Sealed|%t", timeline.Sealed),
+		fmt.Sprintf("Header time|%s", time.Unix(int64(timeline.HeaderTime), 0).UTC().Format(time.RFC3339)),
+		fmt.Sprintf("Transactions tried|%d", timeline.Tried),
+		fmt.Sprintf("Transactions included|%d", timeline.Included),
+		fmt.Sprintf("Transactions rejected|%d", uint64(len(timeline.Rejected))+timeline.Omitted),
+	}))
+
+	c.UI.Output("")
+	c.UI.Output("Timeline")
+	c.UI.Output(formatProductionEvents(timeline.Events))
+
+	if len(timeline.Rejected) > 0 {
+		c.UI.Output("")
+		c.UI.Output("Rejected transactions")
+		c.UI.Output(formatRejectedTransactions(timeline.Rejected, timeline.Omitted))
+	}
+
+	return 0
+}
+
+func formatProductionEvents(events []*proto.DebugProductionResponse_Event) string {
+	if len(events) == 0 {
+		return emptyPlaceHolder
+	}
+
+	rows := make([]string, len(events)+1)
+	rows[0] = "Time|Offset|Step|Duration|Detail"
+
+	start := time.Unix(0, events[0].Time)
+
+	for i, ev := range events {
+		duration := "-"
+		if ev.Duration != 0 {
+			duration = time.Duration(ev.Duration).String()
+		}
+
+		detail := "-"
+		if ev.Detail != "" {
+			detail = ev.Detail
+		}
+
+		evTime := time.Unix(0, ev.Time)
+
+		rows[i+1] = fmt.Sprintf("%s|+%s|%s|%s|%s",
+			evTime.UTC().Format("15:04:05.000"),
+			evTime.Sub(start),
+			ev.Name,
+			duration,
+			detail,
+		)
+	}
+
+	return formatList(rows)
+}
+
+func formatRejectedTransactions(rejected []*proto.DebugProductionResponse_RejectedTransaction, omitted uint64) string {
+	rows := make([]string, 0, len(rejected)+2)
+	rows = append(rows, "Hash|Reason")
+
+	for _, tx := range rejected {
+		rows = append(rows, fmt.Sprintf("%s|%s", tx.Hash, tx.Reason))
+	}
+
+	if omitted > 0 {
+		rows = append(rows, fmt.Sprintf("(%d more)|-", omitted))
+	}
+
+	return formatList(rows)
+}
@@ -112,3 +112,67 @@ func deleteTraces(dst string) {
 	dst = path.Join(currentDir, dst)
 	os.RemoveAll(dst)
 }
+
+func TestCommand_DebugProduction(t *testing.T) {
+	t.Parallel()
+
+	// Start a blockchain in developer mode and get the production timeline of a block
+	config := server.DefaultConfig()
+
+	config.Developer.Enabled = true
+	config.Developer.Period = 2          // block time
+	config.Developer.GasLimit = 11500000 // initial block gaslimit
+
+	srv, err := server.CreateMockServer(config)
+	require.NoError(t, err)
+
+	defer server.CloseMockServer(srv)
+
+	// wait for 4 seconds to mine a 2 blocks
+	time.Sleep(2 * time.Duration(config.Developer.Period) * time.Second)
+
+	ui := cli.NewMockUi()
+	command := &DebugProductionCommand{
+		Meta2: &Meta2{
+			UI: ui,
+		},
+	}
+
+	// The flags are parsed before the block number
+	res := command.Run([]string{"--address", "127.0.0.1:" + srv.GetGrpcAddr(), "1"})
+	require.Equal(t, 0, res, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Timeline")
+
+	// Blocks which weren't produced are reported
+	res = command.Run([]string{"--address", "127.0.0.1:" + srv.GetGrpcAddr(), "1000000"})
+	require.Equal(t, 1, res)
+}
+
+func TestCommand_DebugFinality(t *testing.T) {
+	t.Parallel()
+
+	config := server.DefaultConfig()
+	config.Developer.Enabled = true
+	config.Developer.Period = 2
+
+	srv, err := server.CreateMockServer(config)
+	require.NoError(t, err)
+
+	defer server.CloseMockServer(srv)
+
+	ui := cli.NewMockUi()
+	command := &DebugFinalityCommand{
+		Meta2: &Meta2{
+			UI: ui,
+		},
+	}
+
+	// No incident is recorded on a healthy chain
+	res := command.Run([]string{"--address", "127.0.0.1:" + srv.GetGrpcAddr()})
+	require.Equal(t, 0, res, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), emptyPlaceHolder)
+
+	// Unknown incidents are reported
+	res = command.Run([]string{"--address", "127.0.0.1:" + srv.GetGrpcAddr(), "--id", "1"})
+	require.Equal(t, 1, res)
+}
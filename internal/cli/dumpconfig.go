@@ -66,6 +66,7 @@ func (c *DumpconfigCommand) Run(args []string) int {
 	userConfig.Gpo.IgnorePriceRaw = userConfig.Gpo.IgnorePrice.String()
 	userConfig.Cache.TrieTimeoutRaw = userConfig.Cache.TrieTimeout.String()
 	userConfig.P2P.TxArrivalWaitRaw = userConfig.P2P.TxArrivalWait.String()
+	userConfig.P2P.BanExpiryRaw = userConfig.P2P.BanExpiry.String()
 
 	if err := toml.NewEncoder(os.Stdout).Encode(userConfig); err != nil {
 		c.UI.Error(err.Error())
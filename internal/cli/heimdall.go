@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// HeimdallCommand is the command to group the heimdall commands
+type HeimdallCommand struct {
+	UI cli.Ui
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *HeimdallCommand) MarkDown() string {
+	items := []string{
+		"# Heimdall",
+		"The ```heimdall``` command groups actions to interact with heimdall data:",
+		"- [```heimdall export```](./heimdall_export.md): Export heimdall data to a local archive for offline header verification.",
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *HeimdallCommand) Help() string {
+	return `Usage: bor heimdall <subcommand>
+
+  This command groups actions to interact with heimdall data.
+
+  Export heimdall data to a local archive:
+
+    $ bor heimdall export --output heimdall-archive.json`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *HeimdallCommand) Synopsis() string {
+	return "Interact with heimdall data"
+}
+
+// Run implements the cli.Command interface
+func (c *HeimdallCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
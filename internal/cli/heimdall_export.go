@@ -0,0 +1,273 @@
+package cli
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallarchive"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+
+	"github.com/mitchellh/cli"
+
+	borTypes "github.com/0xPolygon/heimdall-v2/x/bor/types"
+)
+
+// HeimdallExportCommand is the command to export heimdall data to a local archive
+type HeimdallExportCommand struct {
+	UI cli.Ui
+
+	heimdallURL    string
+	timeout        time.Duration
+	output         string
+	keyFile        string
+	spanFrom       uint64
+	spanTo         uint64
+	checkpointFrom uint64
+	checkpointTo   uint64
+	stateSyncFrom  uint64
+	stateSyncTo    uint64
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *HeimdallExportCommand) MarkDown() string {
+	items := []string{
+		"# Heimdall export",
+		"The ```heimdall export``` command fetches spans, checkpoints, milestones and (optionally) state-sync " +
+			"events from a synced heimdall node and writes them to a local archive. The archive can be used with " +
+			"```--bor.heimdallarchive``` to verify headers without a live heimdall.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *HeimdallExportCommand) Help() string {
+	return `Usage: bor heimdall export [--heimdall <url>] [--output <path>] [--key <path>]
+
+  This command exports heimdall data to a local archive for offline header verification` + c.Flags().Help()
+}
+
+func (c *HeimdallExportCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("heimdall export")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "heimdall",
+		Usage:   "URL of Heimdall service to export the data from",
+		Value:   &c.heimdallURL,
+		Default: "http://localhost:1317",
+	})
+	flags.DurationFlag(&flagset.DurationFlag{
+		Name:    "timeout",
+		Usage:   "Timeout period for the requests to heimdall",
+		Value:   &c.timeout,
+		Default: 5 * time.Second,
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "output",
+		Usage:   "Path of the archive file to write",
+		Value:   &c.output,
+		Default: "heimdall-archive.json",
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "key",
+		Usage: "Path of the hex encoded private key used to sign the archive (optional)",
+		Value: &c.keyFile,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "span.from",
+		Usage:   "First span id to export",
+		Value:   &c.spanFrom,
+		Default: 0,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "span.to",
+		Usage:   "Last span id to export (0 exports up to the latest span)",
+		Value:   &c.spanTo,
+		Default: 0,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "checkpoint.from",
+		Usage:   "First checkpoint number to export",
+		Value:   &c.checkpointFrom,
+		Default: 1,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "checkpoint.to",
+		Usage:   "Last checkpoint number to export (0 exports up to the latest checkpoint)",
+		Value:   &c.checkpointTo,
+		Default: 0,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "statesync.from",
+		Usage:   "First state-sync event id to export (0 skips exporting state-sync events)",
+		Value:   &c.stateSyncFrom,
+		Default: 0,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "statesync.to",
+		Usage:   "Unix time (exclusive) up to which state-sync events are exported (0 exports up to now)",
+		Value:   &c.stateSyncTo,
+		Default: 0,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *HeimdallExportCommand) Synopsis() string {
+	return "Export heimdall data to a local archive"
+}
+
+// Run implements the cli.Command interface
+func (c *HeimdallExportCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	var key *ecdsa.PrivateKey
+	if c.keyFile != "" {
+		var err error
+		if key, err = crypto.LoadECDSA(c.keyFile); err != nil {
+			c.UI.Error(fmt.Sprintf("Failed to load signing key: %v", err))
+			return 1
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client := heimdall.NewHeimdallClient(c.heimdallURL, c.timeout)
+	defer client.Close()
+
+	archive, err := c.export(ctx, client)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	bundle, err := heimdallarchive.NewBundle(archive, key)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to create archive bundle: %v", err))
+		return 1
+	}
+
+	if err := heimdallarchive.WriteFile(c.output, bundle); err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to write archive: %v", err))
+		return 1
+	}
+
+	c.UI.Output(formatKV([]string{
+		fmt.Sprintf("Archive|%s", c.output),
+		fmt.Sprintf("Hash|%s", bundle.Hash),
+		fmt.Sprintf("Signer|%s", bundle.Signer),
+		fmt.Sprintf("Spans|%d", len(archive.Spans)),
+		fmt.Sprintf("Checkpoints|%d", len(archive.Checkpoints)),
+		fmt.Sprintf("Milestones|%d", len(archive.Milestones)),
+		fmt.Sprintf("State sync events|%d", len(archive.EventRecords)),
+	}))
+
+	return 0
+}
+
+func (c *HeimdallExportCommand) export(ctx context.Context, client *heimdall.HeimdallClient) (*heimdallarchive.Archive, error) {
+	archive := &heimdallarchive.Archive{
+		CheckpointStart: int64(c.checkpointFrom),
+	}
+
+	// Spans
+	spanTo := c.spanTo
+	if spanTo == 0 {
+		latest, err := client.GetLatestSpan(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch latest span: %w", err)
+		}
+
+		spanTo = latest.Id
+	}
+
+	if c.spanFrom > spanTo {
+		return nil, fmt.Errorf("invalid span range: from %d, to %d", c.spanFrom, spanTo)
+	}
+
+	archive.Spans = make([]*borTypes.Span, 0, spanTo-c.spanFrom+1)
+
+	for id := c.spanFrom; id <= spanTo; id++ {
+		span, err := client.GetSpan(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch span %d: %w", id, err)
+		}
+
+		archive.Spans = append(archive.Spans, span)
+		archive.ChainID = span.BorChainId
+	}
+
+	// Checkpoints
+	checkpointTo := int64(c.checkpointTo)
+	if checkpointTo == 0 {
+		count, err := client.FetchCheckpointCount(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch checkpoint count: %w", err)
+		}
+
+		checkpointTo = count
+	}
+
+	archive.Checkpoints = make([]*checkpoint.Checkpoint, 0)
+
+	for number := int64(c.checkpointFrom); number <= checkpointTo; number++ {
+		cp, err := client.FetchCheckpoint(ctx, number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch checkpoint %d: %w", number, err)
+		}
+
+		archive.Checkpoints = append(archive.Checkpoints, cp)
+	}
+
+	// Milestones, heimdall only serves the latest one
+	count, err := client.FetchMilestoneCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch milestone count: %w", err)
+	}
+
+	archive.MilestoneCount = count
+	archive.Milestones = make([]*milestone.Milestone, 0, 1)
+
+	if count > 0 {
+		m, err := client.FetchMilestone(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch latest milestone: %w", err)
+		}
+
+		archive.Milestones = append(archive.Milestones, m)
+	}
+
+	// State sync events
+	if c.stateSyncFrom > 0 {
+		to := int64(c.stateSyncTo)
+		if to == 0 {
+			to = time.Now().Unix()
+		}
+
+		events, err := client.StateSyncEvents(ctx, c.stateSyncFrom, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch state sync events: %w", err)
+		}
+
+		archive.EventRecords = events
+	}
+
+	return archive, nil
+}
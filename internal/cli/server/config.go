@@ -244,6 +244,15 @@ type P2PConfig struct {
 
 	// TxAnnouncementOnly is used to only announce transactions to peers
 	TxAnnouncementOnly bool `hcl:"txannouncementonly,optional" toml:"txannouncementonly,optional"`
+
+	// BanThreshold is the number of chains conflicting with the whitelisted milestones
+	// and checkpoints after which a peer is banned
+	BanThreshold uint64 `hcl:"banthreshold,optional" toml:"banthreshold,optional"`
+
+	// BanExpiry is the time after the last conflicting chain served by a peer after
+	// which its failures are forgiven, lifting its ban
+	BanExpiry    time.Duration `hcl:"-,optional" toml:"-"`
+	BanExpiryRaw string        `hcl:"banexpiry,optional" toml:"banexpiry,optional"`
 }
 
 type P2PDiscovery struct {
@@ -296,6 +305,24 @@ type HeimdallConfig struct {
 
 	// UseHeimdallApp is used to fetch data from heimdall app when running heimdall as a child process
 	UseHeimdallApp bool `hcl:"bor.useheimdallapp,optional" toml:"bor.useheimdallapp,optional"`
+
+	// ArchivePath is the path of a local heimdall archive used instead of a live heimdall (offline mode)
+	ArchivePath string `hcl:"archive,optional" toml:"archive,optional"`
+
+	// ArchiveSigner is the address of the trusted signer of the heimdall archive
+	ArchiveSigner string `hcl:"archive-signer,optional" toml:"archive-signer,optional"`
+
+	// Endpoints are additional heimdall endpoints (http(s):// for REST, grpc:// for gRPC) to fail over to
+	Endpoints []string `hcl:"endpoints,optional" toml:"endpoints,optional"`
+
+	// FailoverTimeout is the time after which a request to a heimdall endpoint is failed over to the next one
+	FailoverTimeout time.Duration `hcl:"failover-timeout,optional" toml:"failover-timeout,optional"`
+
+	// HedgeDelay is the time after which a slow request is also sent to the next heimdall endpoint
+	HedgeDelay time.Duration `hcl:"hedge-delay,optional" toml:"hedge-delay,optional"`
+
+	// Quorum is the number of heimdall endpoints which must agree on spans and milestones
+	Quorum uint64 `hcl:"quorum,optional" toml:"quorum,optional"`
 }
 
 type TxPoolConfig struct {
@@ -357,6 +384,21 @@ type SealerConfig struct {
 	RecommitRaw string        `hcl:"recommit,optional" toml:"recommit,optional"`
 
 	CommitInterruptFlag bool `hcl:"commitinterrupt,optional" toml:"commitinterrupt,optional"`
+
+	// SpeculativeBuilding enables the speculative parallel execution of transactions while sealing
+	SpeculativeBuilding bool `hcl:"speculative,optional" toml:"speculative,optional"`
+
+	// SpeculativeProcs is the number of transactions executed in parallel (0 = number of CPUs)
+	SpeculativeProcs int `hcl:"speculativeprocs,optional" toml:"speculativeprocs,optional"`
+
+	// TxOrdering is the ordering of the transactions in mined blocks (price, parallel or conditional)
+	TxOrdering string `hcl:"txordering,optional" toml:"txordering,optional"`
+
+	// PipelinedBuilding enables building the next block during the sealing delay of the current one when in turn
+	PipelinedBuilding bool `hcl:"pipeline,optional" toml:"pipeline,optional"`
+
+	// ProductionTimelines is the number of recent heights whose block production timelines are retained
+	ProductionTimelines uint64 `hcl:"timelines,optional" toml:"timelines,optional"`
 }
 
 type JsonRPCConfig struct {
@@ -627,6 +669,10 @@ type ParallelEVMConfig struct {
 	SpeculativeProcesses int `hcl:"procs,optional" toml:"procs,optional"`
 
 	Enforce bool `hcl:"enforce,optional" toml:"enforce,optional"`
+
+	Adaptive bool `hcl:"adaptive,optional" toml:"adaptive,optional"`
+
+	MinParallelism float64 `hcl:"minparallelism,optional" toml:"minparallelism,optional"`
 }
 
 func DefaultConfig() *Config {
@@ -660,6 +706,8 @@ func DefaultConfig() *Config {
 			NetRestrict:        "",
 			TxArrivalWait:      500 * time.Millisecond,
 			TxAnnouncementOnly: false,
+			BanThreshold:       ethconfig.Defaults.PeerBanThreshold,
+			BanExpiry:          ethconfig.Defaults.PeerFailureExpiry,
 			Discovery: &P2PDiscovery{
 				DiscoveryV4:  true,
 				DiscoveryV5:  true,
@@ -672,11 +720,15 @@ func DefaultConfig() *Config {
 			},
 		},
 		Heimdall: &HeimdallConfig{
-			URL:         "http://localhost:1317",
-			Timeout:     5 * time.Second,
-			Without:     false,
-			GRPCAddress: "",
-			WSAddress:   "",
+			URL:             "http://localhost:1317",
+			Timeout:         5 * time.Second,
+			Without:         false,
+			GRPCAddress:     "",
+			WSAddress:       "",
+			Endpoints:       []string{},
+			FailoverTimeout: 30 * time.Second,
+			HedgeDelay:      0,
+			Quorum:          0,
 		},
 		SyncMode:    "full",
 		GcMode:      "full",
@@ -704,6 +756,11 @@ func DefaultConfig() *Config {
 			ExtraData:           "",
 			Recommit:            125 * time.Second,
 			CommitInterruptFlag: true,
+			SpeculativeBuilding: false,
+			SpeculativeProcs:    0,
+			TxOrdering:          miner.PriceOrdering,
+			PipelinedBuilding:   false,
+			ProductionTimelines: miner.DefaultConfig.ProductionTimelines,
 		},
 		Gpo: &GpoConfig{
 			Blocks:           20,
@@ -828,6 +885,8 @@ func DefaultConfig() *Config {
 			Enable:               true,
 			SpeculativeProcesses: 8,
 			Enforce:              false,
+			Adaptive:             false,
+			MinParallelism:       2,
 		},
 		History: &HistoryConfig{
 			TransactionHistory: ethconfig.Defaults.TransactionHistory,
@@ -890,6 +949,7 @@ func (c *Config) fillTimeDurations() error {
 		{"txpool.rejournal", &c.TxPool.Rejournal, &c.TxPool.RejournalRaw},
 		{"cache.timeout", &c.Cache.TrieTimeout, &c.Cache.TrieTimeoutRaw},
 		{"p2p.txarrivalwait", &c.P2P.TxArrivalWait, &c.P2P.TxArrivalWaitRaw},
+		{"p2p.banexpiry", &c.P2P.BanExpiry, &c.P2P.BanExpiryRaw},
 	}
 
 	for _, x := range tds {
@@ -974,6 +1034,12 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	n.RunHeimdall = c.Heimdall.RunHeimdall
 	n.RunHeimdallArgs = c.Heimdall.RunHeimdallArgs
 	n.UseHeimdallApp = c.Heimdall.UseHeimdallApp
+	n.HeimdallArchivePath = c.Heimdall.ArchivePath
+	n.HeimdallArchiveSigner = c.Heimdall.ArchiveSigner
+	n.HeimdallEndpoints = c.Heimdall.Endpoints
+	n.HeimdallFailoverTimeout = c.Heimdall.FailoverTimeout
+	n.HeimdallHedgeDelay = c.Heimdall.HedgeDelay
+	n.HeimdallQuorum = int(c.Heimdall.Quorum)
 
 	// Developer Fake Author for producing blocks without authorisation on bor consensus
 	n.DevFakeAuthor = c.DevFakeAuthor
@@ -1014,6 +1080,15 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.Miner.GasCeil = c.Sealer.GasCeil
 		n.Miner.ExtraData = []byte(c.Sealer.ExtraData)
 		n.Miner.CommitInterruptFlag = c.Sealer.CommitInterruptFlag
+		n.Miner.SpeculativeBuilding = c.Sealer.SpeculativeBuilding
+		n.Miner.SpeculativeProcs = c.Sealer.SpeculativeProcs
+		n.Miner.TxOrdering = c.Sealer.TxOrdering
+		n.Miner.PipelinedBuilding = c.Sealer.PipelinedBuilding
+		n.Miner.ProductionTimelines = c.Sealer.ProductionTimelines
+
+		if c.Sealer.ProductionTimelines == 0 {
+			return nil, fmt.Errorf("the number of retained production timelines must be greater than 0")
+		}
 
 		if etherbase := c.Sealer.Etherbase; etherbase != "" {
 			if !common.IsHexAddress(etherbase) {
@@ -1195,10 +1270,13 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 
 	n.RPCTxFeeCap = c.JsonRPC.TxFeeCap
 
-	// Choose the sync mode. Only "full" sync is supported
+	// Choose the sync mode. Only "full" and "milestone" sync are supported
 	switch c.SyncMode {
 	case "full":
 		n.SyncMode = downloader.FullSync
+	case "milestone":
+		// Milestone sync is the only snap sync enabled, and waits for snap peers to run
+		n.SyncMode = downloader.MilestoneSync
 	case "snap":
 		log.Info("Snap sync is momentarily disabled in bor, switching to full sync")
 		n.SyncMode = downloader.FullSync
@@ -1234,7 +1312,7 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 
 	// snapshot disable check
 	if !c.Snapshot {
-		if n.SyncMode == downloader.SnapSync {
+		if n.SyncMode == downloader.SnapSync || n.SyncMode == downloader.MilestoneSync {
 			log.Info("Snap sync requested, enabling --snapshot")
 		} else {
 			// disable snapshot
@@ -1249,6 +1327,8 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	n.ParallelEVM.Enable = c.ParallelEVM.Enable
 	n.ParallelEVM.SpeculativeProcesses = c.ParallelEVM.SpeculativeProcesses
 	n.ParallelEVM.Enforce = c.ParallelEVM.Enforce
+	n.ParallelEVM.Adaptive = c.ParallelEVM.Adaptive
+	n.ParallelEVM.MinParallelism = c.ParallelEVM.MinParallelism
 	n.RPCReturnDataLimit = c.RPCReturnDataLimit
 
 	if c.Ancient != "" {
@@ -1257,6 +1337,9 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 
 	n.EnableBlockTracking = c.Logging.EnableBlockTracking
 
+	n.PeerBanThreshold = c.P2P.BanThreshold
+	n.PeerFailureExpiry = c.P2P.BanExpiry
+
 	return &n, nil
 }
 
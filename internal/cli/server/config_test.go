@@ -158,3 +158,13 @@ func TestConfigStateScheme(t *testing.T) {
 	_, err = config.buildEth(nil, nil)
 	assert.Error(t, err)
 }
+
+func TestConfigProductionTimelines(t *testing.T) {
+	config := DefaultConfig()
+	config.Sealer.ProductionTimelines = 0
+
+	assert.NoError(t, config.loadChain())
+
+	_, err := config.buildEth(nil, nil)
+	assert.Error(t, err)
+}
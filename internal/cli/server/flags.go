@@ -88,7 +88,7 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 	})
 	f.StringFlag(&flagset.StringFlag{
 		Name:    "syncmode",
-		Usage:   `Blockchain sync mode (only "full" sync supported)`,
+		Usage:   `Blockchain sync mode ("full" or "milestone", which downloads the state at the latest milestone or checkpoint from snap peers)`,
 		Value:   &c.cliConfig.SyncMode,
 		Default: c.cliConfig.SyncMode,
 	})
@@ -215,6 +215,42 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.Heimdall.UseHeimdallApp,
 		Default: c.cliConfig.Heimdall.UseHeimdallApp,
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "bor.heimdallarchive",
+		Usage:   "Path of a local heimdall archive (see 'bor heimdall export') to verify headers without a live Heimdall",
+		Value:   &c.cliConfig.Heimdall.ArchivePath,
+		Default: c.cliConfig.Heimdall.ArchivePath,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "bor.heimdallarchivesigner",
+		Usage:   "Address of the trusted signer of the heimdall archive, unsigned archives are rejected if set",
+		Value:   &c.cliConfig.Heimdall.ArchiveSigner,
+		Default: c.cliConfig.Heimdall.ArchiveSigner,
+	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "bor.heimdallendpoints",
+		Usage:   "Comma separated additional Heimdall endpoints to fail over to (http(s):// for REST, grpc:// for gRPC)",
+		Value:   &c.cliConfig.Heimdall.Endpoints,
+		Default: c.cliConfig.Heimdall.Endpoints,
+	})
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:    "bor.heimdallfailovertimeout",
+		Usage:   "Time after which a request to a Heimdall endpoint is failed over to the next one",
+		Value:   &c.cliConfig.Heimdall.FailoverTimeout,
+		Default: c.cliConfig.Heimdall.FailoverTimeout,
+	})
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:    "bor.heimdallhedgedelay",
+		Usage:   "Time after which a slow request is also sent to the next Heimdall endpoint (0 = disabled)",
+		Value:   &c.cliConfig.Heimdall.HedgeDelay,
+		Default: c.cliConfig.Heimdall.HedgeDelay,
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "bor.heimdallquorum",
+		Usage:   "Number of Heimdall endpoints which must agree on spans and milestones (0 = disabled)",
+		Value:   &c.cliConfig.Heimdall.Quorum,
+		Default: c.cliConfig.Heimdall.Quorum,
+	})
 
 	// txpool options
 	f.SliceStringFlag(&flagset.SliceStringFlag{
@@ -345,6 +381,41 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.Sealer.CommitInterruptFlag,
 		Group:   "Sealer",
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "miner.speculative",
+		Usage:   "Speculatively execute candidate transactions in parallel when building blocks",
+		Value:   &c.cliConfig.Sealer.SpeculativeBuilding,
+		Default: c.cliConfig.Sealer.SpeculativeBuilding,
+		Group:   "Sealer",
+	})
+	f.IntFlag(&flagset.IntFlag{
+		Name:    "miner.speculative.procs",
+		Usage:   "Number of transactions executed in parallel when building blocks speculatively (0 = number of CPUs)",
+		Value:   &c.cliConfig.Sealer.SpeculativeProcs,
+		Default: c.cliConfig.Sealer.SpeculativeProcs,
+		Group:   "Sealer",
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "miner.txordering",
+		Usage:   "Ordering of the transactions in mined blocks (price, parallel or conditional)",
+		Value:   &c.cliConfig.Sealer.TxOrdering,
+		Default: c.cliConfig.Sealer.TxOrdering,
+		Group:   "Sealer",
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "miner.pipeline",
+		Usage:   "Build the next block during the sealing delay of the current one when in turn for both",
+		Value:   &c.cliConfig.Sealer.PipelinedBuilding,
+		Default: c.cliConfig.Sealer.PipelinedBuilding,
+		Group:   "Sealer",
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "miner.timelines",
+		Usage:   "Number of recent heights whose block production timelines are retained",
+		Value:   &c.cliConfig.Sealer.ProductionTimelines,
+		Default: c.cliConfig.Sealer.ProductionTimelines,
+		Group:   "Sealer",
+	})
 
 	// ethstats
 	f.StringFlag(&flagset.StringFlag{
@@ -825,6 +896,20 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.P2P.TxAnnouncementOnly,
 		Group:   "P2P",
 	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "banthreshold",
+		Usage:   "Number of chains conflicting with the whitelisted milestones and checkpoints after which a peer is banned",
+		Value:   &c.cliConfig.P2P.BanThreshold,
+		Default: c.cliConfig.P2P.BanThreshold,
+		Group:   "P2P",
+	})
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:    "banexpiry",
+		Usage:   "Time after the last conflicting chain served by a peer after which its ban is lifted",
+		Value:   &c.cliConfig.P2P.BanExpiry,
+		Default: c.cliConfig.P2P.BanExpiry,
+		Group:   "P2P",
+	})
 	f.SliceStringFlag(&flagset.SliceStringFlag{
 		Name:    "discovery.dns",
 		Usage:   "Comma separated list of enrtree:// URLs which will be queried for nodes to connect to",
@@ -1011,6 +1096,18 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.ParallelEVM.Enforce,
 		Default: c.cliConfig.ParallelEVM.Enforce,
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "parallelevm.adaptive",
+		Usage:   "Pick the number of speculative processes of Block STM per block, falling back to serial execution for blocks with little parallelism",
+		Value:   &c.cliConfig.ParallelEVM.Adaptive,
+		Default: c.cliConfig.ParallelEVM.Adaptive,
+	})
+	f.Float64Flag(&flagset.Float64Flag{
+		Name:    "parallelevm.minparallelism",
+		Usage:   "Predicted parallelism of a block below which it's executed serially when adaptive",
+		Value:   &c.cliConfig.ParallelEVM.MinParallelism,
+		Default: c.cliConfig.ParallelEVM.MinParallelism,
+	})
 
 	f.Uint64Flag(&flagset.Uint64Flag{
 		Name:    "dev.gaslimit",
@@ -1377,34 +1377,629 @@ func (*DebugFileResponse_Input_) isDebugFileResponse_Event() {}
 
 func (*DebugFileResponse_Eof) isDebugFileResponse_Event() {}
 
+type DebugProductionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number uint64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+func (x *DebugProductionRequest) Reset() {
+	*x = DebugProductionRequest{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugProductionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugProductionRequest) ProtoMessage() {}
+
+func (x *DebugProductionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[22]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugProductionRequest.ProtoReflect.Descriptor instead.
+func (*DebugProductionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DebugProductionRequest) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+
+	return 0
+}
+
+type DebugProductionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number     uint64                                         `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Hash       string                                         `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Sealed     bool                                           `protobuf:"varint,3,opt,name=sealed,proto3" json:"sealed,omitempty"`
+	HeaderTime uint64                                         `protobuf:"varint,4,opt,name=headerTime,proto3" json:"headerTime,omitempty"`
+	Tried      uint64                                         `protobuf:"varint,5,opt,name=tried,proto3" json:"tried,omitempty"`
+	Included   uint64                                         `protobuf:"varint,6,opt,name=included,proto3" json:"included,omitempty"`
+	Rejected   []*DebugProductionResponse_RejectedTransaction `protobuf:"bytes,7,rep,name=rejected,proto3" json:"rejected,omitempty"`
+	Omitted    uint64                                         `protobuf:"varint,8,opt,name=omitted,proto3" json:"omitted,omitempty"`
+	Events     []*DebugProductionResponse_Event               `protobuf:"bytes,9,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *DebugProductionResponse) Reset() {
+	*x = DebugProductionResponse{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugProductionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugProductionResponse) ProtoMessage() {}
+
+func (x *DebugProductionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[23]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugProductionResponse.ProtoReflect.Descriptor instead.
+func (*DebugProductionResponse) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DebugProductionResponse) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+
+	return 0
+}
+
+func (x *DebugProductionResponse) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+
+	return ""
+}
+
+func (x *DebugProductionResponse) GetSealed() bool {
+	if x != nil {
+		return x.Sealed
+	}
+
+	return false
+}
+
+func (x *DebugProductionResponse) GetHeaderTime() uint64 {
+	if x != nil {
+		return x.HeaderTime
+	}
+
+	return 0
+}
+
+func (x *DebugProductionResponse) GetTried() uint64 {
+	if x != nil {
+		return x.Tried
+	}
+
+	return 0
+}
+
+func (x *DebugProductionResponse) GetIncluded() uint64 {
+	if x != nil {
+		return x.Included
+	}
+
+	return 0
+}
+
+func (x *DebugProductionResponse) GetRejected() []*DebugProductionResponse_RejectedTransaction {
+	if x != nil {
+		return x.Rejected
+	}
+
+	return nil
+}
+
+func (x *DebugProductionResponse) GetOmitted() uint64 {
+	if x != nil {
+		return x.Omitted
+	}
+
+	return 0
+}
+
+func (x *DebugProductionResponse) GetEvents() []*DebugProductionResponse_Event {
+	if x != nil {
+		return x.Events
+	}
+
+	return nil
+}
+
+type DebugFinalityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Id    uint64 `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DebugFinalityRequest) Reset() {
+	*x = DebugFinalityRequest{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugFinalityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugFinalityRequest) ProtoMessage() {}
+
+func (x *DebugFinalityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[24]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugFinalityRequest.ProtoReflect.Descriptor instead.
+func (*DebugFinalityRequest) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DebugFinalityRequest) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+
+	return 0
+}
+
+func (x *DebugFinalityRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+
+	return 0
+}
+
+type DebugFinalityResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Incidents []*DebugFinalityResponse_Incident `protobuf:"bytes,1,rep,name=incidents,proto3" json:"incidents,omitempty"`
+}
+
+func (x *DebugFinalityResponse) Reset() {
+	*x = DebugFinalityResponse{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugFinalityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugFinalityResponse) ProtoMessage() {}
+
+func (x *DebugFinalityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[25]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugFinalityResponse.ProtoReflect.Descriptor instead.
+func (*DebugFinalityResponse) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DebugFinalityResponse) GetIncidents() []*DebugFinalityResponse_Incident {
+	if x != nil {
+		return x.Incidents
+	}
+
+	return nil
+}
+
 type StatusResponse_Fork struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Block    int64  `protobuf:"varint,2,opt,name=block,proto3" json:"block,omitempty"`
-	Disabled bool   `protobuf:"varint,3,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	Block    int64  `protobuf:"varint,2,opt,name=block,proto3" json:"block,omitempty"`
+	Disabled bool   `protobuf:"varint,3,opt,name=disabled,proto3" json:"disabled,omitempty"`
+}
+
+func (x *StatusResponse_Fork) Reset() {
+	*x = StatusResponse_Fork{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse_Fork) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse_Fork) ProtoMessage() {}
+
+func (x *StatusResponse_Fork) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[26]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse_Fork.ProtoReflect.Descriptor instead.
+func (*StatusResponse_Fork) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{17, 0}
+}
+
+func (x *StatusResponse_Fork) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+
+	return ""
+}
+
+func (x *StatusResponse_Fork) GetBlock() int64 {
+	if x != nil {
+		return x.Block
+	}
+
+	return 0
+}
+
+func (x *StatusResponse_Fork) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+
+	return false
+}
+
+type StatusResponse_Syncing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartingBlock int64 `protobuf:"varint,1,opt,name=startingBlock,proto3" json:"startingBlock,omitempty"`
+	HighestBlock  int64 `protobuf:"varint,2,opt,name=highestBlock,proto3" json:"highestBlock,omitempty"`
+	CurrentBlock  int64 `protobuf:"varint,3,opt,name=currentBlock,proto3" json:"currentBlock,omitempty"`
+}
+
+func (x *StatusResponse_Syncing) Reset() {
+	*x = StatusResponse_Syncing{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse_Syncing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse_Syncing) ProtoMessage() {}
+
+func (x *StatusResponse_Syncing) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[27]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse_Syncing.ProtoReflect.Descriptor instead.
+func (*StatusResponse_Syncing) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{17, 1}
+}
+
+func (x *StatusResponse_Syncing) GetStartingBlock() int64 {
+	if x != nil {
+		return x.StartingBlock
+	}
+
+	return 0
+}
+
+func (x *StatusResponse_Syncing) GetHighestBlock() int64 {
+	if x != nil {
+		return x.HighestBlock
+	}
+
+	return 0
+}
+
+func (x *StatusResponse_Syncing) GetCurrentBlock() int64 {
+	if x != nil {
+		return x.CurrentBlock
+	}
+
+	return 0
+}
+
+type DebugFileResponse_Open struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headers map[string]string `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *DebugFileResponse_Open) Reset() {
+	*x = DebugFileResponse_Open{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugFileResponse_Open) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugFileResponse_Open) ProtoMessage() {}
+
+func (x *DebugFileResponse_Open) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[28]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugFileResponse_Open.ProtoReflect.Descriptor instead.
+func (*DebugFileResponse_Open) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{21, 0}
+}
+
+func (x *DebugFileResponse_Open) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+
+	return nil
+}
+
+type DebugFileResponse_Input struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *DebugFileResponse_Input) Reset() {
+	*x = DebugFileResponse_Input{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugFileResponse_Input) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugFileResponse_Input) ProtoMessage() {}
+
+func (x *DebugFileResponse_Input) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[29]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugFileResponse_Input.ProtoReflect.Descriptor instead.
+func (*DebugFileResponse_Input) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{21, 1}
+}
+
+func (x *DebugFileResponse_Input) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+
+	return nil
+}
+
+type DebugProductionResponse_RejectedTransaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash   string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *DebugProductionResponse_RejectedTransaction) Reset() {
+	*x = DebugProductionResponse_RejectedTransaction{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugProductionResponse_RejectedTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugProductionResponse_RejectedTransaction) ProtoMessage() {}
+
+func (x *DebugProductionResponse_RejectedTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[31]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugProductionResponse_RejectedTransaction.ProtoReflect.Descriptor instead.
+func (*DebugProductionResponse_RejectedTransaction) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{23, 0}
+}
+
+func (x *DebugProductionResponse_RejectedTransaction) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+
+	return ""
+}
+
+func (x *DebugProductionResponse_RejectedTransaction) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+
+	return ""
+}
+
+type DebugProductionResponse_Event struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Time     int64  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Duration int64  `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	Detail   string `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
 }
 
-func (x *StatusResponse_Fork) Reset() {
-	*x = StatusResponse_Fork{}
+func (x *DebugProductionResponse_Event) Reset() {
+	*x = DebugProductionResponse_Event{}
 
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[22]
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StatusResponse_Fork) String() string {
+func (x *DebugProductionResponse_Event) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusResponse_Fork) ProtoMessage() {}
+func (*DebugProductionResponse_Event) ProtoMessage() {}
 
-func (x *StatusResponse_Fork) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[22]
+func (x *DebugProductionResponse_Event) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[32]
 
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1418,12 +2013,12 @@ func (x *StatusResponse_Fork) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusResponse_Fork.ProtoReflect.Descriptor instead.
-func (*StatusResponse_Fork) Descriptor() ([]byte, []int) {
-	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{17, 0}
+// Deprecated: Use DebugProductionResponse_Event.ProtoReflect.Descriptor instead.
+func (*DebugProductionResponse_Event) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{23, 1}
 }
 
-func (x *StatusResponse_Fork) GetName() string {
+func (x *DebugProductionResponse_Event) GetName() string {
 	if x != nil {
 		return x.Name
 	}
@@ -1431,50 +2026,67 @@ func (x *StatusResponse_Fork) GetName() string {
 	return ""
 }
 
-func (x *StatusResponse_Fork) GetBlock() int64 {
+func (x *DebugProductionResponse_Event) GetTime() int64 {
 	if x != nil {
-		return x.Block
+		return x.Time
 	}
 
 	return 0
 }
 
-func (x *StatusResponse_Fork) GetDisabled() bool {
+func (x *DebugProductionResponse_Event) GetDuration() int64 {
 	if x != nil {
-		return x.Disabled
+		return x.Duration
 	}
 
-	return false
+	return 0
 }
 
-type StatusResponse_Syncing struct {
+func (x *DebugProductionResponse_Event) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+
+	return ""
+}
+
+type DebugFinalityResponse_Incident struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	StartingBlock int64 `protobuf:"varint,1,opt,name=startingBlock,proto3" json:"startingBlock,omitempty"`
-	HighestBlock  int64 `protobuf:"varint,2,opt,name=highestBlock,proto3" json:"highestBlock,omitempty"`
-	CurrentBlock  int64 `protobuf:"varint,3,opt,name=currentBlock,proto3" json:"currentBlock,omitempty"`
+	Id               uint64                          `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Time             uint64                          `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Kind             string                          `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	StartBlock       uint64                          `protobuf:"varint,4,opt,name=startBlock,proto3" json:"startBlock,omitempty"`
+	EndBlock         uint64                          `protobuf:"varint,5,opt,name=endBlock,proto3" json:"endBlock,omitempty"`
+	Expected         string                          `protobuf:"bytes,6,opt,name=expected,proto3" json:"expected,omitempty"`
+	Local            string                          `protobuf:"bytes,7,opt,name=local,proto3" json:"local,omitempty"`
+	Head             uint64                          `protobuf:"varint,8,opt,name=head,proto3" json:"head,omitempty"`
+	RewindTo         uint64                          `protobuf:"varint,9,opt,name=rewindTo,proto3" json:"rewindTo,omitempty"`
+	LocalHeaders     []*DebugFinalityResponse_Header `protobuf:"bytes,10,rep,name=localHeaders,proto3" json:"localHeaders,omitempty"`
+	FinalizedHeaders []*DebugFinalityResponse_Header `protobuf:"bytes,11,rep,name=finalizedHeaders,proto3" json:"finalizedHeaders,omitempty"`
+	Peers            []string                        `protobuf:"bytes,12,rep,name=peers,proto3" json:"peers,omitempty"`
 }
 
-func (x *StatusResponse_Syncing) Reset() {
-	*x = StatusResponse_Syncing{}
+func (x *DebugFinalityResponse_Incident) Reset() {
+	*x = DebugFinalityResponse_Incident{}
 
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[23]
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StatusResponse_Syncing) String() string {
+func (x *DebugFinalityResponse_Incident) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusResponse_Syncing) ProtoMessage() {}
+func (*DebugFinalityResponse_Incident) ProtoMessage() {}
 
-func (x *StatusResponse_Syncing) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[23]
+func (x *DebugFinalityResponse_Incident) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[33]
 
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1488,113 +2100,137 @@ func (x *StatusResponse_Syncing) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusResponse_Syncing.ProtoReflect.Descriptor instead.
-func (*StatusResponse_Syncing) Descriptor() ([]byte, []int) {
-	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{17, 1}
+// Deprecated: Use DebugFinalityResponse_Incident.ProtoReflect.Descriptor instead.
+func (*DebugFinalityResponse_Incident) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{25, 0}
 }
 
-func (x *StatusResponse_Syncing) GetStartingBlock() int64 {
+func (x *DebugFinalityResponse_Incident) GetId() uint64 {
 	if x != nil {
-		return x.StartingBlock
+		return x.Id
 	}
 
 	return 0
 }
 
-func (x *StatusResponse_Syncing) GetHighestBlock() int64 {
+func (x *DebugFinalityResponse_Incident) GetTime() uint64 {
 	if x != nil {
-		return x.HighestBlock
+		return x.Time
 	}
 
 	return 0
 }
 
-func (x *StatusResponse_Syncing) GetCurrentBlock() int64 {
+func (x *DebugFinalityResponse_Incident) GetKind() string {
 	if x != nil {
-		return x.CurrentBlock
+		return x.Kind
 	}
 
-	return 0
+	return ""
 }
 
-type DebugFileResponse_Open struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *DebugFinalityResponse_Incident) GetStartBlock() uint64 {
+	if x != nil {
+		return x.StartBlock
+	}
 
-	Headers map[string]string `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	return 0
 }
 
-func (x *DebugFileResponse_Open) Reset() {
-	*x = DebugFileResponse_Open{}
+func (x *DebugFinalityResponse_Incident) GetEndBlock() uint64 {
+	if x != nil {
+		return x.EndBlock
+	}
 
-	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[24]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+	return 0
+}
+
+func (x *DebugFinalityResponse_Incident) GetExpected() string {
+	if x != nil {
+		return x.Expected
 	}
+
+	return ""
 }
 
-func (x *DebugFileResponse_Open) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *DebugFinalityResponse_Incident) GetLocal() string {
+	if x != nil {
+		return x.Local
+	}
+
+	return ""
 }
 
-func (*DebugFileResponse_Open) ProtoMessage() {}
+func (x *DebugFinalityResponse_Incident) GetHead() uint64 {
+	if x != nil {
+		return x.Head
+	}
 
-func (x *DebugFileResponse_Open) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[24]
+	return 0
+}
 
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
+func (x *DebugFinalityResponse_Incident) GetRewindTo() uint64 {
+	if x != nil {
+		return x.RewindTo
+	}
 
-		return ms
+	return 0
+}
+
+func (x *DebugFinalityResponse_Incident) GetLocalHeaders() []*DebugFinalityResponse_Header {
+	if x != nil {
+		return x.LocalHeaders
 	}
 
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use DebugFileResponse_Open.ProtoReflect.Descriptor instead.
-func (*DebugFileResponse_Open) Descriptor() ([]byte, []int) {
-	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{21, 0}
+func (x *DebugFinalityResponse_Incident) GetFinalizedHeaders() []*DebugFinalityResponse_Header {
+	if x != nil {
+		return x.FinalizedHeaders
+	}
+
+	return nil
 }
 
-func (x *DebugFileResponse_Open) GetHeaders() map[string]string {
+func (x *DebugFinalityResponse_Incident) GetPeers() []string {
 	if x != nil {
-		return x.Headers
+		return x.Peers
 	}
 
 	return nil
 }
 
-type DebugFileResponse_Input struct {
+type DebugFinalityResponse_Header struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Number     uint64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Hash       string `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	ParentHash string `protobuf:"bytes,3,opt,name=parentHash,proto3" json:"parentHash,omitempty"`
+	Signer     string `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer,omitempty"`
+	Peer       string `protobuf:"bytes,5,opt,name=peer,proto3" json:"peer,omitempty"`
 }
 
-func (x *DebugFileResponse_Input) Reset() {
-	*x = DebugFileResponse_Input{}
+func (x *DebugFinalityResponse_Header) Reset() {
+	*x = DebugFinalityResponse_Header{}
 
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[25]
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DebugFileResponse_Input) String() string {
+func (x *DebugFinalityResponse_Header) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DebugFileResponse_Input) ProtoMessage() {}
+func (*DebugFinalityResponse_Header) ProtoMessage() {}
 
-func (x *DebugFileResponse_Input) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[25]
+func (x *DebugFinalityResponse_Header) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[34]
 
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1608,17 +2244,49 @@ func (x *DebugFileResponse_Input) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DebugFileResponse_Input.ProtoReflect.Descriptor instead.
-func (*DebugFileResponse_Input) Descriptor() ([]byte, []int) {
-	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{21, 1}
+// Deprecated: Use DebugFinalityResponse_Header.ProtoReflect.Descriptor instead.
+func (*DebugFinalityResponse_Header) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{25, 1}
 }
 
-func (x *DebugFileResponse_Input) GetData() []byte {
+func (x *DebugFinalityResponse_Header) GetNumber() uint64 {
 	if x != nil {
-		return x.Data
+		return x.Number
 	}
 
-	return nil
+	return 0
+}
+
+func (x *DebugFinalityResponse_Header) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+
+	return ""
+}
+
+func (x *DebugFinalityResponse_Header) GetParentHash() string {
+	if x != nil {
+		return x.ParentHash
+	}
+
+	return ""
+}
+
+func (x *DebugFinalityResponse_Header) GetSigner() string {
+	if x != nil {
+		return x.Signer
+	}
+
+	return ""
+}
+
+func (x *DebugFinalityResponse_Header) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+
+	return ""
 }
 
 var File_internal_cli_server_proto_server_proto protoreflect.FileDescriptor
@@ -1753,47 +2421,135 @@ var file_internal_cli_server_proto_server_proto_rawDesc = []byte{
 	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x1b, 0x0a, 0x05,
 	0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x32, 0xdb, 0x04, 0x0a, 0x03, 0x42, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x08, 0x50, 0x65,
-	0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
-	0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x73,
-	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
-	0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52,
-	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a,
-	0x09, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72,
-	0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a,
-	0x0b, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x74, 0x48,
-	0x65, 0x61, 0x64, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69,
-	0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x74,
-	0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57, 0x61, 0x74, 0x63,
-	0x68, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57,
-	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0a, 0x44, 0x65, 0x62, 0x75,
-	0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
-	0x65, 0x62, 0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69,
-	0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0a,
-	0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62,
-	0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
-	0x42, 0x1c, 0x5a, 0x1a, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x63, 0x6c,
-	0x69, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x74, 0x22, 0x30, 0x0a, 0x16, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x22, 0xff, 0x03, 0x0a, 0x17, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x65,
+	0x61, 0x6c, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x72, 0x69, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x74, 0x72, 0x69, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x12, 0x4e, 0x0a, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x72, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x6d, 0x69, 0x74, 0x74, 0x65,
+	0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x6f, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64,
+	0x12, 0x3c, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x41,
+	0x0a, 0x13, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x1a, 0x63, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x22, 0x3c, 0x0a, 0x14, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46,
+	0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0xf2, 0x04, 0x0a, 0x15, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69,
+	0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43,
+	0x0a, 0x09, 0x69, 0x6e, 0x63, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46,
+	0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x49, 0x6e, 0x63, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x69, 0x6e, 0x63, 0x69, 0x64, 0x65,
+	0x6e, 0x74, 0x73, 0x1a, 0x90, 0x03, 0x0a, 0x08, 0x49, 0x6e, 0x63, 0x69, 0x64, 0x65, 0x6e, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x77, 0x69, 0x6e, 0x64, 0x54, 0x6f, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65,
+	0x77, 0x69, 0x6e, 0x64, 0x54, 0x6f, 0x12, 0x47, 0x0a, 0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69,
+	0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x52, 0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12,
+	0x4f, 0x0a, 0x10, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x10,
+	0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x1a, 0x80, 0x01, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1e, 0x0a,
+	0x0a, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x32, 0xf9, 0x05, 0x0a, 0x03, 0x42, 0x6f,
+	0x72, 0x12, 0x3b, 0x0a, 0x08, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x12, 0x16, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65,
+	0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44,
+	0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x19, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x43, 0x68,
+	0x61, 0x69, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x68, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x43, 0x68,
+	0x61, 0x69, 0x6e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x42, 0x0a, 0x0a, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x12, 0x18, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0a, 0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x0f, 0x44, 0x65, 0x62, 0x75, 0x67,
+	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0d, 0x44, 0x65, 0x62,
+	0x75, 0x67, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x1c, 0x5a, 0x1a, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x63, 0x6c, 0x69, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1810,37 +2566,45 @@ func file_internal_cli_server_proto_server_proto_rawDescGZIP() []byte {
 }
 
 var file_internal_cli_server_proto_server_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_cli_server_proto_server_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_internal_cli_server_proto_server_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_internal_cli_server_proto_server_proto_goTypes = []interface{}{
-	(DebugPprofRequest_Type)(0),     // 0: proto.DebugPprofRequest.Type
-	(*TraceRequest)(nil),            // 1: proto.TraceRequest
-	(*TraceResponse)(nil),           // 2: proto.TraceResponse
-	(*ChainWatchRequest)(nil),       // 3: proto.ChainWatchRequest
-	(*ChainWatchResponse)(nil),      // 4: proto.ChainWatchResponse
-	(*BlockStub)(nil),               // 5: proto.BlockStub
-	(*PeersAddRequest)(nil),         // 6: proto.PeersAddRequest
-	(*PeersAddResponse)(nil),        // 7: proto.PeersAddResponse
-	(*PeersRemoveRequest)(nil),      // 8: proto.PeersRemoveRequest
-	(*PeersRemoveResponse)(nil),     // 9: proto.PeersRemoveResponse
-	(*PeersListRequest)(nil),        // 10: proto.PeersListRequest
-	(*PeersListResponse)(nil),       // 11: proto.PeersListResponse
-	(*PeersStatusRequest)(nil),      // 12: proto.PeersStatusRequest
-	(*PeersStatusResponse)(nil),     // 13: proto.PeersStatusResponse
-	(*Peer)(nil),                    // 14: proto.Peer
-	(*ChainSetHeadRequest)(nil),     // 15: proto.ChainSetHeadRequest
-	(*ChainSetHeadResponse)(nil),    // 16: proto.ChainSetHeadResponse
-	(*StatusRequest)(nil),           // 17: proto.StatusRequest
-	(*StatusResponse)(nil),          // 18: proto.StatusResponse
-	(*Header)(nil),                  // 19: proto.Header
-	(*DebugPprofRequest)(nil),       // 20: proto.DebugPprofRequest
-	(*DebugBlockRequest)(nil),       // 21: proto.DebugBlockRequest
-	(*DebugFileResponse)(nil),       // 22: proto.DebugFileResponse
-	(*StatusResponse_Fork)(nil),     // 23: proto.StatusResponse.Fork
-	(*StatusResponse_Syncing)(nil),  // 24: proto.StatusResponse.Syncing
-	(*DebugFileResponse_Open)(nil),  // 25: proto.DebugFileResponse.Open
-	(*DebugFileResponse_Input)(nil), // 26: proto.DebugFileResponse.Input
-	nil,                             // 27: proto.DebugFileResponse.Open.HeadersEntry
-	(*emptypb.Empty)(nil),           // 28: google.protobuf.Empty
+	(DebugPprofRequest_Type)(0),                         // 0: proto.DebugPprofRequest.Type
+	(*TraceRequest)(nil),                                // 1: proto.TraceRequest
+	(*TraceResponse)(nil),                               // 2: proto.TraceResponse
+	(*ChainWatchRequest)(nil),                           // 3: proto.ChainWatchRequest
+	(*ChainWatchResponse)(nil),                          // 4: proto.ChainWatchResponse
+	(*BlockStub)(nil),                                   // 5: proto.BlockStub
+	(*PeersAddRequest)(nil),                             // 6: proto.PeersAddRequest
+	(*PeersAddResponse)(nil),                            // 7: proto.PeersAddResponse
+	(*PeersRemoveRequest)(nil),                          // 8: proto.PeersRemoveRequest
+	(*PeersRemoveResponse)(nil),                         // 9: proto.PeersRemoveResponse
+	(*PeersListRequest)(nil),                            // 10: proto.PeersListRequest
+	(*PeersListResponse)(nil),                           // 11: proto.PeersListResponse
+	(*PeersStatusRequest)(nil),                          // 12: proto.PeersStatusRequest
+	(*PeersStatusResponse)(nil),                         // 13: proto.PeersStatusResponse
+	(*Peer)(nil),                                        // 14: proto.Peer
+	(*ChainSetHeadRequest)(nil),                         // 15: proto.ChainSetHeadRequest
+	(*ChainSetHeadResponse)(nil),                        // 16: proto.ChainSetHeadResponse
+	(*StatusRequest)(nil),                               // 17: proto.StatusRequest
+	(*StatusResponse)(nil),                              // 18: proto.StatusResponse
+	(*Header)(nil),                                      // 19: proto.Header
+	(*DebugPprofRequest)(nil),                           // 20: proto.DebugPprofRequest
+	(*DebugBlockRequest)(nil),                           // 21: proto.DebugBlockRequest
+	(*DebugFileResponse)(nil),                           // 22: proto.DebugFileResponse
+	(*DebugProductionRequest)(nil),                      // 23: proto.DebugProductionRequest
+	(*DebugProductionResponse)(nil),                     // 24: proto.DebugProductionResponse
+	(*DebugFinalityRequest)(nil),                        // 25: proto.DebugFinalityRequest
+	(*DebugFinalityResponse)(nil),                       // 26: proto.DebugFinalityResponse
+	(*StatusResponse_Fork)(nil),                         // 27: proto.StatusResponse.Fork
+	(*StatusResponse_Syncing)(nil),                      // 28: proto.StatusResponse.Syncing
+	(*DebugFileResponse_Open)(nil),                      // 29: proto.DebugFileResponse.Open
+	(*DebugFileResponse_Input)(nil),                     // 30: proto.DebugFileResponse.Input
+	nil,                                                 // 31: proto.DebugFileResponse.Open.HeadersEntry
+	(*DebugProductionResponse_RejectedTransaction)(nil), // 32: proto.DebugProductionResponse.RejectedTransaction
+	(*DebugProductionResponse_Event)(nil),               // 33: proto.DebugProductionResponse.Event
+	(*DebugFinalityResponse_Incident)(nil),              // 34: proto.DebugFinalityResponse.Incident
+	(*DebugFinalityResponse_Header)(nil),                // 35: proto.DebugFinalityResponse.Header
+	(*emptypb.Empty)(nil),                               // 36: google.protobuf.Empty
 }
 var file_internal_cli_server_proto_server_proto_depIdxs = []int32{
 	5,  // 0: proto.ChainWatchResponse.oldchain:type_name -> proto.BlockStub
@@ -1849,36 +2613,45 @@ var file_internal_cli_server_proto_server_proto_depIdxs = []int32{
 	14, // 3: proto.PeersStatusResponse.peer:type_name -> proto.Peer
 	19, // 4: proto.StatusResponse.currentBlock:type_name -> proto.Header
 	19, // 5: proto.StatusResponse.currentHeader:type_name -> proto.Header
-	24, // 6: proto.StatusResponse.syncing:type_name -> proto.StatusResponse.Syncing
-	23, // 7: proto.StatusResponse.forks:type_name -> proto.StatusResponse.Fork
+	28, // 6: proto.StatusResponse.syncing:type_name -> proto.StatusResponse.Syncing
+	27, // 7: proto.StatusResponse.forks:type_name -> proto.StatusResponse.Fork
 	0,  // 8: proto.DebugPprofRequest.type:type_name -> proto.DebugPprofRequest.Type
-	25, // 9: proto.DebugFileResponse.open:type_name -> proto.DebugFileResponse.Open
-	26, // 10: proto.DebugFileResponse.input:type_name -> proto.DebugFileResponse.Input
-	28, // 11: proto.DebugFileResponse.eof:type_name -> google.protobuf.Empty
-	27, // 12: proto.DebugFileResponse.Open.headers:type_name -> proto.DebugFileResponse.Open.HeadersEntry
-	6,  // 13: proto.Bor.PeersAdd:input_type -> proto.PeersAddRequest
-	8,  // 14: proto.Bor.PeersRemove:input_type -> proto.PeersRemoveRequest
-	10, // 15: proto.Bor.PeersList:input_type -> proto.PeersListRequest
-	12, // 16: proto.Bor.PeersStatus:input_type -> proto.PeersStatusRequest
-	15, // 17: proto.Bor.ChainSetHead:input_type -> proto.ChainSetHeadRequest
-	17, // 18: proto.Bor.Status:input_type -> proto.StatusRequest
-	3,  // 19: proto.Bor.ChainWatch:input_type -> proto.ChainWatchRequest
-	20, // 20: proto.Bor.DebugPprof:input_type -> proto.DebugPprofRequest
-	21, // 21: proto.Bor.DebugBlock:input_type -> proto.DebugBlockRequest
-	7,  // 22: proto.Bor.PeersAdd:output_type -> proto.PeersAddResponse
-	9,  // 23: proto.Bor.PeersRemove:output_type -> proto.PeersRemoveResponse
-	11, // 24: proto.Bor.PeersList:output_type -> proto.PeersListResponse
-	13, // 25: proto.Bor.PeersStatus:output_type -> proto.PeersStatusResponse
-	16, // 26: proto.Bor.ChainSetHead:output_type -> proto.ChainSetHeadResponse
-	18, // 27: proto.Bor.Status:output_type -> proto.StatusResponse
-	4,  // 28: proto.Bor.ChainWatch:output_type -> proto.ChainWatchResponse
-	22, // 29: proto.Bor.DebugPprof:output_type -> proto.DebugFileResponse
-	22, // 30: proto.Bor.DebugBlock:output_type -> proto.DebugFileResponse
-	22, // [22:31] is the sub-list for method output_type
-	13, // [13:22] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	29, // 9: proto.DebugFileResponse.open:type_name -> proto.DebugFileResponse.Open
+	30, // 10: proto.DebugFileResponse.input:type_name -> proto.DebugFileResponse.Input
+	36, // 11: proto.DebugFileResponse.eof:type_name -> google.protobuf.Empty
+	32, // 12: proto.DebugProductionResponse.rejected:type_name -> proto.DebugProductionResponse.RejectedTransaction
+	33, // 13: proto.DebugProductionResponse.events:type_name -> proto.DebugProductionResponse.Event
+	34, // 14: proto.DebugFinalityResponse.incidents:type_name -> proto.DebugFinalityResponse.Incident
+	31, // 15: proto.DebugFileResponse.Open.headers:type_name -> proto.DebugFileResponse.Open.HeadersEntry
+	35, // 16: proto.DebugFinalityResponse.Incident.localHeaders:type_name -> proto.DebugFinalityResponse.Header
+	35, // 17: proto.DebugFinalityResponse.Incident.finalizedHeaders:type_name -> proto.DebugFinalityResponse.Header
+	6,  // 18: proto.Bor.PeersAdd:input_type -> proto.PeersAddRequest
+	8,  // 19: proto.Bor.PeersRemove:input_type -> proto.PeersRemoveRequest
+	10, // 20: proto.Bor.PeersList:input_type -> proto.PeersListRequest
+	12, // 21: proto.Bor.PeersStatus:input_type -> proto.PeersStatusRequest
+	15, // 22: proto.Bor.ChainSetHead:input_type -> proto.ChainSetHeadRequest
+	17, // 23: proto.Bor.Status:input_type -> proto.StatusRequest
+	3,  // 24: proto.Bor.ChainWatch:input_type -> proto.ChainWatchRequest
+	20, // 25: proto.Bor.DebugPprof:input_type -> proto.DebugPprofRequest
+	21, // 26: proto.Bor.DebugBlock:input_type -> proto.DebugBlockRequest
+	23, // 27: proto.Bor.DebugProduction:input_type -> proto.DebugProductionRequest
+	25, // 28: proto.Bor.DebugFinality:input_type -> proto.DebugFinalityRequest
+	7,  // 29: proto.Bor.PeersAdd:output_type -> proto.PeersAddResponse
+	9,  // 30: proto.Bor.PeersRemove:output_type -> proto.PeersRemoveResponse
+	11, // 31: proto.Bor.PeersList:output_type -> proto.PeersListResponse
+	13, // 32: proto.Bor.PeersStatus:output_type -> proto.PeersStatusResponse
+	16, // 33: proto.Bor.ChainSetHead:output_type -> proto.ChainSetHeadResponse
+	18, // 34: proto.Bor.Status:output_type -> proto.StatusResponse
+	4,  // 35: proto.Bor.ChainWatch:output_type -> proto.ChainWatchResponse
+	22, // 36: proto.Bor.DebugPprof:output_type -> proto.DebugFileResponse
+	22, // 37: proto.Bor.DebugBlock:output_type -> proto.DebugFileResponse
+	24, // 38: proto.Bor.DebugProduction:output_type -> proto.DebugProductionResponse
+	26, // 39: proto.Bor.DebugFinality:output_type -> proto.DebugFinalityResponse
+	29, // [29:40] is the sub-list for method output_type
+	18, // [18:29] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_internal_cli_server_proto_server_proto_init() }
@@ -2153,7 +2926,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusResponse_Fork); i {
+			switch v := v.(*DebugProductionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2165,7 +2938,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusResponse_Syncing); i {
+			switch v := v.(*DebugProductionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2177,7 +2950,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DebugFileResponse_Open); i {
+			switch v := v.(*DebugFinalityRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2189,6 +2962,54 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugFinalityResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_Fork); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_Syncing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugFileResponse_Open); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DebugFileResponse_Input); i {
 			case 0:
 				return &v.state
@@ -2200,6 +3021,54 @@ func file_internal_cli_server_proto_server_proto_init() {
 				return nil
 			}
 		}
+		file_internal_cli_server_proto_server_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugProductionResponse_RejectedTransaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugProductionResponse_Event); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugFinalityResponse_Incident); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugFinalityResponse_Header); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 
 	file_internal_cli_server_proto_server_proto_msgTypes[21].OneofWrappers = []interface{}{
@@ -2215,7 +3084,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_cli_server_proto_server_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   27,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -31,6 +31,8 @@ type BorClient interface {
 	ChainWatch(ctx context.Context, in *ChainWatchRequest, opts ...grpc.CallOption) (Bor_ChainWatchClient, error)
 	DebugPprof(ctx context.Context, in *DebugPprofRequest, opts ...grpc.CallOption) (Bor_DebugPprofClient, error)
 	DebugBlock(ctx context.Context, in *DebugBlockRequest, opts ...grpc.CallOption) (Bor_DebugBlockClient, error)
+	DebugProduction(ctx context.Context, in *DebugProductionRequest, opts ...grpc.CallOption) (*DebugProductionResponse, error)
+	DebugFinality(ctx context.Context, in *DebugFinalityRequest, opts ...grpc.CallOption) (*DebugFinalityResponse, error)
 }
 
 type borClient struct {
@@ -215,6 +217,28 @@ func (x *borDebugBlockClient) Recv() (*DebugFileResponse, error) {
 	return m, nil
 }
 
+func (c *borClient) DebugProduction(ctx context.Context, in *DebugProductionRequest, opts ...grpc.CallOption) (*DebugProductionResponse, error) {
+	out := new(DebugProductionResponse)
+
+	err := c.cc.Invoke(ctx, "/proto.Bor/DebugProduction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *borClient) DebugFinality(ctx context.Context, in *DebugFinalityRequest, opts ...grpc.CallOption) (*DebugFinalityResponse, error) {
+	out := new(DebugFinalityResponse)
+
+	err := c.cc.Invoke(ctx, "/proto.Bor/DebugFinality", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 // BorServer is the server API for Bor service.
 // All implementations must embed UnimplementedBorServer
 // for forward compatibility
@@ -228,6 +252,8 @@ type BorServer interface {
 	ChainWatch(*ChainWatchRequest, Bor_ChainWatchServer) error
 	DebugPprof(*DebugPprofRequest, Bor_DebugPprofServer) error
 	DebugBlock(*DebugBlockRequest, Bor_DebugBlockServer) error
+	DebugProduction(context.Context, *DebugProductionRequest) (*DebugProductionResponse, error)
+	DebugFinality(context.Context, *DebugFinalityRequest) (*DebugFinalityResponse, error)
 	mustEmbedUnimplementedBorServer()
 }
 
@@ -262,6 +288,12 @@ func (UnimplementedBorServer) DebugPprof(*DebugPprofRequest, Bor_DebugPprofServe
 func (UnimplementedBorServer) DebugBlock(*DebugBlockRequest, Bor_DebugBlockServer) error {
 	return status.Errorf(codes.Unimplemented, "method DebugBlock not implemented")
 }
+func (UnimplementedBorServer) DebugProduction(context.Context, *DebugProductionRequest) (*DebugProductionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DebugProduction not implemented")
+}
+func (UnimplementedBorServer) DebugFinality(context.Context, *DebugFinalityRequest) (*DebugFinalityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DebugFinality not implemented")
+}
 func (UnimplementedBorServer) mustEmbedUnimplementedBorServer() {}
 
 // UnsafeBorServer may be embedded to opt out of forward compatibility for this service.
@@ -467,6 +499,48 @@ func (x *borDebugBlockServer) Send(m *DebugFileResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Bor_DebugProduction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DebugProductionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(BorServer).DebugProduction(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Bor/DebugProduction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorServer).DebugProduction(ctx, req.(*DebugProductionRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bor_DebugFinality_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DebugFinalityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(BorServer).DebugFinality(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Bor/DebugFinality",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorServer).DebugFinality(ctx, req.(*DebugFinalityRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
 // Bor_ServiceDesc is the grpc.ServiceDesc for Bor service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -498,6 +572,14 @@ var Bor_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _Bor_Status_Handler,
 		},
+		{
+			MethodName: "DebugProduction",
+			Handler:    _Bor_DebugProduction_Handler,
+		},
+		{
+			MethodName: "DebugFinality",
+			Handler:    _Bor_DebugFinality_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
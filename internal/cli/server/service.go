@@ -12,7 +12,9 @@ import (
 
 	grpc_net_conn "github.com/JekaMas/go-grpc-net-conn"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
@@ -256,6 +258,109 @@ func (s *Server) DebugBlock(req *proto.DebugBlockRequest, stream proto.Bor_Debug
 	return nil
 }
 
+func (s *Server) DebugProduction(ctx context.Context, req *proto.DebugProductionRequest) (*proto.DebugProductionResponse, error) {
+	if s.backend == nil {
+		return nil, ErrUnavailable
+	}
+
+	timeline := rawdb.ReadBlockProductionTimeline(s.backend.ChainDb(), req.Number)
+	if timeline == nil {
+		return nil, fmt.Errorf("no production report for block %d, it wasn't produced recently by the local validator", req.Number)
+	}
+
+	resp := &proto.DebugProductionResponse{
+		Number:     timeline.Number,
+		Sealed:     timeline.Sealed,
+		HeaderTime: timeline.HeaderTime,
+		Tried:      timeline.Tried,
+		Included:   timeline.Included,
+		Omitted:    timeline.Omitted,
+	}
+
+	if timeline.Hash != (common.Hash{}) {
+		resp.Hash = timeline.Hash.Hex()
+	}
+
+	for _, tx := range timeline.Rejected {
+		resp.Rejected = append(resp.Rejected, &proto.DebugProductionResponse_RejectedTransaction{
+			Hash:   tx.Hash.Hex(),
+			Reason: tx.Reason,
+		})
+	}
+
+	for _, ev := range timeline.Events {
+		resp.Events = append(resp.Events, &proto.DebugProductionResponse_Event{
+			Name:     ev.Name,
+			Time:     ev.Time.UnixNano(),
+			Duration: int64(ev.Duration),
+			Detail:   ev.Detail,
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *Server) DebugFinality(ctx context.Context, req *proto.DebugFinalityRequest) (*proto.DebugFinalityResponse, error) {
+	if s.backend == nil {
+		return nil, ErrUnavailable
+	}
+
+	var incidents []*rawdb.FinalityIncident
+
+	if req.Id != 0 {
+		incident := rawdb.ReadFinalityIncident(s.backend.ChainDb(), req.Id)
+		if incident == nil {
+			return nil, fmt.Errorf("unknown finality incident %d", req.Id)
+		}
+
+		incidents = append(incidents, incident)
+	} else {
+		count := req.Count
+		if count == 0 || count > rawdb.MaxFinalityIncidents {
+			count = rawdb.MaxFinalityIncidents
+		}
+
+		incidents = rawdb.ReadFinalityIncidents(s.backend.ChainDb(), count)
+	}
+
+	resp := &proto.DebugFinalityResponse{}
+
+	for _, incident := range incidents {
+		resp.Incidents = append(resp.Incidents, &proto.DebugFinalityResponse_Incident{
+			Id:               incident.ID,
+			Time:             incident.Time,
+			Kind:             incident.Kind,
+			StartBlock:       incident.StartBlock,
+			EndBlock:         incident.EndBlock,
+			Expected:         incident.Expected.Hex(),
+			Local:            incident.Local.Hex(),
+			Head:             incident.Head,
+			RewindTo:         incident.RewindTo,
+			LocalHeaders:     incidentHeadersToProto(incident.LocalHeaders),
+			FinalizedHeaders: incidentHeadersToProto(incident.FinalizedHeaders),
+			Peers:            incident.Peers,
+		})
+	}
+
+	return resp, nil
+}
+
+func incidentHeadersToProto(headers []*rawdb.FinalityIncidentHeader) []*proto.DebugFinalityResponse_Header {
+	res := make([]*proto.DebugFinalityResponse_Header, 0, len(headers))
+
+	for _, header := range headers {
+		res = append(res, &proto.DebugFinalityResponse_Header{
+			Number:     header.Number,
+			Hash:       header.Hash.Hex(),
+			ParentHash: header.ParentHash.Hex(),
+			Signer:     header.Signer.Hex(),
+			Peer:       header.Peer,
+		})
+	}
+
+	return res
+}
+
 var bigIntT = reflect.TypeOf(new(big.Int)).Kind()
 
 // gatherForks gathers all the fork numbers via reflection
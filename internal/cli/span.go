@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// SpanCommand is the command to group the span commands
+type SpanCommand struct {
+	UI cli.Ui
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *SpanCommand) MarkDown() string {
+	items := []string{
+		"# Span",
+		"The ```span``` command groups actions to inspect the spans of bor:",
+		"- [```span simulate```](./span_simulate.md): Preview the producer order of a candidate span.",
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *SpanCommand) Help() string {
+	return `Usage: bor span <subcommand>
+
+  This command groups actions to inspect the spans of bor.
+
+  Preview the producer order of a candidate span:
+
+    $ bor span simulate --file span.json`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *SpanCommand) Synopsis() string {
+	return "Inspect the spans of bor"
+}
+
+// Run implements the cli.Command interface
+func (c *SpanCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
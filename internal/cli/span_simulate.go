@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+)
+
+// SpanSimulateCommand is the command to preview the producer order of a candidate span
+type SpanSimulateCommand struct {
+	UI cli.Ui
+
+	endpoint string
+	file     string
+	sprints  uint64
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *SpanSimulateCommand) MarkDown() string {
+	items := []string{
+		"# Span simulate",
+		"The ```span simulate``` command commits a candidate span on top of a copy of the latest state of the node " +
+			"through the ```bor_simulateSpan``` RPC and prints the producer order, the proposer priorities and the " +
+			"difficulties of the first sprints of the span. The chain isn't altered. The span is read from a file in " +
+			"the JSON format served by heimdall, e.g. the response of ```/bor/spans/{id}```.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *SpanSimulateCommand) Help() string {
+	return `Usage: bor span simulate --file <span.json> [--sprints <count>]
+
+  This command previews the producer order of a candidate span` + c.Flags().Help()
+}
+
+func (c *SpanSimulateCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("span simulate")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "endpoint",
+		Usage: "IPC path or RPC url of the bor node (defaults to the IPC endpoint of the default data directory)",
+		Value: &c.endpoint,
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "file",
+		Usage: "Path to the JSON file of the candidate span",
+		Value: &c.file,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "sprints",
+		Usage:   "Number of sprints of the span to simulate",
+		Value:   &c.sprints,
+		Default: 16,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *SpanSimulateCommand) Synopsis() string {
+	return "Preview the producer order of a candidate span"
+}
+
+// Run implements the cli.Command interface
+func (c *SpanSimulateCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.file == "" {
+		c.UI.Error("The span file is required")
+		return 1
+	}
+
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to read the span file: %v", err))
+		return 1
+	}
+
+	if !json.Valid(data) {
+		c.UI.Error("The span file isn't valid JSON")
+		return 1
+	}
+
+	rpcClient, err := dialRPC(c.endpoint)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to connect to bor: %v", err))
+		return 1
+	}
+	defer rpcClient.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	var simulation bor.SpanSimulation
+	if err := rpcClient.CallContext(ctx, &simulation, "bor_simulateSpan", json.RawMessage(data), c.sprints); err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to simulate the span: %v", err))
+		return 1
+	}
+
+	c.UI.Output(formatKV([]string{
+		fmt.Sprintf("Span|%d", simulation.ID),
+		fmt.Sprintf("Blocks|%d - %d", simulation.StartBlock, simulation.EndBlock),
+		fmt.Sprintf("Simulated on block|%d", simulation.Head),
+		fmt.Sprintf("Sprints|%d", len(simulation.Sprints)),
+	}))
+
+	for _, sprint := range simulation.Sprints {
+		c.UI.Output("")
+		c.UI.Output(fmt.Sprintf("Sprint %d - %d, proposer %s", sprint.StartBlock, sprint.EndBlock, sprint.Proposer))
+		c.UI.Output(formatSimulatedProducers(sprint.Producers))
+	}
+
+	return 0
+}
+
+func formatSimulatedProducers(producers []*bor.SimulatedProducer) string {
+	if len(producers) == 0 {
+		return emptyPlaceHolder
+	}
+
+	rows := make([]string, len(producers)+1)
+	rows[0] = "Succession|Address|ID|Voting power|Proposer priority|Difficulty"
+
+	for i, p := range producers {
+		rows[i+1] = fmt.Sprintf("%d|%s|%d|%d|%d|%d",
+			p.Succession,
+			p.Address,
+			p.ID,
+			p.VotingPower,
+			p.ProposerPriority,
+			p.Difficulty,
+		)
+	}
+
+	return formatList(rows)
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// StateSyncCommand is the command to group the state-sync commands
+type StateSyncCommand struct {
+	UI cli.Ui
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *StateSyncCommand) MarkDown() string {
+	items := []string{
+		"# State-sync",
+		"The ```state-sync``` command groups actions to inspect the state-sync events committed by bor:",
+		"- [```state-sync verify```](./state-sync_verify.md): Verify the state-sync events committed in a block range against heimdall.",
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *StateSyncCommand) Help() string {
+	return `Usage: bor state-sync <subcommand>
+
+  This command groups actions to inspect the state-sync events committed by bor.
+
+  Verify the state-sync events committed in a block range:
+
+    $ bor state-sync verify --from 1000 --to 2000`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *StateSyncCommand) Synopsis() string {
+	return "Inspect the state-sync events committed by bor"
+}
+
+// Run implements the cli.Command interface
+func (c *StateSyncCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,404 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mitchellh/cli"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/contract"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/chains"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// stateCommittedTopic is the topic of the event emitted by the state receiver
+// contract for every committed state-sync event
+var stateCommittedTopic = crypto.Keccak256Hash([]byte("StateCommitted(uint256,bool)"))
+
+// State-sync event verification statuses
+const (
+	stateSyncDelayed    = "delayed"    // committed in a later block than expected
+	stateSyncEarly      = "early"      // committed in an earlier block than expected
+	stateSyncMissing    = "missing"    // expected but not committed in the range
+	stateSyncUnexpected = "unexpected" // committed but not expected in the range
+)
+
+// StateSyncVerifyCommand is the command to verify the state-sync events committed
+// in a block range against heimdall
+type StateSyncVerifyCommand struct {
+	UI cli.Ui
+
+	endpoint    string
+	heimdallURL string
+	chain       string
+	timeout     time.Duration
+	from        uint64
+	to          uint64
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *StateSyncVerifyCommand) MarkDown() string {
+	items := []string{
+		"# State-sync verify",
+		"The ```state-sync verify``` command re-fetches the state-sync events from heimdall for every sprint start " +
+			"block in the range, recomputes the events bor should have committed (including the state-sync record " +
+			"overrides and the state-sync confirmation delay) and compares them with the events committed according " +
+			"to the bor block receipts of the node. Mismatches are reported per event id.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *StateSyncVerifyCommand) Help() string {
+	return `Usage: bor state-sync verify --from <block> [--to <block>]
+
+  This command verifies the state-sync events committed in a block range against heimdall` + c.Flags().Help()
+}
+
+func (c *StateSyncVerifyCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("state-sync verify")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "endpoint",
+		Usage: "IPC path or RPC url of the bor node (defaults to the IPC endpoint of the default data directory)",
+		Value: &c.endpoint,
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "heimdall",
+		Usage:   "URL of Heimdall service to fetch the state-sync events from",
+		Value:   &c.heimdallURL,
+		Default: "http://localhost:1317",
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "chain",
+		Usage:   "Name of the chain ('amoy', 'mumbai', 'mainnet') or path to a genesis file",
+		Value:   &c.chain,
+		Default: "mainnet",
+	})
+	flags.DurationFlag(&flagset.DurationFlag{
+		Name:    "timeout",
+		Usage:   "Timeout period for the requests to heimdall",
+		Value:   &c.timeout,
+		Default: 5 * time.Second,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "from",
+		Usage:   "First block of the range to verify",
+		Value:   &c.from,
+		Default: 1,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "to",
+		Usage:   "Last block of the range to verify (0 verifies up to the latest block)",
+		Value:   &c.to,
+		Default: 0,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *StateSyncVerifyCommand) Synopsis() string {
+	return "Verify the state-sync events committed in a block range against heimdall"
+}
+
+// stateSyncReport holds the verification result of a single state-sync event
+type stateSyncReport struct {
+	id            uint64
+	recordTime    time.Time
+	expectedBlock uint64 // 0 if the event isn't expected in the range
+	appliedBlock  uint64 // 0 if the event isn't committed in the range
+}
+
+func (r *stateSyncReport) status() string {
+	switch {
+	case r.expectedBlock == r.appliedBlock:
+		return ""
+	case r.appliedBlock == 0:
+		return stateSyncMissing
+	case r.expectedBlock == 0:
+		return stateSyncUnexpected
+	case r.appliedBlock > r.expectedBlock:
+		return stateSyncDelayed
+	default:
+		return stateSyncEarly
+	}
+}
+
+// stateSyncVerifier collects the expected and committed events per event id
+type stateSyncVerifier struct {
+	reports map[uint64]*stateSyncReport
+}
+
+func newStateSyncVerifier() *stateSyncVerifier {
+	return &stateSyncVerifier{
+		reports: make(map[uint64]*stateSyncReport),
+	}
+}
+
+func (v *stateSyncVerifier) report(id uint64) *stateSyncReport {
+	r, ok := v.reports[id]
+	if !ok {
+		r = &stateSyncReport{id: id}
+		v.reports[id] = r
+	}
+
+	return r
+}
+
+// expect records that the event should have been committed in the given block. An
+// event which wasn't committed is expected again in the following sprints, hence
+// only the first block is kept.
+func (v *stateSyncVerifier) expect(number uint64, id uint64, recordTime time.Time) {
+	r := v.report(id)
+	if r.expectedBlock == 0 {
+		r.expectedBlock = number
+		r.recordTime = recordTime
+	}
+}
+
+// apply records that the event was committed in the given block
+func (v *stateSyncVerifier) apply(number uint64, id uint64) {
+	v.report(id).appliedBlock = number
+}
+
+// mismatches returns the reports of the events which weren't committed as expected,
+// ordered by event id
+func (v *stateSyncVerifier) mismatches() []*stateSyncReport {
+	mismatches := make([]*stateSyncReport, 0)
+
+	for _, r := range v.reports {
+		if r.status() != "" {
+			mismatches = append(mismatches, r)
+		}
+	}
+
+	sort.Slice(mismatches, func(i, j int) bool {
+		return mismatches[i].id < mismatches[j].id
+	})
+
+	return mismatches
+}
+
+// Run implements the cli.Command interface
+func (c *StateSyncVerifyCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	chain, err := chains.GetChain(c.chain)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	chainConfig := chain.Genesis.Config
+	if chainConfig.Bor == nil {
+		c.UI.Error(fmt.Sprintf("Chain %s doesn't use bor consensus", c.chain))
+		return 1
+	}
+
+	rpcClient, err := dialRPC(c.endpoint)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to connect to bor: %v", err))
+		return 1
+	}
+	defer rpcClient.Close()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	client := ethclient.NewClient(rpcClient)
+
+	heimdallClient := heimdall.NewHeimdallClient(c.heimdallURL, c.timeout)
+	defer heimdallClient.Close()
+
+	if c.to == 0 {
+		latest, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Failed to fetch the latest block: %v", err))
+			return 1
+		}
+
+		c.to = latest.Number.Uint64()
+	}
+
+	if c.from == 0 {
+		// The genesis block doesn't commit any state-sync events
+		c.from = 1
+	}
+
+	if c.from > c.to {
+		c.UI.Error(fmt.Sprintf("Invalid block range: from %d, to %d", c.from, c.to))
+		return 1
+	}
+
+	verifier := newStateSyncVerifier()
+	sprints := 0
+
+	for number := c.from; number <= c.to; number++ {
+		if !chainConfig.Bor.IsSprintStart(number) {
+			continue
+		}
+
+		if err := c.verifyBlock(ctx, client, heimdallClient, chainConfig, verifier, number); err != nil {
+			c.UI.Error(fmt.Sprintf("Failed to verify block %d: %v", number, err))
+			return 1
+		}
+
+		sprints++
+	}
+
+	mismatches := verifier.mismatches()
+
+	c.UI.Output(formatStateSyncReports(mismatches))
+	c.UI.Output("")
+	c.UI.Output(formatKV([]string{
+		fmt.Sprintf("Blocks|%d - %d", c.from, c.to),
+		fmt.Sprintf("Sprints verified|%d", sprints),
+		fmt.Sprintf("Events verified|%d", len(verifier.reports)),
+		fmt.Sprintf("Mismatches|%d", len(mismatches)),
+	}))
+
+	if len(mismatches) > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// verifyBlock recomputes the state-sync events committed by bor in the given sprint
+// start block and compares them with the events in the bor block receipt
+func (c *StateSyncVerifyCommand) verifyBlock(ctx context.Context, client *ethclient.Client, heimdallClient *heimdall.HeimdallClient, chainConfig *params.ChainConfig, verifier *stateSyncVerifier, number uint64) error {
+	borConfig := chainConfig.Bor
+	stateReceiver := common.HexToAddress(borConfig.StateReceiverContract)
+
+	header, err := client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return fmt.Errorf("failed to fetch header: %w", err)
+	}
+
+	lastStateID, err := lastStateID(ctx, client, stateReceiver, header.ParentHash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch last state id: %w", err)
+	}
+
+	// Pre Indore, the time window ends at the block one sprint back
+	var sprintHeader *types.Header
+	if !borConfig.IsIndore(header.Number) {
+		sprintNumber := number - borConfig.CalculateSprint(number)
+
+		if sprintHeader, err = client.HeaderByNumber(ctx, new(big.Int).SetUint64(sprintNumber)); err != nil {
+			return fmt.Errorf("failed to fetch header %d: %w", sprintNumber, err)
+		}
+	}
+
+	to := bor.StateSyncWindowEnd(borConfig, header, func(uint64) *types.Header {
+		return sprintHeader
+	})
+
+	eventRecords, err := heimdallClient.StateSyncEvents(ctx, lastStateID+1, to.Unix())
+	if err != nil {
+		return fmt.Errorf("failed to fetch state-sync events: %w", err)
+	}
+
+	for _, eventRecord := range bor.FilterStateSyncEvents(borConfig, number, eventRecords, lastStateID, to, chainConfig.ChainID.String()) {
+		verifier.expect(number, eventRecord.ID, eventRecord.Time)
+	}
+
+	receipt, err := client.GetBorBlockReceipt(ctx, header.Hash())
+	if errors.Is(err, ethereum.NotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch bor block receipt: %w", err)
+	}
+
+	for _, l := range receipt.Logs {
+		if l.Address != stateReceiver || len(l.Topics) < 2 || l.Topics[0] != stateCommittedTopic {
+			continue
+		}
+
+		verifier.apply(number, new(big.Int).SetBytes(l.Topics[1].Bytes()).Uint64())
+	}
+
+	return nil
+}
+
+// lastStateID returns the id of the last state-sync event committed as of the given block
+func lastStateID(ctx context.Context, client *ethclient.Client, stateReceiver common.Address, blockHash common.Hash) (uint64, error) {
+	const method = "lastStateId"
+
+	stateReceiverABI := contract.StateReceiver()
+
+	data, err := stateReceiverABI.Pack(method)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := client.CallContractAtHash(ctx, ethereum.CallMsg{To: &stateReceiver, Data: data}, blockHash)
+	if err != nil {
+		return 0, err
+	}
+
+	ret := new(*big.Int)
+	if err := stateReceiverABI.UnpackIntoInterface(ret, method, result); err != nil {
+		return 0, err
+	}
+
+	return (*ret).Uint64(), nil
+}
+
+func formatStateSyncReports(reports []*stateSyncReport) string {
+	if len(reports) == 0 {
+		return "No mismatches found"
+	}
+
+	formatBlock := func(number uint64) string {
+		if number == 0 {
+			return emptyPlaceHolder
+		}
+
+		return fmt.Sprintf("%d", number)
+	}
+
+	rows := make([]string, len(reports)+1)
+	rows[0] = "Event ID|Record time|Expected block|Committed block|Status"
+
+	for i, r := range reports {
+		recordTime := emptyPlaceHolder
+		if !r.recordTime.IsZero() {
+			recordTime = r.recordTime.UTC().Format(time.RFC3339)
+		}
+
+		rows[i+1] = fmt.Sprintf("%d|%s|%s|%s|%s",
+			r.id,
+			recordTime,
+			formatBlock(r.expectedBlock),
+			formatBlock(r.appliedBlock),
+			r.status())
+	}
+
+	return formatList(rows)
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateSyncVerifier(t *testing.T) {
+	t.Parallel()
+
+	recordTime := time.Unix(1_700_000_000, 0)
+	verifier := newStateSyncVerifier()
+
+	// Committed as expected
+	verifier.expect(16, 1, recordTime)
+	verifier.apply(16, 1)
+
+	// Expected in every sprint until committed
+	verifier.expect(16, 2, recordTime)
+	verifier.expect(32, 2, recordTime)
+	verifier.apply(32, 2)
+
+	// Never committed
+	verifier.expect(32, 3, recordTime)
+
+	// Committed although heimdall doesn't return it for the block
+	verifier.apply(48, 4)
+
+	// Committed before heimdall returns it for the block
+	verifier.expect(64, 5, recordTime)
+	verifier.apply(48, 5)
+
+	mismatches := verifier.mismatches()
+	require.Len(t, mismatches, 4)
+
+	statuses := make(map[uint64]string, len(mismatches))
+	for _, r := range mismatches {
+		statuses[r.id] = r.status()
+	}
+
+	require.Equal(t, map[uint64]string{
+		2: stateSyncDelayed,
+		3: stateSyncMissing,
+		4: stateSyncUnexpected,
+		5: stateSyncEarly,
+	}, statuses)
+}
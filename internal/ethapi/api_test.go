@@ -48,6 +48,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -686,6 +687,18 @@ func (b testBackend) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) even
 	panic("implement me")
 }
 
+func (b testBackend) SubscribeFinalizedHeadEvent(ch chan<- core.FinalizedHeadEvent) event.Subscription {
+	panic("implement me")
+}
+
+func (b testBackend) SubscribeDroppedConditionalTxsEvent(ch chan<- core.DroppedConditionalTxEvent) event.Subscription {
+	panic("implement me")
+}
+
+func (b testBackend) GetConditionalTransactionOutcome(txHash common.Hash) *txpool.ConditionalOutcome {
+	panic("implement me")
+}
+
 func (b testBackend) PeerStats() interface{} {
 	panic("implement me")
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -102,6 +103,7 @@ type Backend interface {
 
 	// Bor related APIs
 	SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) event.Subscription
+	SubscribeFinalizedHeadEvent(ch chan<- core.FinalizedHeadEvent) event.Subscription
 	GetRootHash(ctx context.Context, starBlockNr uint64, endBlockNr uint64) (string, error)
 	GetVoteOnHash(ctx context.Context, startBlockNumber uint64, endBlockNumber uint64, hash string, milestoneID string) (bool, error)
 	GetBorBlockReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
@@ -109,6 +111,8 @@ type Backend interface {
 	GetBorBlockTransaction(ctx context.Context, txHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error)
 	GetBorBlockTransactionWithBlockHash(ctx context.Context, txHash common.Hash, blockHash common.Hash) (*types.Transaction, common.Hash, uint64, uint64, error)
 	SubscribeChain2HeadEvent(ch chan<- core.Chain2HeadEvent) event.Subscription
+	SubscribeDroppedConditionalTxsEvent(ch chan<- core.DroppedConditionalTxEvent) event.Subscription
+	GetConditionalTransactionOutcome(txHash common.Hash) *txpool.ConditionalOutcome
 	GetWhitelistedCheckpoint() (bool, uint64, common.Hash)
 	PurgeWhitelistedCheckpoint()
 	GetWhitelistedMilestone() (bool, uint64, common.Hash)
@@ -2,9 +2,12 @@ package ethapi
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -106,6 +109,120 @@ func (api *BorAPI) SendRawTransactionConditional(ctx context.Context, input hexu
 	return SubmitTransaction(ctx, api.b, tx)
 }
 
+// DroppedConditionalTransaction is the notification of a conditional transaction dropped
+// from the transaction pool because its options aren't satisfied anymore
+type DroppedConditionalTransaction struct {
+	Hash   common.Hash    `json:"hash"`
+	From   common.Address `json:"from"`
+	Nonce  hexutil.Uint64 `json:"nonce"`
+	Reason string         `json:"reason"` // Option which isn't satisfied anymore: knownAccounts, blockNumber or timestamp
+	Error  string         `json:"error"`
+}
+
+// DroppedConditionalTransactions sends a notification each time a conditional transaction
+// is dropped from the transaction pool as its known accounts, block number range or
+// timestamp range isn't satisfied by the new head anymore.
+func (api *BorAPI) DroppedConditionalTransactions(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		var (
+			drops  = make(chan core.DroppedConditionalTxEvent, 128)
+			sub    = api.b.SubscribeDroppedConditionalTxsEvent(drops)
+			signer = types.LatestSigner(api.b.ChainConfig())
+		)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case drop := <-drops:
+				from, _ := types.Sender(signer, drop.Tx)
+
+				notification := &DroppedConditionalTransaction{
+					Hash:   drop.Tx.Hash(),
+					From:   from,
+					Nonce:  hexutil.Uint64(drop.Tx.Nonce()),
+					Reason: drop.Reason,
+				}
+
+				if drop.Err != nil {
+					notification.Error = drop.Err.Error()
+				}
+
+				notifier.Notify(rpcSub.ID, notification)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// Statuses of a conditional transaction besides the outcomes journaled when its options
+// aren't satisfied
+const (
+	conditionalPending  = "pending"
+	conditionalIncluded = "included"
+)
+
+// ConditionalTransactionStatus is the status of a conditional transaction
+type ConditionalTransactionStatus struct {
+	Hash        common.Hash              `json:"hash"`
+	Status      string                   `json:"status"`                // pending, included, rejected or expired
+	BlockNumber *hexutil.Uint64          `json:"blockNumber,omitempty"` // Block the transaction was included in, or its options were last checked against
+	BlockHash   *common.Hash             `json:"blockHash,omitempty"`   // Block the transaction was included in
+	Dropped     bool                     `json:"dropped"`               // Whether the transaction was dropped from the pool
+	Check       *txpool.ConditionalCheck `json:"check,omitempty"`       // Check of the options which failed, if rejected or expired
+}
+
+// GetConditionalTransactionStatus returns the status of a transaction submitted through
+// SendRawTransactionConditional: pending if it's in the pool, included if it's in the
+// chain, or rejected or expired if its options weren't satisfied while building a block
+// or by a new head, along with the check which failed. A transaction skipped while
+// building a block may still be in the pool, unlike one which was dropped.
+func (api *BorAPI) GetConditionalTransactionStatus(ctx context.Context, hash common.Hash) (*ConditionalTransactionStatus, error) {
+	status := &ConditionalTransactionStatus{Hash: hash}
+
+	if found, _, blockHash, blockNumber, _ := api.b.GetTransaction(hash); found {
+		number := hexutil.Uint64(blockNumber)
+
+		status.Status = conditionalIncluded
+		status.BlockNumber = &number
+		status.BlockHash = &blockHash
+
+		return status, nil
+	}
+
+	outcome := api.b.GetConditionalTransactionOutcome(hash)
+
+	// The transaction may have been resubmitted since it was dropped
+	if api.b.GetPoolTransaction(hash) != nil && (outcome == nil || outcome.Dropped) {
+		status.Status = conditionalPending
+		return status, nil
+	}
+
+	if outcome == nil {
+		return nil, errors.New("conditional transaction not found")
+	}
+
+	number := hexutil.Uint64(outcome.BlockNumber)
+
+	status.Status = outcome.Status
+	status.BlockNumber = &number
+	status.Dropped = outcome.Dropped
+	status.Check = outcome.Check
+
+	return status, nil
+}
+
 func (api *BorAPI) GetVoteOnHash(ctx context.Context, starBlockNr uint64, endBlockNr uint64, hash string, milestoneId string) (bool, error) {
 	return api.b.GetVoteOnHash(ctx, starBlockNr, endBlockNr, hash, milestoneId)
 }
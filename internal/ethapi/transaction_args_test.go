@@ -32,6 +32,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -425,6 +426,18 @@ func (b *backendMock) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) eve
 	return nil
 }
 
+func (b *backendMock) SubscribeFinalizedHeadEvent(ch chan<- core.FinalizedHeadEvent) event.Subscription {
+	return nil
+}
+
+func (b *backendMock) SubscribeDroppedConditionalTxsEvent(ch chan<- core.DroppedConditionalTxEvent) event.Subscription {
+	return nil
+}
+
+func (b *backendMock) GetConditionalTransactionOutcome(txHash common.Hash) *txpool.ConditionalOutcome {
+	return nil
+}
+
 func (b *backendMock) GetRootHash(ctx context.Context, starBlockNr uint64, endBlockNr uint64) (string, error) {
 	return "", nil
 }
@@ -60,6 +60,73 @@ web3._extend({
 			call: 'bor_getRootHash',
 			params: 2,
 		}),
+		new web3._extend.Method({
+			name: 'getSpan',
+			call: 'bor_getSpan',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'getSpanByBlock',
+			call: 'bor_getSpanByBlock',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getValidatorSetHistory',
+			call: 'bor_getValidatorSetHistory',
+			params: 2,
+		}),
+		new web3._extend.Method({
+			name: 'getProducerStats',
+			call: 'bor_getProducerStats',
+			params: 2,
+		}),
+		new web3._extend.Method({
+			name: 'getCheckpoint',
+			call: 'bor_getCheckpoint',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'getMilestone',
+			call: 'bor_getMilestone',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'getFinalizedRange',
+			call: 'bor_getFinalizedRange',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'getRootHashProof',
+			call: 'bor_getRootHashProof',
+			params: 3,
+		}),
+		new web3._extend.Method({
+			name: 'simulateSpan',
+			call: 'bor_simulateSpan',
+			params: 2,
+		}),
+		new web3._extend.Method({
+			name: 'getTxDependencyStats',
+			call: 'bor_getTxDependencyStats',
+			params: 2,
+		}),
+		new web3._extend.Method({
+			name: 'getConditionalTransactionStatus',
+			call: 'bor_getConditionalTransactionStatus',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'getBlockProductionReport',
+			call: 'bor_getBlockProductionReport',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'getFinalityIncidents',
+			call: 'bor_getFinalityIncidents',
+			params: 1,
+			inputFormatter: [null]
+		}),
 		new web3._extend.Method({
 			name: 'getVoteOnHash',
 			call: 'bor_getVoteOnHash',
@@ -127,6 +127,10 @@ web3._extend({
 			call: 'admin_sleepBlocks',
 			params: 2
 		}),
+		new web3._extend.Method({
+			name: 'peerReputation',
+			call: 'admin_peerReputation'
+		}),
 		new web3._extend.Method({
 			name: 'startHTTP',
 			call: 'admin_startHTTP',
@@ -485,6 +489,12 @@ web3._extend({
 			params: 2,
 			inputFormatter:[web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
 		}),
+		new web3._extend.Method({
+			name: 'getBlockDependencyGraph',
+			call: 'debug_getBlockDependencyGraph',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter],
+		}),
 		new web3._extend.Method({
 			name: 'getWhitelistedCheckpoint',
 			call: 'debug_getWhitelistedCheckpoint',
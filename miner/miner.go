@@ -53,6 +53,15 @@ type Config struct {
 	Recommit            time.Duration  // The time interval for miner to re-create mining work.
 	CommitInterruptFlag bool           // Interrupt commit when time is up ( default = true)
 
+	SpeculativeBuilding bool // Speculatively execute candidate transactions in parallel while sealing
+	SpeculativeProcs    int  // Number of transactions executed in parallel (0 = number of CPUs)
+
+	TxOrdering string `toml:",omitempty"` // Name of the ordering of the transactions in mined blocks (default = price)
+
+	PipelinedBuilding bool // Build the next block on top of the sealed block during its sealing delay when in turn
+
+	ProductionTimelines uint64 // Number of recent heights whose block production timelines are retained
+
 	NewPayloadTimeout time.Duration // The maximum time allowance for creating a new payload
 }
 
@@ -68,6 +77,8 @@ var DefaultConfig = Config{
 	// run 3 rounds.
 	Recommit:          2 * time.Second,
 	NewPayloadTimeout: 2 * time.Second,
+
+	ProductionTimelines: 1024,
 }
 
 // Miner creates blocks and searches for proof-of-work values.
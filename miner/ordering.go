@@ -18,7 +18,9 @@ package miner
 
 import (
 	"container/heap"
+	"fmt"
 	"math/big"
+	"sync"
 	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -27,11 +29,141 @@ import (
 	"github.com/holiman/uint256"
 )
 
+const (
+	// PriceOrdering fills blocks with the transactions paying the highest tips first, and
+	// the ones first seen first if the tips are equal
+	PriceOrdering = "price"
+
+	// ParallelOrdering fills blocks in rounds of at most one transaction per account, in
+	// price order within a round, so that transactions of independent senders are grouped
+	// together and can be executed in parallel
+	ParallelOrdering = "parallel"
+
+	// ConditionalOrdering is the price ordering with a priority lane for the conditional
+	// transactions (PIP-15) whose conditions are close to expiring
+	ConditionalOrdering = "conditional"
+)
+
+const (
+	// conditionalExpiryBlocks is the number of blocks before the maximum block number of a
+	// conditional transaction from which it is included with priority
+	conditionalExpiryBlocks = 2
+
+	// conditionalExpiryTime is the number of seconds before the maximum timestamp of a
+	// conditional transaction from which it is included with priority
+	conditionalExpiryTime = 8
+)
+
+// TransactionOrder is a set of transactions returned in the order they are committed to
+// a block, while honouring the nonce order of the transactions of every account.
+type TransactionOrder interface {
+	// Peek returns the next transaction along with its effective miner tip.
+	Peek() (*txpool.LazyTransaction, *uint256.Int)
+
+	// Shift replaces the next transaction with the following one from the same account.
+	Shift()
+
+	// Pop removes the next transaction, along with all the following ones from the same
+	// account.
+	Pop()
+
+	// Empty returns whether there are no transactions left.
+	Empty() bool
+
+	// Clear removes all the transactions.
+	Clear()
+}
+
+// TransactionOrdering creates the transaction set a block is filled with from the nonce
+// sorted pending transactions of every account. The input map is reowned by the set. The
+// construction should be halted if interrupt is set.
+type TransactionOrdering func(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, header *types.Header, interrupt *atomic.Bool) TransactionOrder
+
+var (
+	transactionOrderings = map[string]TransactionOrdering{
+		PriceOrdering: func(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, header *types.Header, interrupt *atomic.Bool) TransactionOrder {
+			return newTransactionsByPriceAndNonce(signer, txs, header.BaseFee, interrupt)
+		},
+		ParallelOrdering: func(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, header *types.Header, interrupt *atomic.Bool) TransactionOrder {
+			return newTransactionsBySenderRounds(signer, txs, header.BaseFee, interrupt)
+		},
+		ConditionalOrdering: func(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, header *types.Header, interrupt *atomic.Bool) TransactionOrder {
+			return newTransactionsWithPriorityLane(signer, txs, header.BaseFee, interrupt, expiringConditional(header))
+		},
+	}
+	transactionOrderingsLock sync.RWMutex
+)
+
+// RegisterTransactionOrdering makes a transaction ordering available to the miner under
+// the given name, replacing the existing one with the same name if any.
+func RegisterTransactionOrdering(name string, ordering TransactionOrdering) {
+	transactionOrderingsLock.Lock()
+	defer transactionOrderingsLock.Unlock()
+
+	transactionOrderings[name] = ordering
+}
+
+// transactionOrdering returns the transaction ordering registered under the given name,
+// the price ordering being the default one.
+func transactionOrdering(name string) (TransactionOrdering, error) {
+	if name == "" {
+		name = PriceOrdering
+	}
+
+	transactionOrderingsLock.RLock()
+	defer transactionOrderingsLock.RUnlock()
+
+	ordering, ok := transactionOrderings[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown transaction ordering %q", name)
+	}
+
+	return ordering, nil
+}
+
+// expiringConditional returns whether a transaction is a conditional transaction (PIP-15)
+// whose block number or timestamp range ends soon after the given header, but hasn't
+// ended yet.
+func expiringConditional(header *types.Header) func(*txpool.LazyTransaction) bool {
+	return func(ltx *txpool.LazyTransaction) bool {
+		// Conditional transactions are only accepted by the legacy pool, which hands
+		// them out resolved
+		if ltx.Tx == nil {
+			return false
+		}
+
+		options := ltx.Tx.GetOptions()
+		if options == nil {
+			return false
+		}
+
+		// Transactions whose range already ended can't be included anymore, so there's
+		// no point in prioritising them
+		if options.BlockNumberMax != nil && header.Number != nil && options.BlockNumberMax.Cmp(header.Number) < 0 {
+			return false
+		}
+
+		if options.TimestampMax != nil && *options.TimestampMax < header.Time {
+			return false
+		}
+
+		if options.BlockNumberMax != nil && header.Number != nil {
+			left := new(big.Int).Sub(options.BlockNumberMax, header.Number)
+			if left.Cmp(big.NewInt(conditionalExpiryBlocks)) <= 0 {
+				return true
+			}
+		}
+
+		return options.TimestampMax != nil && *options.TimestampMax <= header.Time+conditionalExpiryTime
+	}
+}
+
 // txWithMinerFee wraps a transaction with its gas price or effective miner gasTipCap
 type txWithMinerFee struct {
-	tx   *txpool.LazyTransaction
-	from common.Address
-	fees *uint256.Int
+	tx       *txpool.LazyTransaction
+	from     common.Address
+	fees     *uint256.Int
+	priority bool // Whether the transaction is in the priority lane
 }
 
 // newTxWithMinerFee creates a wrapped transaction, calculating the effective
@@ -61,6 +193,10 @@ type txByPriceAndTime []*txWithMinerFee
 
 func (s txByPriceAndTime) Len() int { return len(s) }
 func (s txByPriceAndTime) Less(i, j int) bool {
+	// Transactions in the priority lane go first regardless of their price
+	if s[i].priority != s[j].priority {
+		return s[i].priority
+	}
 	// If the prices are equal, use the time the transaction was first seen for
 	// deterministic sorting
 	cmp := s[i].fees.Cmp(s[j].fees)
@@ -92,6 +228,8 @@ type transactionsByPriceAndNonce struct {
 	heads   txByPriceAndTime                             // Next transaction for each unique account (price heap)
 	signer  types.Signer                                 // Signer for the set of transactions
 	baseFee *uint256.Int                                 // Current base fee
+
+	priority func(*txpool.LazyTransaction) bool // Whether a transaction is in the priority lane, if there is one
 }
 
 // newTransactionsByPriceAndNonce creates a transaction set that can retrieve
@@ -102,6 +240,13 @@ type transactionsByPriceAndNonce struct {
 //
 // The construction is halted if interrupt is set (during block building timeout).
 func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, interrupt *atomic.Bool) *transactionsByPriceAndNonce {
+	return newTransactionsWithPriorityLane(signer, txs, baseFee, interrupt, nil)
+}
+
+// newTransactionsWithPriorityLane creates a price sorted transaction set like
+// newTransactionsByPriceAndNonce, except that the transactions for which priority
+// returns true are retrieved before all the others.
+func newTransactionsWithPriorityLane(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, interrupt *atomic.Bool, priority func(*txpool.LazyTransaction) bool) *transactionsByPriceAndNonce {
 	// Convert the basefee from header format to uint256 format
 	var baseFeeUint *uint256.Int
 	if baseFee != nil {
@@ -124,6 +269,9 @@ func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address]
 			delete(txs, from)
 			continue
 		}
+		if priority != nil {
+			wrapped.priority = priority(accTxs[0])
+		}
 		heads = append(heads, wrapped)
 		txs[from] = accTxs[1:]
 	}
@@ -131,10 +279,11 @@ func newTransactionsByPriceAndNonce(signer types.Signer, txs map[common.Address]
 
 	// Assemble and return the transaction set
 	return &transactionsByPriceAndNonce{
-		txs:     txs,
-		heads:   heads,
-		signer:  signer,
-		baseFee: baseFeeUint,
+		txs:      txs,
+		heads:    heads,
+		signer:   signer,
+		baseFee:  baseFeeUint,
+		priority: priority,
 	}
 }
 
@@ -160,6 +309,9 @@ func (t *transactionsByPriceAndNonce) Shift() {
 	acc := t.heads[0].from
 	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
 		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
+			if t.priority != nil {
+				wrapped.priority = t.priority(txs[0])
+			}
 			t.heads[0], t.txs[acc] = wrapped, txs[1:]
 			heap.Fix(&t.heads, 0)
 			return
@@ -0,0 +1,92 @@
+package miner
+
+import (
+	"container/heap"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+// transactionsBySenderRounds represents a set of transactions returned in rounds of at
+// most one transaction per account, sorted by price within a round. The transactions of
+// an account depend on each other through the nonce, whereas the transactions of the
+// same round come from independent senders, which makes the block more parallelizable.
+type transactionsBySenderRounds struct {
+	txs     map[common.Address][]*txpool.LazyTransaction // Per account nonce-sorted list of transactions
+	heads   txByPriceAndTime                             // Next transaction for each account of the current round (price heap)
+	next    txByPriceAndTime                             // Next transaction for each account of the following round
+	baseFee *uint256.Int                                 // Current base fee
+}
+
+// newTransactionsBySenderRounds creates a transaction set that retrieves transactions in
+// rounds of independent senders in a nonce-honouring way.
+//
+// Note, the input map is reowned so the caller should not interact any more with
+// if after providing it to the constructor.
+//
+// The construction is halted if interrupt is set (during block building timeout).
+func newTransactionsBySenderRounds(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, baseFee *big.Int, interrupt *atomic.Bool) *transactionsBySenderRounds {
+	// The first round is the price heap of the head transactions of every account
+	set := newTransactionsByPriceAndNonce(signer, txs, baseFee, interrupt)
+
+	return &transactionsBySenderRounds{
+		txs:     set.txs,
+		heads:   set.heads,
+		next:    make(txByPriceAndTime, 0, len(set.heads)),
+		baseFee: set.baseFee,
+	}
+}
+
+// Peek returns the next transaction of the current round by price.
+func (t *transactionsBySenderRounds) Peek() (*txpool.LazyTransaction, *uint256.Int) {
+	if len(t.heads) == 0 {
+		return nil, nil
+	}
+
+	return t.heads[0].tx, t.heads[0].fees
+}
+
+// Shift removes the current best head and defers the next transaction from the same
+// account to the following round.
+func (t *transactionsBySenderRounds) Shift() {
+	acc := heap.Pop(&t.heads).(*txWithMinerFee).from
+	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
+		if wrapped, err := newTxWithMinerFee(txs[0], acc, t.baseFee); err == nil {
+			t.next, t.txs[acc] = append(t.next, wrapped), txs[1:]
+		}
+	}
+
+	t.nextRound()
+}
+
+// Pop removes the best transaction, *not* deferring the next one from the same account.
+// This should be used when a transaction cannot be executed and hence all subsequent
+// ones should be discarded from the same account.
+func (t *transactionsBySenderRounds) Pop() {
+	heap.Pop(&t.heads)
+	t.nextRound()
+}
+
+// Empty returns if there are no transactions left in any round.
+func (t *transactionsBySenderRounds) Empty() bool {
+	return len(t.heads) == 0
+}
+
+// Clear removes the entire content of the set.
+func (t *transactionsBySenderRounds) Clear() {
+	t.heads, t.next, t.txs = nil, nil, nil
+}
+
+// nextRound starts the following round once the current one is exhausted.
+func (t *transactionsBySenderRounds) nextRound() {
+	if len(t.heads) > 0 || len(t.next) == 0 {
+		return
+	}
+
+	t.heads, t.next = t.next, t.heads[:0]
+	heap.Init(&t.heads)
+}
@@ -20,6 +20,7 @@ import (
 	"crypto/ecdsa"
 	"math/big"
 	"math/rand"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -194,3 +195,180 @@ func TestTransactionTimeSort(t *testing.T) {
 		}
 	}
 }
+
+func newLazyTransaction(tx *types.Transaction) *txpool.LazyTransaction {
+	return &txpool.LazyTransaction{
+		Hash:      tx.Hash(),
+		Tx:        tx,
+		Time:      tx.Time(),
+		GasFeeCap: uint256.MustFromBig(tx.GasFeeCap()),
+		GasTipCap: uint256.MustFromBig(tx.GasTipCap()),
+		Gas:       tx.Gas(),
+		BlobGas:   tx.BlobGas(),
+	}
+}
+
+// Tests that the parallel ordering returns transactions in rounds of one transaction per
+// account, sorted by price within each round.
+func TestTransactionSenderRoundsSort(t *testing.T) {
+	t.Parallel()
+
+	keys := make([]*ecdsa.PrivateKey, 3)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	signer := types.HomesteadSigner{}
+
+	// Every account sends decreasingly priced transactions, the first account paying the most
+	groups := map[common.Address][]*txpool.LazyTransaction{}
+	for start, key := range keys {
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		for i := 0; i < 3; i++ {
+			price := big.NewInt(int64(100*(len(keys)-start) - 10*i))
+			tx, _ := types.SignTx(types.NewTransaction(uint64(i), common.Address{}, big.NewInt(100), 100, price, nil), signer, key)
+			groups[addr] = append(groups[addr], newLazyTransaction(tx))
+		}
+	}
+	txset := newTransactionsBySenderRounds(signer, groups, nil, nil)
+
+	txs := types.Transactions{}
+	for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
+		txs = append(txs, tx.Tx)
+		txset.Shift()
+	}
+	if len(txs) != 9 {
+		t.Fatalf("expected 9 transactions, found %d", len(txs))
+	}
+	for i, tx := range txs {
+		from, _ := types.Sender(signer, tx)
+		if want := crypto.PubkeyToAddress(keys[i%3].PublicKey); from != want {
+			t.Errorf("tx #%d: sender mismatch: have %x, want %x", i, from, want)
+		}
+		if tx.Nonce() != uint64(i/3) {
+			t.Errorf("tx #%d: nonce mismatch: have %d, want %d", i, tx.Nonce(), i/3)
+		}
+	}
+	if !txset.Empty() {
+		t.Errorf("expected empty transaction set")
+	}
+}
+
+// Tests that popping a transaction from the parallel ordering drops the following
+// transactions of the same account from the next rounds.
+func TestTransactionSenderRoundsPop(t *testing.T) {
+	t.Parallel()
+
+	keys := make([]*ecdsa.PrivateKey, 2)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	signer := types.HomesteadSigner{}
+
+	groups := map[common.Address][]*txpool.LazyTransaction{}
+	for start, key := range keys {
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		for i := 0; i < 2; i++ {
+			tx, _ := types.SignTx(types.NewTransaction(uint64(i), common.Address{}, big.NewInt(100), 100, big.NewInt(int64(10-start)), nil), signer, key)
+			groups[addr] = append(groups[addr], newLazyTransaction(tx))
+		}
+	}
+	txset := newTransactionsBySenderRounds(signer, groups, nil, nil)
+
+	// Drop the first account, which pays the most
+	txset.Pop()
+
+	count := 0
+	for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
+		if from, _ := types.Sender(signer, tx.Tx); from != crypto.PubkeyToAddress(keys[1].PublicKey) {
+			t.Errorf("unexpected transaction from popped account %x", from)
+		}
+		count++
+		txset.Shift()
+	}
+	if count != 2 {
+		t.Errorf("expected 2 transactions, found %d", count)
+	}
+}
+
+// Tests that the conditional ordering returns the conditional transactions close to
+// expiring before all the others, regardless of their price, leaving the expired ones
+// to the price ordering.
+func TestTransactionConditionalPriorityLane(t *testing.T) {
+	t.Parallel()
+
+	header := &types.Header{Number: big.NewInt(100), Time: 1000}
+	signer := types.HomesteadSigner{}
+
+	var (
+		blockMax    = big.NewInt(101)
+		farMax      = big.NewInt(200)
+		timeMax     = uint64(1005)
+		expiredMax  = big.NewInt(99)
+		expiredTime = uint64(999)
+		conditions  = []*types.OptionsPIP15{
+			nil,                             // Plain transaction paying the most
+			{BlockNumberMax: farMax},        // Conditional transaction far from expiring
+			{BlockNumberMax: blockMax},      // Conditional transaction expiring by block number
+			{TimestampMax: &timeMax},        // Conditional transaction expiring by timestamp
+			{BlockNumberMin: big.NewInt(1)}, // Conditional transaction without expiry
+			{BlockNumberMax: expiredMax},    // Conditional transaction expired by block number
+			{TimestampMax: &expiredTime},    // Conditional transaction expired by timestamp
+		}
+		prices = []int64{50, 40, 10, 20, 30, 60, 55}
+		want   = []int{3, 2, 5, 6, 0, 1, 4}
+	)
+
+	groups := map[common.Address][]*txpool.LazyTransaction{}
+	hashes := make(map[common.Hash]int)
+	for i, options := range conditions {
+		key, _ := crypto.GenerateKey()
+		tx, _ := types.SignTx(types.NewTransaction(0, common.Address{}, big.NewInt(100), 100, big.NewInt(prices[i]), nil), signer, key)
+		if options != nil {
+			tx.PutOptions(options)
+		}
+		groups[crypto.PubkeyToAddress(key.PublicKey)] = []*txpool.LazyTransaction{newLazyTransaction(tx)}
+		hashes[tx.Hash()] = i
+	}
+	ordering, err := transactionOrdering(ConditionalOrdering)
+	if err != nil {
+		t.Fatalf("failed to retrieve conditional ordering: %v", err)
+	}
+	txset := ordering(signer, groups, header, nil)
+
+	var have []int
+	for tx, _ := txset.Peek(); tx != nil; tx, _ = txset.Peek() {
+		have = append(have, hashes[tx.Hash])
+		txset.Shift()
+	}
+	if len(have) != len(want) {
+		t.Fatalf("transaction count mismatch: have %d, want %d", len(have), len(want))
+	}
+	for i := range want {
+		if have[i] != want[i] {
+			t.Errorf("tx #%d: have transaction %d, want %d", i, have[i], want[i])
+		}
+	}
+}
+
+// Tests that transaction orderings are looked up by name, the price ordering being the
+// default one, and that custom orderings can be registered.
+func TestTransactionOrderingRegistry(t *testing.T) {
+	t.Parallel()
+
+	if _, err := transactionOrdering(""); err != nil {
+		t.Errorf("failed to retrieve default ordering: %v", err)
+	}
+	if _, err := transactionOrdering("unknown"); err == nil {
+		t.Errorf("expected error for unknown ordering")
+	}
+	RegisterTransactionOrdering("test-custom", func(signer types.Signer, txs map[common.Address][]*txpool.LazyTransaction, header *types.Header, interrupt *atomic.Bool) TransactionOrder {
+		return newTransactionsByPriceAndNonce(signer, nil, header.BaseFee, interrupt)
+	})
+	ordering, err := transactionOrdering("test-custom")
+	if err != nil {
+		t.Fatalf("failed to retrieve registered ordering: %v", err)
+	}
+	if txset := ordering(types.HomesteadSigner{}, nil, &types.Header{Number: common.Big1}, nil); !txset.Empty() {
+		t.Errorf("expected empty transaction set")
+	}
+}
@@ -0,0 +1,324 @@
+package miner
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var (
+	pipelineBuiltMeter     = metrics.NewRegisteredMeter("worker/pipeline/built", nil)
+	pipelineUsedMeter      = metrics.NewRegisteredMeter("worker/pipeline/used", nil)
+	pipelineDiscardedMeter = metrics.NewRegisteredMeter("worker/pipeline/discarded", nil)
+)
+
+// workChain is the chain a block is prepared and executed within.
+type workChain interface {
+	consensus.ChainHeaderReader
+	Engine() consensus.Engine
+}
+
+// pipelineChain is the local chain extended with a sealed block which isn't written yet,
+// on top of which the next block is built in advance.
+type pipelineChain struct {
+	*core.BlockChain
+
+	parent     *types.Header
+	parentHash common.Hash
+}
+
+func newPipelineChain(chain *core.BlockChain, parent *types.Header) *pipelineChain {
+	return &pipelineChain{
+		BlockChain: chain,
+		parent:     parent,
+		parentHash: parent.Hash(),
+	}
+}
+
+// CurrentHeader returns the sealed block, which is expected to become the head.
+func (c *pipelineChain) CurrentHeader() *types.Header {
+	return c.parent
+}
+
+// GetHeader retrieves a block header by hash and number, including the sealed block.
+func (c *pipelineChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if hash == c.parentHash && number == c.parent.Number.Uint64() {
+		return c.parent
+	}
+
+	return c.BlockChain.GetHeader(hash, number)
+}
+
+// GetHeaderByHash retrieves a block header by hash, including the sealed block.
+func (c *pipelineChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	if hash == c.parentHash {
+		return c.parent
+	}
+
+	return c.BlockChain.GetHeaderByHash(hash)
+}
+
+// GetHeaderByNumber retrieves a block header by number, the sealed block being the head.
+func (c *pipelineChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number == c.parent.Number.Uint64() {
+		return c.parent
+	}
+
+	return c.BlockChain.GetHeaderByNumber(number)
+}
+
+// pipelineReq is a request to build the next block on top of a block waiting for its slot.
+type pipelineReq struct {
+	parent    *types.Header // Header of the sealed block
+	hash      common.Hash   // Hash of the sealed block
+	interrupt atomic.Int32  // Signal aborting the building if the sealed block is superseded
+	stop      atomic.Bool   // Toggle stopping the building once the sealed block is due
+	done      chan struct{} // Closed once the building is over, whether the work is kept or not
+}
+
+func newPipelineReq(parent *types.Header) *pipelineReq {
+	return &pipelineReq{
+		parent: parent,
+		hash:   parent.Hash(),
+		done:   make(chan struct{}),
+	}
+}
+
+// pipelining returns whether the next block may be built in advance on top of the given
+// block while it waits for its slot. Blocks starting a sprint are never built in advance,
+// since the span and the state-sync events they commit require the parent to be written,
+// besides them being produced by the next producer.
+func (w *worker) pipelining(block *types.Block) bool {
+	if !w.config.PipelinedBuilding || w.chainConfig.Bor == nil {
+		return false
+	}
+
+	number := block.NumberU64() + 1
+
+	return !bor.IsSprintStart(number, w.chainConfig.Bor.CalculateSprint(number))
+}
+
+// commitPipelineParent commits a copy of the state of a block submitted for sealing, so
+// that the next block is built in advance on top of its committed state. The import of
+// the block then finds its state committed already, keeping the state layers linear.
+func (w *worker) commitPipelineParent(task *task) error {
+	return w.chain.CommitStateAhead(task.block, task.state.Copy())
+}
+
+// requestPipeline requests the building of the next block on top of the block with the
+// given seal hash, which was just submitted for sealing, superseding any previous request.
+func (w *worker) requestPipeline(sealHash common.Hash) {
+	engine, ok := w.engine.(*bor.Bor)
+	if !ok {
+		return
+	}
+
+	parent := engine.SignedHeader(sealHash)
+	if parent == nil {
+		return
+	}
+
+	req := newPipelineReq(parent)
+
+	if prev := w.pipeline.Swap(req); prev != nil {
+		prev.interrupt.Store(commitInterruptNewHead)
+	}
+
+	// Drop any pending request, the channel has a single sender hence the send can't block
+	select {
+	case prev := <-w.pipelineCh:
+		close(prev.done)
+	default:
+	}
+
+	w.pipelineCh <- req
+}
+
+// interruptPipeline stops the building in advance of the next block once the sealed block
+// it's built upon becomes the head, so that the work is submitted right away, and aborts it
+// if the new head is another block.
+func (w *worker) interruptPipeline(head *types.Header) {
+	req := w.pipeline.Load()
+	if req == nil {
+		return
+	}
+
+	if req.hash == head.Hash() {
+		req.stop.Store(true)
+	} else {
+		req.interrupt.Store(commitInterruptNewHead)
+	}
+}
+
+// pipelineLoop is a standalone goroutine building the next block in advance on top of
+// the blocks submitted for sealing, so that the main loop isn't held up meanwhile.
+func (w *worker) pipelineLoop() {
+	defer w.wg.Done()
+	defer w.discardPipelinedWork()
+
+	for {
+		select {
+		case req := <-w.pipelineCh:
+			w.commitPipelinedWork(req)
+			close(req.done)
+
+		case <-w.exitCh:
+			return
+		}
+	}
+}
+
+// commitPipelinedWork builds the next block on top of a sealed block waiting for its slot,
+// if the local signer is in turn for it. The building stops at the slot of the sealed block,
+// or as soon as it becomes the head, and the work is then submitted by the next commitWork.
+// It's thrown away if the sealed block is superseded.
+func (w *worker) commitPipelinedWork(req *pipelineReq) {
+	if !w.IsRunning() || w.syncing.Load() || req.interrupt.Load() != commitInterruptNone {
+		return
+	}
+
+	engine, ok := w.engine.(*bor.Bor)
+	if !ok {
+		return
+	}
+
+	chain := newPipelineChain(w.chain, req.parent)
+	if !engine.InTurn(chain, req.parent) {
+		return
+	}
+
+	coinbase := w.etherbase()
+	if coinbase == (common.Address{}) {
+		return
+	}
+
+	w.discardPipelinedWork()
+
+	start := time.Now()
+	number := req.parent.Number.Uint64() + 1
+
+	// The state of the sealed block was committed before sealing it
+	state, err := w.chain.StateAt(req.parent.Root)
+	if err != nil {
+		log.Debug("Failed to open pipelined parent state", "number", number, "parent", req.hash, "err", err)
+		return
+	}
+
+	w.mu.RLock()
+	work, err := w.prepareWorkOn(chain, req.parent, state, &generateParams{
+		timestamp: uint64(start.Unix()),
+		coinbase:  coinbase,
+	}, false)
+	w.mu.RUnlock()
+
+	if err != nil {
+		log.Debug("Failed to prepare pipelined work", "number", number, "err", err)
+		return
+	}
+
+	work.buildInterrupt = &req.stop
+
+	w.timeline.begin(number, start)
+	w.timeline.prepare(work.header)
+
+	// The building stops at the slot of the sealed block, which then becomes the head. The
+	// timer clears the stop toggle when starting, but fires right away if the slot passed.
+	stopFn := func() {}
+	if w.interruptCommitFlag {
+		stopFn = createInterruptTimer(number, req.parent.Time, work.buildInterrupt, func() {
+			w.timeline.record(number, timelineInterrupt, 0, "")
+		})
+	}
+
+	err = w.fillTransactions(&req.interrupt, work)
+	stopFn()
+
+	if err != nil {
+		log.Debug("Discarding pipelined work", "number", number, "parent", req.hash, "err", err)
+		work.discard()
+
+		return
+	}
+
+	w.timeline.record(number, timelinePipeline, time.Since(start), "parent "+req.hash.Hex())
+	pipelineBuiltMeter.Mark(1)
+
+	log.Debug("Built pipelined work", "number", number, "parent", req.hash, "txs", work.tcount,
+		"elapsed", common.PrettyDuration(time.Since(start)))
+
+	w.pipelineMu.Lock()
+	defer w.pipelineMu.Unlock()
+
+	// The sealed block may have been superseded while building on top of it
+	if req.interrupt.Load() != commitInterruptNone {
+		pipelineDiscardedMeter.Mark(1)
+		work.discard()
+
+		return
+	}
+
+	w.pipelined = work
+}
+
+// takePipelinedWork returns the work built in advance on top of the given parent for the
+// given coinbase, if any. A building still in progress on top of the parent is stopped and
+// awaited rather than building the block again. Work built upon a block still waiting for its
+// slot is kept for it, and work built upon any other block is thrown away.
+func (w *worker) takePipelinedWork(parent *types.Header, coinbase common.Address) *environment {
+	hash := parent.Hash()
+
+	req := w.pipeline.Load()
+	if req != nil && req.hash == hash {
+		req.stop.Store(true)
+
+		select {
+		case <-req.done:
+		case <-w.exitCh:
+			return nil
+		}
+	}
+
+	w.pipelineMu.Lock()
+	defer w.pipelineMu.Unlock()
+
+	work := w.pipelined
+	if work == nil {
+		return nil
+	}
+
+	if work.header.ParentHash != hash && req != nil && req.hash == work.header.ParentHash && req.interrupt.Load() == commitInterruptNone {
+		return nil
+	}
+
+	w.pipelined = nil
+
+	if work.header.ParentHash != hash || work.coinbase != coinbase {
+		pipelineDiscardedMeter.Mark(1)
+		work.discard()
+
+		return nil
+	}
+
+	pipelineUsedMeter.Mark(1)
+
+	return work
+}
+
+// discardPipelinedWork throws away the work built in advance, if any.
+func (w *worker) discardPipelinedWork() {
+	w.pipelineMu.Lock()
+	defer w.pipelineMu.Unlock()
+
+	if w.pipelined != nil {
+		pipelineDiscardedMeter.Mark(1)
+		w.pipelined.discard()
+		w.pipelined = nil
+	}
+}
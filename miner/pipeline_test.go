@@ -0,0 +1,345 @@
+package miner
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestPipelineChain(t *testing.T) {
+	t.Parallel()
+
+	engine := ethash.NewFaker()
+	defer engine.Close()
+
+	b := newTestWorkerBackend(t, params.TestChainConfig, engine, rawdb.NewMemoryDatabase())
+	defer b.chain.Stop()
+	defer b.txPool.Close()
+
+	genesis := b.chain.CurrentHeader()
+	sealed := &types.Header{ParentHash: genesis.Hash(), Number: big.NewInt(1), Time: genesis.Time + 2, Extra: []byte("sealed")}
+
+	chain := newPipelineChain(b.chain, sealed)
+
+	if head := chain.CurrentHeader(); head.Hash() != sealed.Hash() {
+		t.Fatalf("head mismatch: have %x, want %x", head.Hash(), sealed.Hash())
+	}
+
+	if header := chain.GetHeader(sealed.Hash(), 1); header == nil || header.Hash() != sealed.Hash() {
+		t.Fatalf("sealed block not found by hash and number")
+	}
+
+	if header := chain.GetHeaderByHash(sealed.Hash()); header == nil || header.Hash() != sealed.Hash() {
+		t.Fatalf("sealed block not found by hash")
+	}
+
+	if header := chain.GetHeaderByNumber(1); header == nil || header.Hash() != sealed.Hash() {
+		t.Fatalf("sealed block not found by number")
+	}
+
+	// Blocks of the local chain are still available
+	if header := chain.GetHeader(genesis.Hash(), 0); header == nil || header.Hash() != genesis.Hash() {
+		t.Fatalf("genesis block not found")
+	}
+
+	// The sealed block isn't written
+	if header := b.chain.GetHeaderByHash(sealed.Hash()); header != nil {
+		t.Fatalf("sealed block found in the local chain")
+	}
+}
+
+// newPipelineTestWorker creates a bor worker building blocks in advance, on top of a chain
+// using the given state scheme.
+func newPipelineTestWorker(t *testing.T, scheme string) (*worker, *testWorkerBackend, func()) {
+	t.Helper()
+
+	chainConfig := *params.BorUnittestChainConfig
+
+	engine, ctrl := getFakeBorFromConfig(t, &chainConfig)
+
+	config := DefaultTestConfig()
+	config.PipelinedBuilding = true
+
+	b := newTestWorkerBackendWithCache(t, &chainConfig, engine, rawdb.NewMemoryDatabase(), core.DefaultCacheConfigWithScheme(scheme))
+
+	w := newWorker(config, &chainConfig, engine, b, new(event.TypeMux), nil, false)
+	w.setEtherbase(testBankAddress)
+
+	return w, b, func() {
+		w.close()
+		b.chain.Stop()
+		engine.Close()
+		ctrl.Finish()
+	}
+}
+
+// nolint : paralleltest
+func TestPipelinedWorkImport(t *testing.T) {
+	for _, scheme := range []string{rawdb.HashScheme, rawdb.PathScheme} {
+		t.Run(scheme, func(t *testing.T) {
+			testPipelinedWorkImport(t, scheme)
+		})
+	}
+}
+
+func testPipelinedWorkImport(t *testing.T, scheme string) {
+	w, b, cleanup := newPipelineTestWorker(t, scheme)
+	defer cleanup()
+
+	// This test chain imports the mined blocks.
+	chain, _ := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, b.genesis, nil, w.engine, vm.Config{}, nil, nil, nil)
+	defer chain.Stop()
+
+	sub := w.mux.Subscribe(core.NewMinedBlockEvent{})
+	defer sub.Unsubscribe()
+
+	used := pipelineUsedMeter.Snapshot().Count()
+
+	w.start()
+
+	for i := uint64(0); i < 6; i++ {
+		if err := b.txPool.Add([]*types.Transaction{b.newRandomTxWithNonce(false, i)}, false)[0]; err != nil {
+			t.Fatal("while adding a transaction", err)
+		}
+
+		select {
+		case ev := <-sub.Chan():
+			block := ev.Data.(core.NewMinedBlockEvent).Block
+			if _, err := chain.InsertChain([]*types.Block{block}); err != nil {
+				t.Fatalf("failed to insert new mined block %d: %v", block.NumberU64(), err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout")
+		}
+	}
+
+	w.stop()
+
+	if pipelineUsedMeter.Snapshot().Count() == used {
+		t.Fatalf("no block built in advance was submitted")
+	}
+
+	checkLinearState(t, w.chain, scheme)
+}
+
+// checkLinearState checks the state of every block of the chain is available and layered
+// right on top of the state of its parent.
+func checkLinearState(t *testing.T, chain *core.BlockChain, scheme string) {
+	t.Helper()
+
+	head := chain.CurrentBlock()
+
+	for number := uint64(1); number <= head.Number.Uint64(); number++ {
+		if !chain.HasState(chain.GetHeaderByNumber(number).Root) {
+			t.Fatalf("block %d: state missing", number)
+		}
+	}
+
+	// The path scheme assigns consecutive ids to the layers persisted from the head down
+	if scheme == rawdb.PathScheme {
+		if err := chain.TrieDB().Commit(head.Root, false); err != nil {
+			t.Fatalf("failed to persist the state layers: %v", err)
+		}
+	}
+
+	for number := uint64(1); number <= head.Number.Uint64(); number++ {
+		header := chain.GetHeaderByNumber(number)
+		parent := chain.GetHeaderByNumber(number - 1)
+
+		if header.Root == parent.Root {
+			continue
+		}
+
+		switch scheme {
+		case rawdb.HashScheme:
+			layers := chain.Snapshots().Snapshots(header.Root, 2, false)
+			if len(layers) != 2 {
+				t.Fatalf("block %d: state layers missing: have %d, want 2", number, len(layers))
+			}
+
+			if root := layers[1].Root(); root != parent.Root {
+				t.Fatalf("block %d: parent state layer mismatch: have %x, want %x", number, root, parent.Root)
+			}
+
+		case rawdb.PathScheme:
+			db := chain.TrieDB().Disk()
+
+			id, parentID := rawdb.ReadStateID(db, header.Root), rawdb.ReadStateID(db, parent.Root)
+			if id == nil || parentID == nil {
+				t.Fatalf("block %d: state ids missing", number)
+			}
+
+			if *id != *parentID+1 {
+				t.Fatalf("block %d: state not layered on top of its parent: id %d, parent id %d", number, *id, *parentID)
+			}
+		}
+	}
+}
+
+// nolint : paralleltest
+func TestPipelinedWorkFullPool(t *testing.T) {
+	w, b, cleanup := newPipelineTestWorker(t, rawdb.PathScheme)
+	defer cleanup()
+
+	// Filling a block takes way longer than a slot, so the building in advance of a block
+	// is still running when its parent becomes the head
+	w.setMockTxDelay(100)
+
+	txs := make([]*types.Transaction, 200)
+	for i := range txs {
+		txs[i] = b.newRandomTxWithNonce(false, uint64(i))
+	}
+
+	for _, err := range b.txPool.Add(txs, true) {
+		if err != nil {
+			t.Fatal("while adding a transaction", err)
+		}
+	}
+
+	chain, _ := core.NewBlockChain(rawdb.NewMemoryDatabase(), nil, b.genesis, nil, w.engine, vm.Config{}, nil, nil, nil)
+	defer chain.Stop()
+
+	sub := w.mux.Subscribe(core.NewMinedBlockEvent{})
+	defer sub.Unsubscribe()
+
+	var (
+		used      = pipelineUsedMeter.Snapshot().Count()
+		discarded = pipelineDiscardedMeter.Snapshot().Count()
+		included  int
+	)
+
+	w.start()
+
+	for i := 0; i < 5; i++ {
+		select {
+		case ev := <-sub.Chan():
+			block := ev.Data.(core.NewMinedBlockEvent).Block
+			if _, err := chain.InsertChain([]*types.Block{block}); err != nil {
+				t.Fatalf("failed to insert new mined block %d: %v", block.NumberU64(), err)
+			}
+
+			included += len(block.Transactions())
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timeout")
+		}
+	}
+
+	w.stop()
+
+	// The blocks built in advance are submitted rather than built again
+	if have := pipelineUsedMeter.Snapshot().Count() - used; have < 3 {
+		t.Fatalf("blocks built in advance submitted: have %d, want at least 3", have)
+	}
+
+	if have := pipelineDiscardedMeter.Snapshot().Count() - discarded; have != 0 {
+		t.Fatalf("blocks built in advance discarded: have %d, want 0", have)
+	}
+
+	if included == 0 {
+		t.Fatalf("no transaction included")
+	}
+
+	checkLinearState(t, w.chain, rawdb.PathScheme)
+}
+
+// nolint : paralleltest
+func TestPipelinedWorkDiscarded(t *testing.T) {
+	w, b, cleanup := newPipelineTestWorker(t, rawdb.HashScheme)
+	defer cleanup()
+
+	genesis := b.chain.CurrentBlock()
+	sealed := &types.Header{ParentHash: genesis.Hash(), Number: big.NewInt(1), Time: genesis.Time + 2, Root: genesis.Root}
+	other := &types.Header{ParentHash: genesis.Hash(), Number: big.NewInt(1), Time: genesis.Time + 2, Root: genesis.Root, Extra: []byte("other")}
+
+	prepare := func() *environment {
+		work, err := w.prepareWork(&generateParams{timestamp: genesis.Time + 2, coinbase: testBankAddress}, false)
+		if err != nil {
+			t.Fatalf("failed to prepare work: %v", err)
+		}
+
+		return work
+	}
+
+	// Work built upon another block than the head is thrown away
+	discarded := pipelineDiscardedMeter.Snapshot().Count()
+
+	w.pipelined = prepare()
+	if work := w.takePipelinedWork(other, testBankAddress); work != nil {
+		t.Fatalf("work built upon another block submitted")
+	}
+
+	if w.pipelined != nil {
+		t.Fatalf("work built upon another block retained")
+	}
+
+	if pipelineDiscardedMeter.Snapshot().Count() != discarded+1 {
+		t.Fatalf("discarded work not accounted")
+	}
+
+	// Work built for another coinbase is thrown away as well
+	w.pipelined = prepare()
+	if work := w.takePipelinedWork(genesis, common.Address{0x01}); work != nil {
+		t.Fatalf("work built for another coinbase submitted")
+	}
+
+	// Work built upon the head is submitted
+	w.pipelined = prepare()
+	if work := w.takePipelinedWork(genesis, testBankAddress); work == nil {
+		t.Fatalf("work built upon the head not submitted")
+	}
+
+	// Work built upon a block still waiting for its slot is kept for it
+	pending := newPipelineReq(genesis)
+	w.pipeline.Store(pending)
+
+	w.pipelined = prepare()
+	if work := w.takePipelinedWork(other, testBankAddress); work != nil {
+		t.Fatalf("work built upon a pending block submitted")
+	}
+
+	if w.pipelined == nil {
+		t.Fatalf("work built upon a pending block thrown away")
+	}
+
+	// The building in progress upon the head is stopped and awaited
+	close(pending.done)
+
+	if work := w.takePipelinedWork(genesis, testBankAddress); work == nil {
+		t.Fatalf("work built upon the head not submitted")
+	}
+
+	if !pending.stop.Load() {
+		t.Fatalf("building upon the head not stopped")
+	}
+
+	// The sealed block becoming the head stops the building upon it, another head aborts it
+	req := newPipelineReq(sealed)
+	w.pipeline.Store(req)
+
+	w.interruptPipeline(sealed)
+	if req.interrupt.Load() != commitInterruptNone || !req.stop.Load() {
+		t.Fatalf("building not stopped by the sealed block")
+	}
+
+	w.interruptPipeline(other)
+	if req.interrupt.Load() != commitInterruptNewHead {
+		t.Fatalf("building not interrupted by another head")
+	}
+
+	w.running.Store(true)
+	w.commitPipelinedWork(req)
+	w.running.Store(false)
+
+	if w.pipelined != nil {
+		t.Fatalf("work built upon a superseded block")
+	}
+}
@@ -0,0 +1,445 @@
+package miner
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum/go-ethereum/common"
+	cmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/blockstm"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// speculativeBatchSize is the maximum number of candidate transactions executed
+// speculatively at once
+const speculativeBatchSize = 64
+
+var (
+	// speculativeCommittedMeter counts the transactions committed from their speculative execution
+	speculativeCommittedMeter = metrics.NewRegisteredMeter("worker/speculative/committed", nil)
+	// speculativeReexecutedMeter counts the transactions re-executed because of a conflict
+	speculativeReexecutedMeter = metrics.NewRegisteredMeter("worker/speculative/reexecuted", nil)
+)
+
+// depsRecorder records the read and write sets of the last committed transaction to
+// compute the transaction dependencies of the block, if enabled
+type depsRecorder func(readList []blockstm.ReadDescriptor, readMap map[blockstm.Key]blockstm.ReadDescriptor, writeList []blockstm.WriteDescriptor) error
+
+// speculativeTx is a candidate transaction executed on its own copy of the state of
+// the block being built, without the changes of the transactions committed before it
+// in the same batch.
+type speculativeTx struct {
+	tx    *types.Transaction
+	msg   *core.Message
+	from  common.Address
+	state *state.StateDB // Copy of the state recording the reads and writes of the execution
+
+	result *core.ExecutionResult
+	err    error
+}
+
+// valid reports whether the speculative execution can be committed as is, i.e. whether
+// it didn't read any key written by the transactions committed before it in the batch.
+func (stx *speculativeTx) valid(coinbase common.Address, written map[blockstm.Key]struct{}) bool {
+	if stx.err != nil || stx.result == nil || errors.Is(stx.result.Err, vm.ErrInterrupt) {
+		return false
+	}
+
+	reads := stx.state.MVReadMap()
+
+	// The fees of the committed transactions are credited to these balances on commit
+	if _, ok := reads[blockstm.NewSubpathKey(coinbase, state.BalancePath)]; ok {
+		return false
+	}
+
+	if _, ok := reads[blockstm.NewSubpathKey(stx.result.BurntContractAddress, state.BalancePath)]; ok {
+		return false
+	}
+
+	for key := range reads {
+		if _, ok := written[key]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// speculativeProcs returns the number of transactions executed in parallel
+func (w *worker) speculativeProcs() int {
+	if w.config.SpeculativeProcs > 0 {
+		return w.config.SpeculativeProcs
+	}
+
+	return runtime.NumCPU()
+}
+
+// commitSpeculativeTransactions fills the block with batches of plain transactions which
+// are executed speculatively in parallel and then committed in price order. Transactions
+// whose speculative execution conflicts with a transaction committed before them are
+// re-executed on the state of the block. Transactions left in the list, e.g. once the
+// gas left doesn't allow a full batch anymore, are committed one at a time afterwards.
+// The dependencies of the committed transactions are recorded if recordDeps isn't nil.
+func (w *worker) commitSpeculativeTransactions(env *environment, txs TransactionOrder, interrupt *atomic.Int32, minTip *uint256.Int, recordDeps depsRecorder) ([]*types.Log, error) {
+	var (
+		logs    []*types.Log
+		skipped = make(map[common.Address]bool)
+	)
+
+	for {
+		if interrupt != nil {
+			if signal := interrupt.Load(); signal != commitInterruptNone {
+				return logs, signalToErr(signal)
+			}
+		}
+
+		if env.buildInterrupt.Load() {
+			return logs, nil
+		}
+
+		batch := w.speculativeBatch(env, txs, minTip, skipped)
+		if len(batch) == 0 {
+			return logs, nil
+		}
+
+		w.executeSpeculativeBatch(env, batch)
+
+		batchLogs, err := w.commitSpeculativeBatch(env, batch, interrupt, skipped, recordDeps)
+		logs = append(logs, batchLogs...)
+
+		if err != nil || env.buildInterrupt.Load() {
+			return logs, err
+		}
+	}
+}
+
+// speculativeBatch pulls the next candidate transactions from the list in price order,
+// at most one per account since the following nonces of an account always conflict with
+// the first one. The batch ends before a transaction which may not fit in the gas left or
+// which is sent by an account already in the batch, leaving it to the next batch.
+func (w *worker) speculativeBatch(env *environment, txs TransactionOrder, minTip *uint256.Int, skipped map[common.Address]bool) []*speculativeTx {
+	var (
+		batch   = make([]*speculativeTx, 0, speculativeBatchSize)
+		gas     = env.gasPool.Gas()
+		senders = make(map[common.Address]struct{}, speculativeBatchSize)
+	)
+
+	for len(batch) < speculativeBatchSize {
+		ltx, tip := txs.Peek()
+		if ltx == nil || gas < params.TxGas || gas < ltx.Gas {
+			break
+		}
+
+		// If the next-best is too low, surely no better will be available
+		if tip.Cmp(minTip) < 0 {
+			break
+		}
+
+		tx := ltx.Resolve()
+		if tx == nil {
+			log.Trace("Ignoring evicted transaction", "hash", ltx.Hash)
+			txs.Pop()
+
+			continue
+		}
+
+		from, _ := types.Sender(env.signer, tx)
+		if skipped[from] {
+			txs.Pop()
+			continue
+		}
+
+		if _, ok := senders[from]; ok {
+			break
+		}
+
+		// The known accounts of conditional transactions are validated on commit
+		if options := tx.GetOptions(); options != nil {
+			if err := env.header.ValidateBlockNumberOptionsPIP15(options.BlockNumberMin, options.BlockNumberMax); err != nil {
+				w.rejectConditional(env, from, tx, core.ConditionalBlockNumber, err)
+				txs.Pop()
+
+				continue
+			}
+
+			if err := env.header.ValidateTimestampOptionsPIP15(options.TimestampMin, options.TimestampMax); err != nil {
+				w.rejectConditional(env, from, tx, core.ConditionalTimestamp, err)
+				txs.Pop()
+
+				continue
+			}
+		}
+
+		if tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
+			log.Trace("Ignoring replay protected transaction", "hash", ltx.Hash, "eip155", w.chainConfig.EIP155Block)
+			txs.Pop()
+
+			continue
+		}
+
+		msg, err := core.TransactionToMessage(tx, env.signer, env.header.BaseFee)
+		if err != nil {
+			log.Debug("Transaction failed, account skipped", "hash", ltx.Hash, "err", err)
+			w.timeline.reject(env.header.Number.Uint64(), ltx.Hash, err)
+			txs.Pop()
+
+			continue
+		}
+
+		batch = append(batch, &speculativeTx{tx: tx, msg: msg, from: from})
+		senders[from] = struct{}{}
+		gas -= ltx.Gas
+
+		txs.Shift()
+	}
+
+	return batch
+}
+
+// executeSpeculativeBatch executes the transactions of the batch in parallel, each one
+// on its own copy of the state of the block.
+func (w *worker) executeSpeculativeBatch(env *environment, batch []*speculativeTx) {
+	// The state is copied upfront as copies of the same state must not be taken concurrently
+	for _, stx := range batch {
+		stx.state = env.state.Copy()
+		stx.state.SetMVHashmap(blockstm.MakeMVHashMap())
+		stx.state.ClearReadMap()
+		stx.state.ClearWriteMap()
+	}
+
+	var (
+		wg    sync.WaitGroup
+		tasks = make(chan *speculativeTx, len(batch))
+	)
+
+	for _, stx := range batch {
+		tasks <- stx
+	}
+
+	close(tasks)
+
+	for i := 0; i < w.speculativeProcs() && i < len(batch); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for stx := range tasks {
+				w.executeSpeculativeTx(env, stx)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (w *worker) executeSpeculativeTx(env *environment, stx *speculativeTx) {
+	defer func() {
+		if r := recover(); r != nil {
+			stx.err = fmt.Errorf("speculative execution failed: %v", r)
+		}
+	}()
+
+	stx.state.SetTxContext(stx.tx.Hash(), env.tcount)
+
+	evm := vm.NewEVM(env.evm.Context, stx.state, w.chainConfig, vm.Config{})
+	evm.SetTxContext(core.NewEVMTxContext(stx.msg))
+
+	stx.result, stx.err = core.ApplyMessageNoFeeBurnOrTip(evm, *stx.msg, new(core.GasPool).AddGas(stx.tx.Gas()), env.buildInterrupt)
+
+	// Set mock delay (if any) between transactions for tests
+	time.Sleep(time.Duration(w.mockTxDelay) * time.Millisecond)
+}
+
+// commitSpeculativeBatch commits the transactions of the batch in order. A speculative
+// execution which read a key written by a transaction committed before it is discarded
+// and the transaction is executed again on the state of the block.
+func (w *worker) commitSpeculativeBatch(env *environment, batch []*speculativeTx, interrupt *atomic.Int32, skipped map[common.Address]bool, recordDeps depsRecorder) ([]*types.Log, error) {
+	var (
+		logs     []*types.Log
+		coinbase = env.evm.Context.Coinbase
+		written  = make(map[blockstm.Key]struct{})
+	)
+
+	for _, stx := range batch {
+		if interrupt != nil {
+			if signal := interrupt.Load(); signal != commitInterruptNone {
+				return logs, signalToErr(signal)
+			}
+		}
+
+		if env.buildInterrupt.Load() {
+			txCommitInterruptCounter.Inc(1)
+			log.Debug("Block building interrupted due to timeout, aborting new transaction commits", "hash", stx.tx.Hash())
+
+			return logs, nil
+		}
+
+		if skipped[stx.from] {
+			continue
+		}
+
+		if env.gasPool.Gas() < stx.tx.Gas() {
+			log.Trace("Not enough gas left for transaction", "hash", stx.tx.Hash(), "left", env.gasPool.Gas(), "needed", stx.tx.Gas())
+			skipped[stx.from] = true
+
+			continue
+		}
+
+		env.state.SetMVHashmap(nil)
+
+		if options := stx.tx.GetOptions(); options != nil {
+			if err := env.state.ValidateKnownAccounts(options.KnownAccounts); err != nil {
+				w.rejectConditional(env, stx.from, stx.tx, core.ConditionalKnownAccounts, err)
+				skipped[stx.from] = true
+
+				continue
+			}
+		}
+
+		var (
+			txLogs   []*types.Log
+			readList []blockstm.ReadDescriptor
+			readMap  map[blockstm.Key]blockstm.ReadDescriptor
+			writes   []blockstm.WriteDescriptor
+			err      error
+		)
+
+		if stx.valid(coinbase, written) {
+			speculativeCommittedMeter.Mark(1)
+			w.timeline.tried(env.header.Number.Uint64())
+
+			txLogs = w.applySpeculativeTx(env, stx)
+			readList, readMap, writes = stx.state.MVReadList(), stx.state.MVReadMap(), stx.state.MVFullWriteList()
+		} else {
+			speculativeReexecutedMeter.Mark(1)
+
+			env.state.AddEmptyMVHashMap()
+			env.state.SetTxContext(stx.tx.Hash(), env.tcount)
+
+			txLogs, err = w.commitTransaction(env, stx.tx)
+			readList, readMap, writes = env.state.MVReadList(), env.state.MVReadMap(), env.state.MVFullWriteList()
+
+			env.state.ClearReadMap()
+			env.state.ClearWriteMap()
+			env.state.SetMVHashmap(nil)
+		}
+
+		switch {
+		case errors.Is(err, core.ErrNonceTooLow):
+			log.Trace("Skipping transaction with low nonce", "hash", stx.tx.Hash(), "sender", stx.from, "nonce", stx.tx.Nonce())
+			w.timeline.reject(env.header.Number.Uint64(), stx.tx.Hash(), err)
+
+			continue
+
+		case err != nil:
+			log.Debug("Transaction failed, account skipped", "hash", stx.tx.Hash(), "err", err)
+			w.timeline.reject(env.header.Number.Uint64(), stx.tx.Hash(), err)
+			skipped[stx.from] = true
+
+			continue
+		}
+
+		logs = append(logs, txLogs...)
+		env.tcount++
+
+		if recordDeps != nil {
+			if err := recordDeps(readList, readMap, writes); err != nil {
+				return logs, err
+			}
+		}
+
+		for _, write := range writes {
+			written[write.Path] = struct{}{}
+		}
+	}
+
+	return logs, nil
+}
+
+// applySpeculativeTx commits a transaction to the block by applying the writes of its
+// speculative execution and crediting its fees, as done when settling transactions in
+// the parallel state processor.
+func (w *worker) applySpeculativeTx(env *environment, stx *speculativeTx) []*types.Log {
+	var (
+		statedb  = env.state
+		tx       = stx.tx
+		result   = stx.result
+		coinbase = env.evm.Context.Coinbase
+		number   = env.header.Number
+		hash     = env.header.Hash()
+	)
+
+	statedb.SetTxContext(tx.Hash(), env.tcount)
+
+	coinbaseBalance := statedb.GetBalance(coinbase)
+
+	statedb.ApplyMVWriteSet(stx.state.MVFullWriteList())
+
+	for _, l := range stx.state.GetLogs(tx.Hash(), number.Uint64(), hash) {
+		statedb.AddLog(l)
+	}
+
+	for k, v := range stx.state.Preimages() {
+		statedb.AddPreimage(k, v)
+	}
+
+	if w.chainConfig.IsLondon(number) {
+		statedb.AddBalance(result.BurntContractAddress, cmath.BigIntToUint256Int(result.FeeBurnt), tracing.BalanceChangeTransfer)
+	}
+
+	statedb.AddBalance(coinbase, cmath.BigIntToUint256Int(result.FeeTipped), tracing.BalanceChangeTransfer)
+	output1 := new(big.Int).SetBytes(result.SenderInitBalance.Bytes())
+	output2 := new(big.Int).SetBytes(coinbaseBalance.Bytes())
+
+	// Deprecating transfer log and will be removed in future fork. PLEASE DO NOT USE this transfer log going forward. Parameters won't get updated as expected going forward with EIP1559
+	// add transfer log
+	core.AddFeeTransferLog(
+		statedb,
+
+		stx.msg.From,
+		coinbase,
+
+		result.FeeTipped,
+		result.SenderInitBalance,
+		coinbaseBalance.ToBig(),
+		output1.Sub(output1, result.FeeTipped),
+		output2.Add(output2, result.FeeTipped),
+	)
+
+	// Update the state with pending changes.
+	var root []byte
+
+	if w.chainConfig.IsByzantium(number) {
+		statedb.Finalise(true)
+	} else {
+		root = statedb.IntermediateRoot(w.chainConfig.IsEIP158(number)).Bytes()
+	}
+
+	// The gas left was checked against the gas limit of the transaction
+	_ = env.gasPool.SubGas(result.UsedGas)
+	env.header.GasUsed += result.UsedGas
+
+	env.evm.SetTxContext(core.NewEVMTxContext(stx.msg))
+
+	receipt := core.MakeReceipt(env.evm, result, statedb, number, hash, tx, env.header.GasUsed, root)
+
+	env.txs = append(env.txs, tx)
+	env.receipts = append(env.receipts, receipt)
+
+	return receipt.Logs
+}
@@ -0,0 +1,88 @@
+package miner
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that a speculative batch holds at most one transaction per account, leaving the
+// following nonces of the accounts to the next batches.
+func TestSpeculativeBatchOneTxPerSender(t *testing.T) {
+	t.Parallel()
+
+	keys := make([]*ecdsa.PrivateKey, 2)
+	for i := 0; i < len(keys); i++ {
+		keys[i], _ = crypto.GenerateKey()
+	}
+	signer := types.HomesteadSigner{}
+
+	// Interleave the prices of the accounts so that each one has consecutive nonces
+	// next to each other in price order
+	prices := [][]int64{{30, 10, 5}, {20, 15, 1}}
+
+	groups := map[common.Address][]*txpool.LazyTransaction{}
+	for k, key := range keys {
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+		for i, price := range prices[k] {
+			tx, _ := types.SignTx(types.NewTransaction(uint64(i), common.Address{}, big.NewInt(100), params.TxGas, big.NewInt(price), nil), signer, key)
+			groups[addr] = append(groups[addr], newLazyTransaction(tx))
+		}
+	}
+	txs := newTransactionsByPriceAndNonce(signer, groups, nil, nil)
+
+	w := &worker{chainConfig: params.TestChainConfig}
+	env := &environment{
+		signer:  signer,
+		header:  &types.Header{Number: big.NewInt(1)},
+		gasPool: new(core.GasPool).AddGas(params.TxGas * 10),
+	}
+
+	var (
+		batches [][]*speculativeTx
+		nonces  = make(map[common.Address]uint64)
+		count   int
+	)
+
+	for {
+		batch := w.speculativeBatch(env, txs, new(uint256.Int), map[common.Address]bool{})
+		if len(batch) == 0 {
+			break
+		}
+		batches = append(batches, batch)
+
+		senders := make(map[common.Address]struct{})
+		for _, stx := range batch {
+			if _, ok := senders[stx.from]; ok {
+				t.Fatalf("batch #%d: account %x included twice", len(batches)-1, stx.from)
+			}
+			senders[stx.from] = struct{}{}
+
+			if stx.tx.Nonce() != nonces[stx.from] {
+				t.Fatalf("batch #%d: nonce mismatch for %x: have %d, want %d", len(batches)-1, stx.from, stx.tx.Nonce(), nonces[stx.from])
+			}
+			nonces[stx.from]++
+			count++
+		}
+	}
+	if count != 6 {
+		t.Fatalf("expected 6 transactions, found %d", count)
+	}
+	if len(batches) != 3 {
+		t.Fatalf("expected 3 batches, found %d", len(batches))
+	}
+	for i, batch := range batches {
+		if len(batch) != 2 {
+			t.Errorf("batch #%d: expected 2 transactions, found %d", i, len(batch))
+		}
+	}
+}
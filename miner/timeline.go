@@ -0,0 +1,195 @@
+package miner
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// maxTimelineRejections is the maximum number of rejected transactions recorded in a
+	// block production timeline.
+	maxTimelineRejections = 256
+)
+
+// Steps of the production of a block recorded in its timeline
+const (
+	timelineCommitWork = "commitWork" // Block building started (once per recommit)
+	timelinePrepare    = "prepare"    // Header prepared, with the delay until the header time
+	timelinePipeline   = "pipeline"   // Block built in advance during the sealing delay of its parent
+	timelineInterrupt  = "interrupt"  // Block building interrupted by the timeout
+	timelineFinalize   = "finalize"   // Block assembled, with the time spent in FinalizeAndAssemble
+	timelineStateSync  = "stateSync"  // State-sync events committed, with the time spent
+	timelineSeal       = "seal"       // Block submitted to the consensus engine for sealing
+	timelineBroadcast  = "broadcast"  // Sealed block written and broadcast
+)
+
+// timelineRecorder records the timeline of the blocks produced by the local validator,
+// and persists it once the block is broadcast or superseded by a block at a later height.
+type timelineRecorder struct {
+	db        ethdb.KeyValueStore
+	retain    uint64                                    // Number of recent heights whose timelines are retained
+	timelines map[uint64]*rawdb.BlockProductionTimeline // Timelines of the blocks being produced
+	stored    []uint64                                  // Numbers of the persisted timelines, in ascending order
+	lock      sync.Mutex
+}
+
+func newTimelineRecorder(db ethdb.KeyValueStore, retain uint64) *timelineRecorder {
+	return &timelineRecorder{
+		db:        db,
+		retain:    retain,
+		timelines: make(map[uint64]*rawdb.BlockProductionTimeline),
+		stored:    rawdb.ReadBlockProductionTimelineNumbers(db),
+	}
+}
+
+// begin records the start of the building of the block with the given number. The
+// timelines of blocks which are two or more heights behind are persisted as is, since
+// they can't be sealed anymore.
+func (r *timelineRecorder) begin(number uint64, start time.Time) {
+	if r == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for n, timeline := range r.timelines {
+		if n+1 < number {
+			r.persist(timeline)
+		}
+	}
+
+	timeline, ok := r.timelines[number]
+	if !ok {
+		timeline = &rawdb.BlockProductionTimeline{Number: number}
+		r.timelines[number] = timeline
+	}
+
+	timeline.Events = append(timeline.Events, &rawdb.BlockProductionEvent{Name: timelineCommitWork, Time: start})
+}
+
+// record appends a step to the timeline of the block with the given number.
+func (r *timelineRecorder) record(number uint64, name string, duration time.Duration, detail string) {
+	if r == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if timeline, ok := r.timelines[number]; ok {
+		timeline.Events = append(timeline.Events, &rawdb.BlockProductionEvent{
+			Name:     name,
+			Time:     time.Now(),
+			Duration: duration,
+			Detail:   detail,
+		})
+	}
+}
+
+// prepare records the header of the block with the given number once prepared.
+func (r *timelineRecorder) prepare(header *types.Header) {
+	if r == nil {
+		return
+	}
+
+	number := header.Number.Uint64()
+	delay := time.Until(time.Unix(int64(header.Time), 0))
+
+	r.lock.Lock()
+	if timeline, ok := r.timelines[number]; ok {
+		timeline.HeaderTime = header.Time
+	}
+	r.lock.Unlock()
+
+	r.record(number, timelinePrepare, delay, "")
+}
+
+// tried records the execution of a transaction in the block with the given number.
+func (r *timelineRecorder) tried(number uint64) {
+	if r == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if timeline, ok := r.timelines[number]; ok {
+		timeline.Tried++
+	}
+}
+
+// reject records that a transaction couldn't be included in the block with the given
+// number.
+func (r *timelineRecorder) reject(number uint64, hash common.Hash, reason error) {
+	if r == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	timeline, ok := r.timelines[number]
+	if !ok {
+		return
+	}
+
+	if len(timeline.Rejected) >= maxTimelineRejections {
+		timeline.Omitted++
+		return
+	}
+
+	timeline.Rejected = append(timeline.Rejected, &rawdb.RejectedTransaction{Hash: hash, Reason: reason.Error()})
+}
+
+// broadcast records that the given block was sealed and broadcast, and persists its
+// timeline.
+func (r *timelineRecorder) broadcast(block *types.Block) {
+	if r == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	timeline, ok := r.timelines[block.NumberU64()]
+	if !ok {
+		return
+	}
+
+	timeline.Hash = block.Hash()
+	timeline.Sealed = true
+	timeline.Included = uint64(len(block.Transactions()))
+	timeline.Events = append(timeline.Events, &rawdb.BlockProductionEvent{Name: timelineBroadcast, Time: time.Now()})
+
+	r.persist(timeline)
+}
+
+// persist stores the given timeline and prunes the ones which fell out of the retained
+// range of heights. It assumes the lock is held.
+func (r *timelineRecorder) persist(timeline *rawdb.BlockProductionTimeline) {
+	delete(r.timelines, timeline.Number)
+
+	batch := r.db.NewBatch()
+	rawdb.WriteBlockProductionTimeline(batch, timeline)
+
+	if i, found := slices.BinarySearch(r.stored, timeline.Number); !found {
+		r.stored = slices.Insert(r.stored, i, timeline.Number)
+	}
+
+	for len(r.stored) > 0 && r.stored[0]+r.retain <= timeline.Number {
+		rawdb.DeleteBlockProductionTimeline(batch, r.stored[0])
+		r.stored = r.stored[1:]
+	}
+
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to store block production timeline", "number", timeline.Number, "err", err)
+	}
+}
@@ -0,0 +1,101 @@
+package miner
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestTimelineRecorder(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		recorder = newTimelineRecorder(db, DefaultConfig.ProductionTimelines)
+		start    = time.Now()
+		header   = &types.Header{Number: big.NewInt(10), Time: uint64(start.Unix()) + 2}
+	)
+
+	// Steps of blocks which aren't being built are ignored
+	recorder.record(9, timelineSeal, 0, "")
+	recorder.reject(9, common.Hash{1}, errors.New("nonce too high"))
+
+	recorder.begin(10, start)
+	recorder.prepare(header)
+	recorder.tried(10)
+	recorder.tried(10)
+	recorder.reject(10, common.Hash{2}, errors.New("nonce too high"))
+
+	for i := 0; i < maxTimelineRejections; i++ {
+		recorder.reject(10, common.Hash{3}, errors.New("intrinsic gas too low"))
+	}
+
+	recorder.record(10, timelineFinalize, time.Millisecond, "1 txs")
+	recorder.record(10, timelineSeal, 0, "")
+
+	// The timeline is only persisted once the block is broadcast
+	require.Nil(t, rawdb.ReadBlockProductionTimeline(db, 10))
+
+	block := types.NewBlockWithHeader(header)
+	recorder.broadcast(block)
+
+	timeline := rawdb.ReadBlockProductionTimeline(db, 10)
+	require.NotNil(t, timeline)
+	require.True(t, timeline.Sealed)
+	require.Equal(t, block.Hash(), timeline.Hash)
+	require.Equal(t, header.Time, timeline.HeaderTime)
+	require.Equal(t, uint64(2), timeline.Tried)
+	require.Len(t, timeline.Rejected, maxTimelineRejections)
+	require.Equal(t, common.Hash{2}, timeline.Rejected[0].Hash)
+	require.Equal(t, "nonce too high", timeline.Rejected[0].Reason)
+	require.Equal(t, uint64(1), timeline.Omitted)
+
+	names := make([]string, 0, len(timeline.Events))
+	for _, ev := range timeline.Events {
+		names = append(names, ev.Name)
+	}
+
+	require.Equal(t, []string{timelineCommitWork, timelinePrepare, timelineFinalize, timelineSeal, timelineBroadcast}, names)
+	require.Equal(t, time.Millisecond, timeline.Events[2].Duration)
+
+	// A block which is never sealed is persisted once superseded by two heights
+	recorder.begin(11, start)
+	recorder.begin(12, start)
+	require.Nil(t, rawdb.ReadBlockProductionTimeline(db, 11))
+
+	recorder.begin(13, start)
+
+	timeline = rawdb.ReadBlockProductionTimeline(db, 11)
+	require.NotNil(t, timeline)
+	require.False(t, timeline.Sealed)
+	require.Equal(t, common.Hash{}, timeline.Hash)
+}
+
+func TestTimelineRecorderPruning(t *testing.T) {
+	t.Parallel()
+
+	db := rawdb.NewMemoryDatabase()
+
+	// Timelines persisted before a restart are pruned as well
+	for _, number := range []uint64{1, 3} {
+		rawdb.WriteBlockProductionTimeline(db, &rawdb.BlockProductionTimeline{Number: number})
+	}
+
+	recorder := newTimelineRecorder(db, 4)
+
+	for _, number := range []uint64{4, 6, 7} {
+		recorder.begin(number, time.Now())
+		recorder.broadcast(types.NewBlockWithHeader(&types.Header{Number: new(big.Int).SetUint64(number)}))
+	}
+
+	// Only the timelines of the last 4 heights are retained
+	require.Equal(t, []uint64{4, 6, 7}, rawdb.ReadBlockProductionTimelineNumbers(db))
+	require.Equal(t, []uint64{4, 6, 7}, recorder.stored)
+}
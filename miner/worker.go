@@ -114,6 +114,8 @@ type environment struct {
 	depsMVFullWriteList [][]blockstm.WriteDescriptor
 	mvReadMapList       []map[blockstm.Key]blockstm.ReadDescriptor
 	witness             *stateless.Witness
+
+	buildInterrupt *atomic.Bool // Toggle stopping the building of the block on timeout
 }
 
 // copy creates a deep copy of environment.
@@ -127,6 +129,7 @@ func (env *environment) copy() *environment {
 		receipts:            copyReceipts(env.receipts),
 		depsMVFullWriteList: env.depsMVFullWriteList,
 		mvReadMapList:       env.mvReadMapList,
+		buildInterrupt:      env.buildInterrupt,
 	}
 
 	if env.gasPool != nil {
@@ -232,11 +235,16 @@ type worker struct {
 	exitCh             chan struct{}
 	resubmitIntervalCh chan time.Duration
 	resubmitAdjustCh   chan *intervalAdjust
+	pipelineCh         chan *pipelineReq
 
 	wg sync.WaitGroup
 
 	current *environment // An environment for current running cycle.
 
+	pipeline   atomic.Pointer[pipelineReq] // Latest request to build the next block in advance
+	pipelineMu sync.Mutex                  // The lock used to protect the pipelined work
+	pipelined  *environment                // Next block built in advance
+
 	mu       sync.RWMutex // The lock used to protect the coinbase and extra fields
 	coinbase common.Address
 	extra    []byte
@@ -266,6 +274,12 @@ type worker struct {
 	// payload in proof-of-stake stage.
 	recommit time.Duration
 
+	// ordering creates the transaction sets the blocks are filled with.
+	ordering TransactionOrdering
+
+	// timeline records the timeline of the blocks produced by the local validator.
+	timeline *timelineRecorder
+
 	// External functions
 	isLocalBlock func(header *types.Header) bool // Function used to determine whether the specified block is mined by local miner.
 
@@ -312,6 +326,7 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 		exitCh:              make(chan struct{}),
 		resubmitIntervalCh:  make(chan time.Duration),
 		resubmitAdjustCh:    make(chan *intervalAdjust, resubmitAdjustChanSize),
+		pipelineCh:          make(chan *pipelineReq, 1),
 		interruptCommitFlag: config.CommitInterruptFlag,
 	}
 	worker.noempty.Store(true)
@@ -333,6 +348,15 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 
 	worker.recommit = recommit
 
+	// Fall back to the price ordering if the configured one isn't registered.
+	ordering, err := transactionOrdering(worker.config.TxOrdering)
+	if err != nil {
+		log.Warn("Sanitizing miner transaction ordering", "provided", worker.config.TxOrdering, "updated", PriceOrdering, "err", err)
+		ordering, _ = transactionOrdering(PriceOrdering)
+	}
+
+	worker.ordering = ordering
+
 	// Sanitize the timeout config for creating payload.
 	newpayloadTimeout := worker.config.NewPayloadTimeout
 	if newpayloadTimeout == 0 {
@@ -346,12 +370,22 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 
 	worker.newpayloadTimeout = newpayloadTimeout
 
-	worker.wg.Add(4)
+	// Sanitize the number of retained block production timelines.
+	timelines := worker.config.ProductionTimelines
+	if timelines == 0 {
+		log.Warn("Sanitizing retained production timelines to default", "provided", timelines, "updated", DefaultConfig.ProductionTimelines)
+		timelines = DefaultConfig.ProductionTimelines
+	}
+
+	worker.timeline = newTimelineRecorder(eth.BlockChain().DB(), timelines)
+
+	worker.wg.Add(5)
 
 	go worker.mainLoop()
 	go worker.newWorkLoop(recommit)
 	go worker.resultLoop()
 	go worker.taskLoop()
+	go worker.pipelineLoop()
 
 	// Submit first work to initialize pending state.
 	if init {
@@ -530,6 +564,7 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 
 		case head := <-w.chainHeadCh:
 			clearPending(head.Header.Number.Uint64())
+			w.interruptPipeline(head.Header)
 
 			timestamp = time.Now().Unix()
 			commit(false, commitInterruptNewHead)
@@ -649,11 +684,11 @@ func (w *worker) mainLoop() {
 
 				stopFn := func() {}
 				if w.interruptCommitFlag {
-					stopFn = createInterruptTimer(w.current.header.Number.Uint64(), w.current.header.Time, &w.interruptBlockBuilding)
+					stopFn = createInterruptTimer(w.current.header.Number.Uint64(), w.current.header.Time, w.current.buildInterrupt, nil)
 				}
 
-				plainTxs := newTransactionsByPriceAndNonce(w.current.signer, txs, w.current.header.BaseFee, &w.interruptBlockBuilding) // Mixed bag of everrything, yolo
-				blobTxs := newTransactionsByPriceAndNonce(w.current.signer, nil, w.current.header.BaseFee, &w.interruptBlockBuilding)  // Empty bag, don't bother optimising
+				plainTxs := w.ordering(w.current.signer, txs, w.current.header, w.current.buildInterrupt)                            // Mixed bag of everrything, yolo
+				blobTxs := newTransactionsByPriceAndNonce(w.current.signer, nil, w.current.header.BaseFee, w.current.buildInterrupt) // Empty bag, don't bother optimising
 
 				tcount := w.current.tcount
 
@@ -729,11 +764,28 @@ func (w *worker) taskLoop() {
 			w.pendingTasks[sealHash] = task
 			w.pendingMu.Unlock()
 
+			// The state is committed before sealing, so the block can't be written meanwhile
+			pipelined := false
+			if w.pipelining(task.block) {
+				if err := w.commitPipelineParent(task); err != nil {
+					log.Debug("Failed to commit pipelined parent state", "number", task.block.Number(), "err", err)
+				} else {
+					pipelined = true
+				}
+			}
+
 			if err := w.engine.Seal(w.chain, task.block, w.resultCh, stopCh); err != nil {
 				log.Warn("Block sealing failed", "err", err)
+				w.timeline.record(task.block.NumberU64(), timelineSeal, 0, err.Error())
 				w.pendingMu.Lock()
 				delete(w.pendingTasks, sealHash)
 				w.pendingMu.Unlock()
+			} else {
+				w.timeline.record(task.block.NumberU64(), timelineSeal, 0, "")
+
+				if pipelined {
+					w.requestPipeline(sealHash)
+				}
 			}
 		case <-w.exitCh:
 			interrupt()
@@ -833,6 +885,7 @@ func (w *worker) resultLoop() {
 
 			// Broadcast the block and announce chain insertion event
 			w.mux.Post(core.NewMinedBlockEvent{Block: block})
+			w.timeline.broadcast(block)
 
 			sealedBlocksCounter.Inc(1)
 
@@ -846,32 +899,41 @@ func (w *worker) resultLoop() {
 	}
 }
 
-// makeEnv creates a new environment for the sealing block.
-func (w *worker) makeEnv(parent *types.Header, header *types.Header, coinbase common.Address, witness bool) (*environment, error) {
+// makeEnv creates a new environment for the sealing block, on top of the given parent
+// state or the one retrieved from the database if nil.
+func (w *worker) makeEnv(chain workChain, parent *types.Header, base *state.StateDB, header *types.Header, coinbase common.Address, witness bool) (*environment, error) {
 	// Retrieve the parent state to execute on top.
-	state, err := w.chain.StateAt(parent.Root)
-	if err != nil {
-		return nil, err
-	}
-	if witness {
-		bundle, err := stateless.NewWitness(header, w.chain)
-		if err != nil {
+	state := base
+	if state == nil {
+		var err error
+		if state, err = w.chain.StateAt(parent.Root); err != nil {
 			return nil, err
 		}
-		state.StartPrefetcher("miner", bundle)
 	}
+	// The prefetcher opens the tries at the root the state was loaded at, hence it's not
+	// started on a given state which holds the uncommitted changes of its parent.
+	if base == nil {
+		if witness {
+			bundle, err := stateless.NewWitness(header, chain)
+			if err != nil {
+				return nil, err
+			}
+			state.StartPrefetcher("miner", bundle)
+		}
 
-	// todo: @anshalshukla - check if witness is required
-	state.StartPrefetcher("miner", nil)
+		// todo: @anshalshukla - check if witness is required
+		state.StartPrefetcher("miner", nil)
+	}
 
 	// Note the passed coinbase may be different with header.Coinbase.
 	env := &environment{
-		signer:   types.MakeSigner(w.chainConfig, header.Number, header.Time),
-		state:    state,
-		coinbase: coinbase,
-		header:   header,
-		witness:  state.Witness(),
-		evm:      vm.NewEVM(core.NewEVMBlockContext(header, w.chain, &coinbase), state, w.chainConfig, vm.Config{}),
+		signer:         types.MakeSigner(w.chainConfig, header.Number, header.Time),
+		state:          state,
+		coinbase:       coinbase,
+		header:         header,
+		witness:        state.Witness(),
+		evm:            vm.NewEVM(core.NewEVMBlockContext(header, chain, &coinbase), state, w.chainConfig, vm.Config{}),
+		buildInterrupt: &w.interruptBlockBuilding,
 	}
 	// Keep track of transactions which return errors so they can be removed
 	env.tcount = 0
@@ -906,7 +968,9 @@ func (w *worker) commitTransaction(env *environment, tx *types.Transaction) ([]*
 		gp   = env.gasPool.Gas()
 	)
 
-	receipt, err := core.ApplyTransaction(env.evm, env.gasPool, env.state, env.header, tx, &env.header.GasUsed, &w.interruptBlockBuilding)
+	w.timeline.tried(env.header.Number.Uint64())
+
+	receipt, err := core.ApplyTransaction(env.evm, env.gasPool, env.state, env.header, tx, &env.header.GasUsed, env.buildInterrupt)
 	if err != nil {
 		env.state.RevertToSnapshot(snap)
 		env.gasPool.SetGas(gp)
@@ -919,7 +983,18 @@ func (w *worker) commitTransaction(env *environment, tx *types.Transaction) ([]*
 	return receipt.Logs, nil
 }
 
-func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transactionsByPriceAndNonce, interrupt *atomic.Int32, minTip *uint256.Int) error {
+// rejectConditional records that the options of a conditional transaction (PIP-15)
+// aren't satisfied by the block being built, which is then skipped.
+func (w *worker) rejectConditional(env *environment, from common.Address, tx *types.Transaction, option string, err error) {
+	log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Hash(), "reason", err)
+	w.timeline.reject(env.header.Number.Uint64(), tx.Hash(), err)
+
+	if pool := w.eth.TxPool(); pool != nil {
+		pool.ConditionalJournal().Reject(tx.Hash(), env.header.Number.Uint64(), option, err)
+	}
+}
+
+func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs TransactionOrder, interrupt *atomic.Int32, minTip *uint256.Int) error {
 	defer func(t0 time.Time) {
 		commitTransactionsTimer.Update(time.Since(t0))
 	}(time.Now())
@@ -962,6 +1037,57 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 		}(chDeps)
 	}
 
+	// recordDeps records the read and write sets of the last committed transaction
+	recordDeps := func(readList []blockstm.ReadDescriptor, readMap map[blockstm.Key]blockstm.ReadDescriptor, writeList []blockstm.WriteDescriptor) error {
+		env.depsMVFullWriteList = append(env.depsMVFullWriteList, writeList)
+		env.mvReadMapList = append(env.mvReadMapList, readMap)
+
+		if env.tcount > len(env.depsMVFullWriteList) {
+			log.Warn("blockstm - env.tcount > len(env.depsMVFullWriteList)", "env.tcount", env.tcount, "len(depsMVFullWriteList)", len(env.depsMVFullWriteList))
+			return errors.New("transaction count exceeds dependency list length")
+		}
+
+		temp := blockstm.TxDep{
+			Index:         env.tcount - 1,
+			ReadList:      readList,
+			FullWriteList: env.depsMVFullWriteList,
+		}
+
+		// Send with timeout to prevent deadlock
+		select {
+		case chDeps <- temp:
+			// Successfully sent
+		case <-time.After(1 * time.Second):
+			// Timeout after 1 second - channel is blocked
+			log.Error("Transaction dependency channel blocked, aborting block building",
+				"txIndex", env.tcount-1,
+				"blockNumber", env.header.Number.Uint64())
+			once.Do(func() {
+				close(chDeps)
+			})
+
+			return errors.New("dependency channel timeout")
+		}
+
+		return nil
+	}
+
+	// Speculatively execute the plain transactions in parallel if enabled, the
+	// remaining transactions are committed one at a time below
+	if w.config.SpeculativeBuilding && w.IsRunning() {
+		var deps depsRecorder
+		if EnableMVHashMap {
+			deps = recordDeps
+		}
+
+		logs, err := w.commitSpeculativeTransactions(env, plainTxs, interrupt, minTip, deps)
+		coalescedLogs = append(coalescedLogs, logs...)
+
+		if err != nil {
+			return err
+		}
+	}
+
 	var lastTxHash common.Hash
 
 mainloop:
@@ -978,7 +1104,7 @@ mainloop:
 		}
 
 		// Check for the flag to interrupt block building on timeout.
-		if w.interruptBlockBuilding.Load() {
+		if env.buildInterrupt.Load() {
 			txCommitInterruptCounter.Inc(1)
 			log.Debug("Block building interrupted due to timeout, aborting new transaction commits", "hash", lastTxHash)
 			break mainloop
@@ -1000,7 +1126,7 @@ mainloop:
 
 		var (
 			ltx *txpool.LazyTransaction
-			txs *transactionsByPriceAndNonce
+			txs TransactionOrder
 		)
 		pltx, ptip := plainTxs.Peek()
 		bltx, btip := blobTxs.Peek()
@@ -1049,21 +1175,21 @@ mainloop:
 		//nolint:nestif
 		if options := tx.GetOptions(); options != nil {
 			if err := env.header.ValidateBlockNumberOptionsPIP15(options.BlockNumberMin, options.BlockNumberMax); err != nil {
-				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Hash(), "reason", err)
+				w.rejectConditional(env, from, tx, core.ConditionalBlockNumber, err)
 				txs.Pop()
 
 				continue
 			}
 
 			if err := env.header.ValidateTimestampOptionsPIP15(options.TimestampMin, options.TimestampMax); err != nil {
-				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Hash(), "reason", err)
+				w.rejectConditional(env, from, tx, core.ConditionalTimestamp, err)
 				txs.Pop()
 
 				continue
 			}
 
 			if err := env.state.ValidateKnownAccounts(options.KnownAccounts); err != nil {
-				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Hash(), "reason", err)
+				w.rejectConditional(env, from, tx, core.ConditionalKnownAccounts, err)
 				txs.Pop()
 
 				continue
@@ -1089,6 +1215,7 @@ mainloop:
 		case errors.Is(err, core.ErrNonceTooLow):
 			// New head notification data race between the transaction pool and miner, shift
 			log.Trace("Skipping transaction with low nonce", "hash", ltx.Hash, "sender", from, "nonce", tx.Nonce())
+			w.timeline.reject(env.header.Number.Uint64(), ltx.Hash, err)
 			txs.Shift()
 
 		case errors.Is(err, nil):
@@ -1097,33 +1224,8 @@ mainloop:
 			env.tcount++
 
 			if EnableMVHashMap && w.IsRunning() {
-				env.depsMVFullWriteList = append(env.depsMVFullWriteList, env.state.MVFullWriteList())
-				env.mvReadMapList = append(env.mvReadMapList, env.state.MVReadMap())
-
-				if env.tcount > len(env.depsMVFullWriteList) {
-					log.Warn("blockstm - env.tcount > len(env.depsMVFullWriteList)", "env.tcount", env.tcount, "len(depsMVFullWriteList)", len(env.depsMVFullWriteList))
-					return errors.New("transaction count exceeds dependency list length")
-				}
-
-				temp := blockstm.TxDep{
-					Index:         env.tcount - 1,
-					ReadList:      env.state.MVReadList(),
-					FullWriteList: env.depsMVFullWriteList,
-				}
-
-				// Send with timeout to prevent deadlock
-				select {
-				case chDeps <- temp:
-					// Successfully sent
-				case <-time.After(1 * time.Second):
-					// Timeout after 1 second - channel is blocked
-					log.Error("Transaction dependency channel blocked, aborting block building",
-						"txIndex", env.tcount-1,
-						"blockNumber", env.header.Number.Uint64())
-					once.Do(func() {
-						close(chDeps)
-					})
-					return errors.New("dependency channel timeout")
+				if err := recordDeps(env.state.MVReadList(), env.state.MVReadMap(), env.state.MVFullWriteList()); err != nil {
+					return err
 				}
 			}
 
@@ -1133,6 +1235,7 @@ mainloop:
 			// Transaction is regarded as invalid, drop all consecutive transactions from
 			// the same sender because of `nonce-too-high` clause.
 			log.Debug("Transaction failed, account skipped", "hash", ltx.Hash, "err", err)
+			w.timeline.reject(env.header.Number.Uint64(), ltx.Hash, err)
 			txs.Pop()
 		}
 
@@ -1255,6 +1358,14 @@ func (w *worker) prepareWork(genParams *generateParams, witness bool) (*environm
 
 		parent = block.Header()
 	}
+
+	return w.prepareWorkOn(w.chain, parent, nil, genParams, witness)
+}
+
+// prepareWorkOn constructs the sealing task on top of the given parent, whose state is
+// retrieved from the database unless given. The header is prepared and executed within
+// the given chain. It assumes the worker lock is held.
+func (w *worker) prepareWorkOn(chain workChain, parent *types.Header, base *state.StateDB, genParams *generateParams, witness bool) (*environment, error) {
 	// Sanity check the timestamp correctness, recap the timestamp
 	// to parent+1 if the mutation is allowed.
 	timestamp := genParams.timestamp
@@ -1295,7 +1406,7 @@ func (w *worker) prepareWork(genParams *generateParams, witness bool) (*environm
 	header.ParentBeaconRoot = nil
 
 	// Run the consensus preparation with the default or customized consensus engine.
-	if err := w.engine.Prepare(w.chain, header); err != nil {
+	if err := w.engine.Prepare(chain, header); err != nil {
 		switch err.(type) {
 		case *bor.UnauthorizedSignerError:
 			log.Debug("Failed to prepare header for sealing", "err", err)
@@ -1308,19 +1419,19 @@ func (w *worker) prepareWork(genParams *generateParams, witness bool) (*environm
 	// Could potentially happen if starting to mine in an odd state.
 	// Note genParams.coinbase can be different with header.Coinbase
 	// since clique algorithm can modify the coinbase field in header.
-	env, err := w.makeEnv(parent, header, genParams.coinbase, witness)
+	env, err := w.makeEnv(chain, parent, base, header, genParams.coinbase, witness)
 	if err != nil {
 		log.Error("Failed to create sealing context", "err", err)
 		return nil, err
 	}
 	if header.ParentBeaconRoot != nil {
-		context := core.NewEVMBlockContext(header, w.chain, nil)
+		context := core.NewEVMBlockContext(header, chain, nil)
 		vmenv := vm.NewEVM(context, env.state, w.chainConfig, vm.Config{})
 		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, vmenv)
 	}
 	if w.chainConfig.IsPrague(header.Number) {
 		// EIP-2935
-		context := core.NewEVMBlockContext(header, w.chain, nil)
+		context := core.NewEVMBlockContext(header, chain, nil)
 		vmenv := vm.NewEVM(context, env.state, w.chainConfig, vm.Config{})
 		core.ProcessParentBlockHash(header.ParentHash, vmenv)
 	}
@@ -1349,10 +1460,10 @@ func (w *worker) fillTransactions(interrupt *atomic.Int32, env *environment) err
 	}
 
 	filter.OnlyPlainTxs, filter.OnlyBlobTxs = true, false
-	pendingPlainTxs := w.eth.TxPool().Pending(filter, &w.interruptBlockBuilding)
+	pendingPlainTxs := w.eth.TxPool().Pending(filter, env.buildInterrupt)
 
 	filter.OnlyPlainTxs, filter.OnlyBlobTxs = false, true
-	pendingBlobTxs := w.eth.TxPool().Pending(filter, &w.interruptBlockBuilding)
+	pendingBlobTxs := w.eth.TxPool().Pending(filter, env.buildInterrupt)
 
 	// Split the pending transactions into locals and remotes.
 	prioPlainTxs, normalPlainTxs := make(map[common.Address][]*txpool.LazyTransaction), pendingPlainTxs
@@ -1371,8 +1482,8 @@ func (w *worker) fillTransactions(interrupt *atomic.Int32, env *environment) err
 
 	// Fill the block with all available pending transactions.
 	if len(prioPlainTxs) > 0 || len(prioBlobTxs) > 0 {
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, prioPlainTxs, env.header.BaseFee, &w.interruptBlockBuilding)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, prioBlobTxs, env.header.BaseFee, &w.interruptBlockBuilding)
+		plainTxs := w.ordering(env.signer, prioPlainTxs, env.header, env.buildInterrupt)
+		blobTxs := w.ordering(env.signer, prioBlobTxs, env.header, env.buildInterrupt)
 
 		if err := w.commitTransactions(env, plainTxs, blobTxs, interrupt, new(uint256.Int)); err != nil {
 			return err
@@ -1380,8 +1491,8 @@ func (w *worker) fillTransactions(interrupt *atomic.Int32, env *environment) err
 	}
 	if len(normalPlainTxs) > 0 || len(normalBlobTxs) > 0 {
 		heapInitTime := time.Now()
-		plainTxs := newTransactionsByPriceAndNonce(env.signer, normalPlainTxs, env.header.BaseFee, &w.interruptBlockBuilding)
-		blobTxs := newTransactionsByPriceAndNonce(env.signer, normalBlobTxs, env.header.BaseFee, &w.interruptBlockBuilding)
+		plainTxs := w.ordering(env.signer, normalPlainTxs, env.header, env.buildInterrupt)
+		blobTxs := w.ordering(env.signer, normalBlobTxs, env.header, env.buildInterrupt)
 		txHeapInitTimer.Update(time.Since(heapInitTime))
 
 		if err := w.commitTransactions(env, plainTxs, blobTxs, interrupt, new(uint256.Int)); err != nil {
@@ -1480,6 +1591,24 @@ func (w *worker) commitWork(interrupt *atomic.Int32, noempty bool, timestamp int
 		}
 	}
 
+	// Submit the block built in advance during the sealing delay of its parent, if any
+	if work = w.takePipelinedWork(w.chain.CurrentBlock(), coinbase); work != nil {
+		number := work.header.Number.Uint64()
+
+		w.timeline.begin(number, start)
+		w.timeline.record(number, timelinePipeline, 0, "submitted")
+
+		_ = w.commit(work.copy(), w.fullTaskHook, true, start)
+
+		if w.current != nil {
+			w.current.discard()
+		}
+
+		w.current = work
+
+		return
+	}
+
 	work, err = w.prepareWork(&generateParams{
 		timestamp: uint64(timestamp),
 		coinbase:  coinbase,
@@ -1489,6 +1618,12 @@ func (w *worker) commitWork(interrupt *atomic.Int32, noempty bool, timestamp int
 		return
 	}
 
+	number := work.header.Number.Uint64()
+	if w.IsRunning() {
+		w.timeline.begin(number, start)
+		w.timeline.prepare(work.header)
+	}
+
 	stopFn := func() {}
 	defer func() {
 		stopFn()
@@ -1496,7 +1631,9 @@ func (w *worker) commitWork(interrupt *atomic.Int32, noempty bool, timestamp int
 
 	if !noempty && w.interruptCommitFlag {
 		// Start the timer for block building
-		stopFn = createInterruptTimer(work.header.Number.Uint64(), work.header.Time, &w.interruptBlockBuilding)
+		stopFn = createInterruptTimer(number, work.header.Time, work.buildInterrupt, func() {
+			w.timeline.record(number, timelineInterrupt, 0, "")
+		})
 	}
 
 	// Create an empty block based on temporary copied state for
@@ -1548,8 +1685,9 @@ func (w *worker) commitWork(interrupt *atomic.Int32, noempty bool, timestamp int
 }
 
 // createInterruptTimer creates and starts a timer based on the header's timestamp for block building
-// and toggles the flag when the timer expires.
-func createInterruptTimer(number, timestamp uint64, interruptBlockBuilding *atomic.Bool) func() {
+// and toggles the flag when the timer expires. The optional timeout callback is invoked if the timer
+// expires before being stopped.
+func createInterruptTimer(number, timestamp uint64, interruptBlockBuilding *atomic.Bool, timeout func()) func() {
 	delay := time.Until(time.Unix(int64(timestamp), 0))
 	interruptCtx, cancel := context.WithTimeout(context.Background(), delay)
 
@@ -1567,6 +1705,10 @@ func createInterruptTimer(number, timestamp uint64, interruptBlockBuilding *atom
 		if interruptCtx.Err() != context.Canceled {
 			log.Info("Block building interrupted due to timeout", "block", number)
 			cancel()
+
+			if timeout != nil {
+				timeout()
+			}
 		}
 	}()
 
@@ -1586,14 +1728,25 @@ func (w *worker) commit(env *environment, interval func(), update bool, start ti
 		// https://github.com/ethereum/go-ethereum/issues/24299
 		env := env.copy()
 		// Withdrawals are set to nil here, because this is only called in PoW.
+		finalizeStart := time.Now()
+
 		block, err := w.engine.FinalizeAndAssemble(w.chain, env.header, env.state, &types.Body{
 			Transactions: env.txs,
 		}, env.receipts)
 
 		if err != nil {
+			w.timeline.record(env.header.Number.Uint64(), timelineFinalize, time.Since(finalizeStart), err.Error())
 			return err
 		}
 
+		w.timeline.record(block.NumberU64(), timelineFinalize, time.Since(finalizeStart), fmt.Sprintf("%d txs", env.tcount))
+
+		if bor, ok := w.engine.(*bor.Bor); ok {
+			if elapsed, ok := bor.StateSyncCommitTime(block.NumberU64()); ok {
+				w.timeline.record(block.NumberU64(), timelineStateSync, elapsed, "")
+			}
+		}
+
 		select {
 		case w.taskCh <- &task{receipts: env.receipts, state: env.state, block: block, createdAt: time.Now()}:
 			fees := totalFees(block, env.receipts)
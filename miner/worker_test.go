@@ -54,16 +54,33 @@ import (
 
 // nolint : paralleltest
 func TestGenerateBlockAndImportClique(t *testing.T) {
-	testGenerateBlockAndImport(t, true, false)
+	testGenerateBlockAndImport(t, true, false, DefaultTestConfig())
 }
 
 // nolint : paralleltest
 func TestGenerateBlockAndImportBor(t *testing.T) {
-	testGenerateBlockAndImport(t, false, true)
+	testGenerateBlockAndImport(t, false, true, DefaultTestConfig())
+}
+
+// nolint : paralleltest
+func TestGenerateBlockAndImportBorSpeculative(t *testing.T) {
+	config := DefaultTestConfig()
+	config.SpeculativeBuilding = true
+	config.SpeculativeProcs = 4
+
+	testGenerateBlockAndImport(t, false, true, config)
+}
+
+// nolint : paralleltest
+func TestGenerateBlockAndImportBorPipelined(t *testing.T) {
+	config := DefaultTestConfig()
+	config.PipelinedBuilding = true
+
+	testGenerateBlockAndImport(t, false, true, config)
 }
 
 //nolint:thelper
-func testGenerateBlockAndImport(t *testing.T, isClique bool, isBor bool) {
+func testGenerateBlockAndImport(t *testing.T, isClique bool, isBor bool, config *Config) {
 	var (
 		engine      consensus.Engine
 		chainConfig params.ChainConfig
@@ -89,7 +106,7 @@ func testGenerateBlockAndImport(t *testing.T, isClique bool, isBor bool) {
 
 	defer engine.Close()
 
-	w, b, _ := newTestWorker(t, DefaultTestConfig(), &chainConfig, engine, db, false, 0)
+	w, b, _ := newTestWorker(t, config, &chainConfig, engine, db, false, 0)
 	defer w.close()
 
 	// This test chain imports the mined blocks.
@@ -225,6 +242,10 @@ type testWorkerBackend struct {
 }
 
 func newTestWorkerBackend(t TensingObject, chainConfig *params.ChainConfig, engine consensus.Engine, db ethdb.Database) *testWorkerBackend {
+	return newTestWorkerBackendWithCache(t, chainConfig, engine, db, &core.CacheConfig{TrieDirtyDisabled: true})
+}
+
+func newTestWorkerBackendWithCache(t TensingObject, chainConfig *params.ChainConfig, engine consensus.Engine, db ethdb.Database, cacheConfig *core.CacheConfig) *testWorkerBackend {
 	var gspec = &core.Genesis{
 		Config: chainConfig,
 		Alloc:  types.GenesisAlloc{testBankAddress: {Balance: testBankFunds}},
@@ -247,7 +268,7 @@ func newTestWorkerBackend(t TensingObject, chainConfig *params.ChainConfig, engi
 		t.Fatalf("unexpected consensus engine type: %T", engine)
 	}
 	// genesis := gspec.MustCommit(db)
-	chain, err := core.NewBlockChain(db, &core.CacheConfig{TrieDirtyDisabled: true}, gspec, nil, engine, vm.Config{}, nil, nil, nil)
+	chain, err := core.NewBlockChain(db, cacheConfig, gspec, nil, engine, vm.Config{}, nil, nil, nil)
 	if err != nil {
 		t.Fatalf("core.NewBlockChain failed: %v", err)
 	}
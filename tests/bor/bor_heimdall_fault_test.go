@@ -0,0 +1,508 @@
+//go:build integration
+
+package bor
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/fdlimit"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdallfake"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+const (
+	// faultMilestoneLength is the minimum number of blocks covered by the milestones
+	// the fake heimdall proposes for the chain the validators agree on
+	faultMilestoneLength = 4
+
+	// faultMilestoneDepth is the number of blocks the end of a proposed milestone lags
+	// behind the head the validators agree on
+	faultMilestoneDepth = 2
+
+	// faultTimeout is the time the network is given to reach a block or a finality
+	// before the liveness invariant is considered broken
+	faultTimeout = 3 * time.Minute
+)
+
+// faultNetwork is an in-process network of bor validators sharing a fake heimdall. It
+// proposes milestones for the chain the validators agree on, as heimdall would, while
+// scenarios inject faults into the fake, and checks the finality invariants of the
+// validators all along:
+//   - the milestone whitelisted by a validator never goes backwards
+//   - the milestone whitelisted by a validator is part of its canonical chain, unless
+//     it's divergent, heimdall being authoritative
+//   - a divergent milestone is only whitelisted once the validator recorded the finality
+//     incident it caused, and is never part of its canonical chain
+//
+// The liveness invariants are checked by waiting for the network to reach a block or a
+// finality within faultTimeout.
+type faultNetwork struct {
+	t        *testing.T
+	genesis  *core.Genesis
+	heimdall *heimdallfake.FakeHeimdall
+	nodes    []*eth.Ethereum
+
+	validators []*valset.Validator // Validators of the spans added to the fake heimdall
+
+	lastMilestone uint64                   // End block of the latest milestone proposed
+	lastHash      common.Hash              // End block hash of the latest milestone proposed
+	diverge       bool                     // Whether the next milestone proposed is divergent
+	finalized     []uint64                 // End block of the milestone whitelisted per validator
+	divergent     map[common.Hash]struct{} // Hashes of the divergent milestones proposed
+}
+
+// newFaultNetwork starts a validator for every given key, all of them in the first
+// spans of the fake heimdall, and starts mining.
+func newFaultNetwork(t *testing.T, keys []*ecdsa.PrivateKey) *faultNetwork {
+	t.Helper()
+
+	genesis := InitGenesis(t, nil, "./testdata/genesis_2val.json", 8)
+	genesis.Config.Bor.StateSyncConfirmationDelay = map[string]uint64{"0": 128}
+	chainID := genesis.Config.ChainID.String()
+
+	validators := make([]*valset.Validator, 0, len(keys))
+	for i, key := range keys {
+		validators = append(validators, &valset.Validator{
+			ID:          uint64(i + 1),
+			Address:     crypto.PubkeyToAddress(key.PublicKey),
+			VotingPower: 10,
+		})
+	}
+
+	heimdall := heimdallfake.NewFakeHeimdall()
+	heimdall.AddSpan(heimdallfake.NewSpan(0, 0, 255, chainID, validators))
+	heimdall.AddSpan(heimdallfake.NewSpan(1, 256, 6655, chainID, validators))
+
+	n := &faultNetwork{
+		t:          t,
+		genesis:    genesis,
+		heimdall:   heimdall,
+		validators: validators,
+		finalized:  make([]uint64, len(keys)),
+		divergent:  make(map[common.Hash]struct{}),
+	}
+
+	var enodes []*enode.Node
+
+	for _, key := range keys {
+		stack, ethBackend, err := initMiner(genesis, key, true, func(ethBackend *eth.Ethereum) {
+			engine := ethBackend.Engine().(*bor.Bor)
+			engine.SetHeimdallClient(heimdall)
+			engine.HeimdallWSClient = heimdall.NewWSClient()
+		})
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			stack.Close()
+		})
+
+		for stack.Server().NodeInfo().Ports.Listener == 0 {
+			time.Sleep(250 * time.Millisecond)
+		}
+
+		// Connect the node to all the previous ones
+		for _, enode := range enodes {
+			stack.Server().AddPeer(enode)
+		}
+
+		n.nodes = append(n.nodes, ethBackend)
+		enodes = append(enodes, stack.Server().Self())
+	}
+
+	// Let the validators connect to each other before mining
+	time.Sleep(3 * time.Second)
+
+	for _, node := range n.nodes {
+		require.NoError(t, node.StartMining())
+	}
+
+	return n
+}
+
+// head returns the lowest head of the validators.
+func (n *faultNetwork) head() uint64 {
+	var head uint64
+
+	for i, node := range n.nodes {
+		if number := node.BlockChain().CurrentBlock().Number.Uint64(); i == 0 || number < head {
+			head = number
+		}
+	}
+
+	return head
+}
+
+// commonHead returns the highest block the canonical chains of all the validators
+// agree on.
+func (n *faultNetwork) commonHead() (uint64, common.Hash) {
+	for number := n.head(); number > 0; number-- {
+		hash := n.nodes[0].BlockChain().GetCanonicalHash(number)
+
+		agreed := true
+
+		for _, node := range n.nodes[1:] {
+			if node.BlockChain().GetCanonicalHash(number) != hash {
+				agreed = false
+				break
+			}
+		}
+
+		if agreed {
+			return number, hash
+		}
+	}
+
+	return 0, n.nodes[0].BlockChain().Genesis().Hash()
+}
+
+// proposeMilestone adds a milestone for the chain the validators agree on to the fake
+// heimdall, once there are enough blocks past the previous one. If a divergent milestone
+// was requested, the milestone conflicts with the chain instead.
+func (n *faultNetwork) proposeMilestone() {
+	agreed, _ := n.commonHead()
+	if agreed < n.lastMilestone+faultMilestoneLength+faultMilestoneDepth {
+		return
+	}
+
+	end := agreed - faultMilestoneDepth
+	hash := n.nodes[0].BlockChain().GetCanonicalHash(end)
+
+	if n.diverge {
+		hash = crypto.Keccak256Hash([]byte(fmt.Sprintf("divergent-%d", end)))
+
+		n.divergent[hash] = struct{}{}
+		n.diverge = false
+	}
+
+	n.addMilestone(n.lastMilestone+1, end, hash)
+}
+
+func (n *faultNetwork) addMilestone(start, end uint64, hash common.Hash) {
+	n.heimdall.AddMilestone(&milestone.Milestone{
+		Proposer:    crypto.PubkeyToAddress(keys[0].PublicKey),
+		StartBlock:  start,
+		EndBlock:    end,
+		Hash:        hash,
+		BorChainID:  n.genesis.Config.ChainID.String(),
+		MilestoneID: fmt.Sprintf("fake-%d-%d", start, end),
+		Timestamp:   uint64(time.Now().Unix()),
+	})
+
+	n.lastMilestone, n.lastHash = end, hash
+}
+
+// checkFinality checks the finality invariants of every validator.
+func (n *faultNetwork) checkFinality() {
+	for i, node := range n.nodes {
+		exists, number, hash := node.Downloader().ChainValidator.GetWhitelistedMilestone()
+		if !exists {
+			require.Zero(n.t, n.finalized[i], "validator %d lost its whitelisted milestone", i)
+			continue
+		}
+
+		require.GreaterOrEqual(n.t, number, n.finalized[i], "finality of validator %d went backwards", i)
+
+		canonical := node.BlockChain().GetCanonicalHash(number)
+
+		if _, ok := n.divergent[hash]; ok {
+			require.NotEqual(n.t, hash, canonical, "divergent milestone %d is canonical on validator %d", number, i)
+			require.True(n.t, n.incidentRecorded(node, number, hash), "validator %d whitelisted divergent milestone %d without an incident", i, number)
+		} else if canonical != (common.Hash{}) {
+			require.Equal(n.t, hash, canonical, "milestone %d whitelisted by validator %d isn't canonical", number, i)
+		}
+
+		n.finalized[i] = number
+	}
+}
+
+// incidentRecorded returns whether the validator recorded a finality incident for the
+// milestone ending at the given block with the given hash.
+func (n *faultNetwork) incidentRecorded(node *eth.Ethereum, number uint64, hash common.Hash) bool {
+	for _, incident := range rawdb.ReadFinalityIncidents(node.ChainDb(), uint64(len(n.divergent))) {
+		if incident.EndBlock == number && incident.Expected == hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// finality returns the lowest end block of the milestones whitelisted by the validators.
+func (n *faultNetwork) finality() uint64 {
+	lowest := n.finalized[0]
+
+	for _, finalized := range n.finalized[1:] {
+		if finalized < lowest {
+			lowest = finalized
+		}
+	}
+
+	return lowest
+}
+
+// waitUntil drives the network until the given condition holds, failing the liveness
+// invariant if it doesn't within faultTimeout.
+func (n *faultNetwork) waitUntil(what string, cond func() bool) {
+	n.t.Helper()
+
+	deadline := time.Now().Add(faultTimeout)
+
+	for {
+		n.proposeMilestone()
+		n.checkFinality()
+
+		if cond() {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			n.t.Fatalf("liveness broken: %s not reached in %v (head %d, finality %d)", what, faultTimeout, n.head(), n.finality())
+		}
+
+		time.Sleep(250 * time.Millisecond)
+	}
+}
+
+// waitForBlock waits for all the validators to reach the given block.
+func (n *faultNetwork) waitForBlock(number uint64) {
+	n.t.Helper()
+
+	n.waitUntil(fmt.Sprintf("block %d", number), func() bool {
+		return n.head() >= number
+	})
+}
+
+// waitForFinality waits for all the validators to whitelist a milestone ending at the
+// given block or later.
+func (n *faultNetwork) waitForFinality(number uint64) {
+	n.t.Helper()
+
+	n.waitUntil(fmt.Sprintf("finality %d", number), func() bool {
+		return n.finality() >= number
+	})
+}
+
+// lastStateIDs returns the id of the last state-sync event committed at the head of
+// every validator.
+func (n *faultNetwork) lastStateIDs() []uint64 {
+	ids := make([]uint64, 0, len(n.nodes))
+
+	for _, node := range n.nodes {
+		head := node.BlockChain().CurrentBlock()
+
+		id, err := node.Engine().(*bor.Bor).GenesisContractsClient.LastStateId(nil, head.Number.Uint64(), head.Hash())
+		require.NoError(n.t, err)
+
+		ids = append(ids, id.Uint64())
+	}
+
+	return ids
+}
+
+// spanKnown returns whether all the validators have the span with the given id in their
+// span store.
+func (n *faultNetwork) spanKnown(id uint64) bool {
+	for _, node := range n.nodes {
+		var api *bor.API
+
+		for _, service := range node.Engine().APIs(node.BlockChain()) {
+			if borAPI, ok := service.Service.(*bor.API); ok {
+				api = borAPI
+			}
+		}
+
+		if _, err := api.GetSpan(id); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TestHeimdallFaults drives a network of validators through heimdall faults and checks
+// the network stays live and its finality safe, whatever heimdall does.
+func TestHeimdallFaults(t *testing.T) {
+	log.SetDefault(log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelWarn, true)))
+
+	_, err := fdlimit.Raise(2048)
+	require.NoError(t, err)
+
+	fetchMethods := []heimdallfake.Method{
+		heimdallfake.MethodGetSpan,
+		heimdallfake.MethodGetLatestSpan,
+		heimdallfake.MethodFetchCheckpoint,
+		heimdallfake.MethodFetchCheckpointCount,
+		heimdallfake.MethodFetchMilestone,
+		heimdallfake.MethodFetchMilestoneCount,
+	}
+
+	cases := []struct {
+		name     string
+		scenario func(t *testing.T, n *faultNetwork)
+	}{
+		{
+			// Blocks are produced while heimdall times out and milestones are whitelisted
+			// again once it's back
+			name: "heimdall timeouts",
+			scenario: func(t *testing.T, n *faultNetwork) {
+				n.waitForFinality(faultMilestoneLength)
+
+				n.heimdall.DisconnectWS()
+
+				for _, method := range fetchMethods {
+					n.heimdall.Inject(method, heimdallfake.Fault{Delay: time.Minute})
+				}
+
+				// State-sync events are fetched without a timeout while sealing, hence
+				// heimdall fails right away instead
+				n.heimdall.Inject(heimdallfake.MethodStateSyncEvents, heimdallfake.Fault{Err: heimdallfake.ErrUnavailable})
+
+				finality, head := n.finality(), n.head()
+				n.waitForBlock(head + 24)
+
+				require.Equal(t, finality, n.finality(), "finality advanced while heimdall is down")
+
+				n.heimdall.ClearAll()
+				n.heimdall.ReconnectWS()
+
+				n.waitForFinality(head + 16)
+			},
+		},
+		{
+			// Blocks are produced while heimdall serves a stale milestone and the withheld
+			// milestones are whitelisted once they're served
+			name: "stale milestones",
+			scenario: func(t *testing.T, n *faultNetwork) {
+				n.waitForFinality(faultMilestoneLength)
+
+				n.heimdall.StallMilestones()
+
+				stale, head := n.lastMilestone, n.head()
+				n.waitForBlock(head + 24)
+
+				require.LessOrEqual(t, n.finality(), stale, "finality advanced past the stale milestone")
+
+				n.heimdall.ResumeMilestones()
+
+				n.waitForFinality(head + 16)
+			},
+		},
+		{
+			// A milestone conflicting with the chain makes the validators record a finality
+			// incident and rewind to it, heimdall being authoritative, without ever making
+			// it part of their canonical chain
+			name: "divergent milestone",
+			scenario: func(t *testing.T, n *faultNetwork) {
+				n.waitForFinality(faultMilestoneLength)
+
+				n.diverge = true
+				n.waitUntil("divergent milestone", func() bool {
+					return !n.diverge
+				})
+
+				divergent, hash := n.lastMilestone, n.lastHash
+
+				n.waitUntil("finality incidents", func() bool {
+					for _, node := range n.nodes {
+						if !n.incidentRecorded(node, divergent, hash) {
+							return false
+						}
+					}
+
+					return true
+				})
+
+				// No one can serve the divergent milestone, hence the validators keep rewinding
+				// to it and refuse each other's chains, safety being favoured over liveness
+				deadline := time.Now().Add(30 * time.Second)
+				n.waitUntil("divergent milestone held", func() bool {
+					return time.Now().After(deadline)
+				})
+
+				require.Equal(t, divergent, n.finality(), "finality moved past the divergent milestone")
+			},
+		},
+		{
+			// State-sync events are committed in order of their ids only, a gap in the ids
+			// holding back the events past it until it's filled, whatever the order the
+			// events are announced in
+			name: "out of order state-sync ids",
+			scenario: func(t *testing.T, n *faultNetwork) {
+				sample := getSampleEventRecord(t)
+
+				for _, id := range []uint64{2, 1, 4} {
+					n.heimdall.AddEventRecord(buildStateEvent(sample, id, 1))
+				}
+
+				n.waitForBlock(n.head() + 16)
+
+				for i, id := range n.lastStateIDs() {
+					require.Equal(t, uint64(2), id, "validator %d committed state-sync events past the gap", i)
+				}
+
+				n.heimdall.AddEventRecord(buildStateEvent(sample, 3, 1))
+
+				n.waitUntil("state-sync event 4", func() bool {
+					for _, id := range n.lastStateIDs() {
+						if id != 4 {
+							return false
+						}
+					}
+
+					return true
+				})
+			},
+		},
+		{
+			// A gap in the spans holds back the prefetching of the spans past it without
+			// affecting the network, the spans being prefetched once the gap is filled
+			name: "span gap",
+			scenario: func(t *testing.T, n *faultNetwork) {
+				chainID := n.genesis.Config.ChainID.String()
+
+				span2 := heimdallfake.NewSpan(2, 6656, 13055, chainID, n.validators)
+				span3 := heimdallfake.NewSpan(3, 13056, 19455, chainID, n.validators)
+
+				n.heimdall.AddSpan(span3)
+
+				head := n.head()
+				n.waitForBlock(head + 16)
+
+				require.False(t, n.spanKnown(3), "span prefetched past the gap")
+
+				n.heimdall.AddSpan(span2)
+				n.heimdall.AddSpan(span3)
+
+				n.waitUntil("spans prefetched", func() bool {
+					return n.spanKnown(2) && n.spanKnown(3)
+				})
+
+				require.Positive(t, n.heimdall.Calls(heimdallfake.MethodGetSpan))
+
+				n.waitForFinality(head + 8)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			n := newFaultNetwork(t, keys)
+			tc.scenario(t, n)
+		})
+	}
+}
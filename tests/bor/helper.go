@@ -560,6 +560,12 @@ func InitGenesis(t *testing.T, faucets []*ecdsa.PrivateKey, fileLocation string,
 }
 
 func InitMiner(genesis *core.Genesis, privKey *ecdsa.PrivateKey, withoutHeimdall bool) (*node.Node, *eth.Ethereum, error) {
+	return initMiner(genesis, privKey, withoutHeimdall, nil)
+}
+
+// initMiner creates and starts a miner node. If given, beforeStart is called with the
+// backend once created, before the node is started.
+func initMiner(genesis *core.Genesis, privKey *ecdsa.PrivateKey, withoutHeimdall bool, beforeStart func(*eth.Ethereum)) (*node.Node, *eth.Ethereum, error) {
 	// Define the basic configurations for the Ethereum node
 	datadir, err := os.MkdirTemp("", "InitMiner-"+uuid.New().String())
 	if err != nil {
@@ -626,6 +632,10 @@ func InitMiner(genesis *core.Genesis, privKey *ecdsa.PrivateKey, withoutHeimdall
 	// proceed to authorize the local account manager in any case
 	ethBackend.AccountManager().AddBackend(kStore)
 
+	if beforeStart != nil {
+		beforeStart(ethBackend)
+	}
+
 	err = stack.Start()
 
 	return stack, ethBackend, err
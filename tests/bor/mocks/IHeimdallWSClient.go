@@ -49,6 +49,20 @@ func (mr *MockIHeimdallWSClientMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIHeimdallWSClient)(nil).Close))
 }
 
+// SubscribeCheckpointEvents mocks base method.
+func (m *MockIHeimdallWSClient) SubscribeCheckpointEvents(arg0 context.Context) <-chan uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeCheckpointEvents", arg0)
+	ret0, _ := ret[0].(<-chan uint64)
+	return ret0
+}
+
+// SubscribeCheckpointEvents indicates an expected call of SubscribeCheckpointEvents.
+func (mr *MockIHeimdallWSClientMockRecorder) SubscribeCheckpointEvents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeCheckpointEvents", reflect.TypeOf((*MockIHeimdallWSClient)(nil).SubscribeCheckpointEvents), arg0)
+}
+
 // SubscribeMilestoneEvents mocks base method.
 func (m *MockIHeimdallWSClient) SubscribeMilestoneEvents(arg0 context.Context) <-chan *milestone.Milestone {
 	m.ctrl.T.Helper()
@@ -63,6 +77,34 @@ func (mr *MockIHeimdallWSClientMockRecorder) SubscribeMilestoneEvents(arg0 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeMilestoneEvents", reflect.TypeOf((*MockIHeimdallWSClient)(nil).SubscribeMilestoneEvents), arg0)
 }
 
+// SubscribeSpanEvents mocks base method.
+func (m *MockIHeimdallWSClient) SubscribeSpanEvents(arg0 context.Context) <-chan uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeSpanEvents", arg0)
+	ret0, _ := ret[0].(<-chan uint64)
+	return ret0
+}
+
+// SubscribeSpanEvents indicates an expected call of SubscribeSpanEvents.
+func (mr *MockIHeimdallWSClientMockRecorder) SubscribeSpanEvents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeSpanEvents", reflect.TypeOf((*MockIHeimdallWSClient)(nil).SubscribeSpanEvents), arg0)
+}
+
+// SubscribeStateSyncEvents mocks base method.
+func (m *MockIHeimdallWSClient) SubscribeStateSyncEvents(arg0 context.Context) <-chan uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeStateSyncEvents", arg0)
+	ret0, _ := ret[0].(<-chan uint64)
+	return ret0
+}
+
+// SubscribeStateSyncEvents indicates an expected call of SubscribeStateSyncEvents.
+func (mr *MockIHeimdallWSClientMockRecorder) SubscribeStateSyncEvents(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeStateSyncEvents", reflect.TypeOf((*MockIHeimdallWSClient)(nil).SubscribeStateSyncEvents), arg0)
+}
+
 // Unsubscribe mocks base method.
 func (m *MockIHeimdallWSClient) Unsubscribe(arg0 context.Context) error {
 	m.ctrl.T.Helper()